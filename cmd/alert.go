@@ -0,0 +1,407 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/alerts"
+	"github.com/Carpe-Wang/terminalCrypto/internal/config"
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	alertWindow   time.Duration
+	alertInterval string
+	alertCooldown time.Duration
+	alertNote     string
+
+	alertRmAll bool
+
+	alertEvery   int
+	alertBell    bool
+	alertExec    []string
+	alertWebhook []string
+)
+
+// alertsPath returns the location of the alerts file
+func alertsPath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return alerts.Path(dir), nil
+}
+
+var alertCmd = &cobra.Command{
+	Use:   "alert",
+	Short: "Manage and run price alerts",
+	Long: `Manage price alerts stored in ~/.terminalcrypto/alerts.yaml and watch for them to fire.
+
+Conditions:
+  above <price>      the price is at or above a level
+  below <price>      the price is at or below a level
+  move <percent>     the price moves by a percentage either way within --window
+  cross-ema <period> the price crosses the EMA of --interval candles
+
+Examples:
+  terminalcrypto alert add BTC above 70000
+  terminalcrypto alert add ETH move 3 --window 1h
+  terminalcrypto alert add SOL cross-ema 50 --interval 4h
+  terminalcrypto alert list
+  terminalcrypto alert run --bell`,
+}
+
+var alertAddCmd = &cobra.Command{
+	Use:   "add [symbol] [condition] [value]",
+	Short: "Add a price alert",
+	Long: `Add a price alert for a symbol on the selected exchange.
+
+A level alert fires once when its level is reached and again only after the
+price has moved back and reached it anew, no sooner than --cooldown after it
+last fired. A move alert measures the next move from the price that fired it.
+
+Examples:
+  terminalcrypto alert add BTC above 70000
+  terminalcrypto alert add BTC below 60000 --cooldown 1h --note "buy zone"
+  terminalcrypto alert add ETH move 3 --window 1h
+  terminalcrypto --exchange okx alert add SOL cross-ema 50 --interval 4h`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+
+		value, err := strconv.ParseFloat(args[2], 64)
+		if err != nil {
+			return fmt.Errorf("invalid value %q: %w", args[2], err)
+		}
+
+		rule := alerts.Rule{
+			Exchange:  exchangeName,
+			Condition: alerts.Condition(strings.ToLower(args[1])),
+			Value:     value,
+			Cooldown:  alerts.Duration(alertCooldown),
+			Note:      alertNote,
+		}
+		switch rule.Condition {
+		case alerts.Move:
+			rule.Window = alerts.Duration(alertWindow)
+		case alerts.CrossEMA:
+			rule.Interval = alertInterval
+		}
+
+		// Catch mistakes before looking up the symbol
+		rule.Symbol = args[0]
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+
+		// Create exchange client
+		client, err := newExchangeClient()
+		if err != nil {
+			return err
+		}
+
+		if rule.Condition == alerts.CrossEMA && !client.Capabilities().SupportsInterval(rule.Interval) {
+			return &exchange.UnsupportedIntervalError{Exchange: client.GetName(), Interval: rule.Interval}
+		}
+
+		if rule.Symbol, err = resolveSymbol(ctx, client, args[0]); err != nil {
+			return err
+		}
+
+		path, err := alertsPath()
+		if err != nil {
+			return err
+		}
+
+		rule, err = alerts.Add(path, rule)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Added alert #%d on %s: %s\n", rule.ID, rule.Exchange, rule)
+		return nil
+	},
+}
+
+var alertListCmd = &cobra.Command{
+	Use:         "list",
+	Aliases:     []string{"ls"},
+	Short:       "List price alerts",
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{structuredOutputAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := alertsPath()
+		if err != nil {
+			return err
+		}
+
+		rules, err := alerts.Load(path)
+		if err != nil {
+			return err
+		}
+
+		if outputFormat != outputText {
+			records := make([]alertRecord, len(rules))
+			for i, r := range rules {
+				records[i] = alertRecord{r}
+			}
+			return writeRecords(os.Stdout, outputFormat, records)
+		}
+
+		if len(rules) == 0 {
+			fmt.Println("No alerts. Add one with 'terminalcrypto alert add BTC above 70000'.")
+			return nil
+		}
+
+		// Define styles
+		headerStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#FFFF00"))
+
+		idStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#00D4FF"))
+
+		labelStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#888888"))
+
+		fmt.Println(headerStyle.Render("\nPrice alerts:"))
+		fmt.Println(labelStyle.Render(fmt.Sprintf("%-5s %-10s %-40s %-10s %s", "ID", "Exchange", "Alert", "Cooldown", "Note")))
+		fmt.Println(strings.Repeat("─", 80))
+
+		for _, r := range rules {
+			cooldown := "-"
+			if r.Cooldown > 0 {
+				cooldown = r.Cooldown.String()
+			}
+			fmt.Printf("%s %-10s %-40s %-10s %s\n",
+				idStyle.Render(fmt.Sprintf("%-5s", "#"+strconv.Itoa(r.ID))),
+				r.Exchange,
+				r.String(),
+				cooldown,
+				labelStyle.Render(r.Note))
+		}
+
+		fmt.Println(strings.Repeat("─", 80))
+		fmt.Println(labelStyle.Render(fmt.Sprintf("%d alerts in %s", len(rules), path)))
+		fmt.Println()
+		return nil
+	},
+}
+
+// alertRecord is one alert in structured output
+type alertRecord struct {
+	alerts.Rule
+}
+
+func (r alertRecord) columns() []string {
+	return []string{"id", "exchange", "symbol", "condition", "value", "window", "interval", "cooldown", "note", "created_at"}
+}
+
+func (r alertRecord) values() []string {
+	var window, cooldown string
+	if r.Window > 0 {
+		window = r.Window.String()
+	}
+	if r.Cooldown > 0 {
+		cooldown = r.Cooldown.String()
+	}
+	return []string{
+		strconv.Itoa(r.ID),
+		r.Exchange,
+		r.Symbol,
+		string(r.Condition),
+		formatFloat(r.Value),
+		window,
+		r.Interval,
+		cooldown,
+		r.Note,
+		formatTime(r.CreatedAt),
+	}
+}
+
+var alertRmCmd = &cobra.Command{
+	Use:     "rm [id...]",
+	Aliases: []string{"remove"},
+	Short:   "Remove price alerts",
+	Long: `Remove price alerts by ID (see 'terminalcrypto alert list').
+
+Examples:
+  terminalcrypto alert rm 3
+  terminalcrypto alert rm 1 2 5
+  terminalcrypto alert rm --all`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if alertRmAll == (len(args) > 0) {
+			return fmt.Errorf("give the IDs of the alerts to remove, or --all")
+		}
+
+		path, err := alertsPath()
+		if err != nil {
+			return err
+		}
+
+		var removed []alerts.Rule
+		if alertRmAll {
+			if removed, err = alerts.Load(path); err != nil {
+				return err
+			}
+			err = alerts.Save(path, nil)
+		} else {
+			ids := make([]int, len(args))
+			for i, arg := range args {
+				if ids[i], err = strconv.Atoi(strings.TrimPrefix(arg, "#")); err != nil {
+					return fmt.Errorf("invalid alert ID %q", arg)
+				}
+			}
+			removed, err = alerts.Remove(path, ids...)
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, r := range removed {
+			fmt.Printf("Removed alert #%d: %s\n", r.ID, r)
+		}
+		return nil
+	},
+}
+
+var alertRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Watch prices and fire alerts",
+	Long: `Check every alert at a fixed interval and report the ones that fire until interrupted.
+
+Besides printing each alert, it can ring the terminal bell, run a command or
+post to a webhook. Commands receive the alert as JSON on stdin and in the
+ALERT_ID, ALERT_SYMBOL, ALERT_EXCHANGE, ALERT_CONDITION, ALERT_PRICE and
+ALERT_MESSAGE environment variables; webhooks receive the same JSON.
+
+Move alerts measure moves seen while running, so they need their window to
+pass before they can fire on a slow move.
+
+Examples:
+  terminalcrypto alert run
+  terminalcrypto alert run --bell --interval 30
+  terminalcrypto alert run --exec 'notify-send "$ALERT_MESSAGE"'
+  terminalcrypto alert run --webhook https://hooks.example.com/crypto`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		if alertEvery <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+
+		path, err := alertsPath()
+		if err != nil {
+			return err
+		}
+
+		rules, err := alerts.Load(path)
+		if err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return fmt.Errorf("no alerts to run; add one with 'terminalcrypto alert add BTC above 70000'")
+		}
+
+		// One client per exchange, created on first use
+		clients := make(map[string]exchange.Exchange)
+		clientFor := func(name string) (exchange.Exchange, error) {
+			if client, ok := clients[name]; ok {
+				return client, nil
+			}
+			client, err := newExchangeClientFor(name)
+			if err != nil {
+				return nil, err
+			}
+			clients[name] = client
+			return client, nil
+		}
+
+		notifiers := []alerts.Notifier{alerts.NotifierFunc(printAlert)}
+		if alertBell {
+			notifiers = append(notifiers, alerts.BellNotifier{W: os.Stdout})
+		}
+		for _, command := range alertExec {
+			notifiers = append(notifiers, alerts.CommandNotifier{Command: command})
+		}
+		for _, url := range alertWebhook {
+			notifiers = append(notifiers, alerts.WebhookNotifier{URL: url})
+		}
+
+		engine := alerts.NewEngine(rules, clientFor, notifiers...)
+
+		labelStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#888888"))
+
+		fmt.Println(labelStyle.Render(fmt.Sprintf("Watching %d alerts every %ds. Press Ctrl+C to stop.", len(rules), alertEvery)))
+
+		err = engine.Run(ctx, time.Duration(alertEvery)*time.Second, printAlertError)
+		if errors.Is(err, context.Canceled) {
+			return nil
+		}
+		return err
+	},
+}
+
+// printAlert writes a fired alert to stdout
+func printAlert(_ context.Context, event alerts.Event) error {
+	timeStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888888"))
+
+	idStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#00D4FF"))
+
+	messageStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFF00"))
+
+	line := fmt.Sprintf("%s %s %s",
+		timeStyle.Render(event.Time.Format("2006-01-02 15:04:05")),
+		idStyle.Render(fmt.Sprintf("#%d", event.Rule.ID)),
+		messageStyle.Render(event.Message))
+	if event.Rule.Note != "" {
+		line += timeStyle.Render(" — " + event.Rule.Note)
+	}
+
+	_, err := fmt.Println(line)
+	return err
+}
+
+// printAlertError writes a failure to evaluate or deliver an alert to stderr
+func printAlertError(err error) {
+	var ruleErr *alerts.RuleError
+	if errors.As(err, &ruleErr) {
+		fmt.Fprintf(os.Stderr, "Error: alert #%d (%s): %s\n", ruleErr.Rule.ID, ruleErr.Rule, describeError(ruleErr.Err))
+		return
+	}
+	fmt.Fprintln(os.Stderr, "Error:", describeError(err))
+}
+
+func init() {
+	rootCmd.AddCommand(alertCmd)
+	alertCmd.AddCommand(alertAddCmd, alertListCmd, alertRmCmd, alertRunCmd)
+
+	alertAddCmd.Flags().DurationVarP(&alertWindow, "window", "w", time.Hour, "period a move alert is measured over")
+	alertAddCmd.Flags().StringVarP(&alertInterval, "interval", "i", "1h", "candle interval of a cross-ema alert")
+	alertAddCmd.Flags().DurationVar(&alertCooldown, "cooldown", 5*time.Minute, "least time between two firings of the alert")
+	alertAddCmd.Flags().StringVar(&alertNote, "note", "", "text shown with the alert when it fires")
+
+	alertRmCmd.Flags().BoolVar(&alertRmAll, "all", false, "remove every alert")
+
+	alertRunCmd.Flags().IntVarP(&alertEvery, "interval", "i", 10, "seconds between checks")
+	alertRunCmd.Flags().BoolVar(&alertBell, "bell", false, "ring the terminal bell when an alert fires")
+	alertRunCmd.Flags().StringArrayVar(&alertExec, "exec", nil, "run a shell command when an alert fires (repeatable)")
+	alertRunCmd.Flags().StringArrayVar(&alertWebhook, "webhook", nil, "post fired alerts as JSON to a URL (repeatable)")
+}
@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/backtest"
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backtestStrategy string
+	backtestSymbol   string
+	backtestInterval string
+	backtestFrom     string
+	backtestTo       string
+	backtestCash     float64
+	backtestFee      float64
+	backtestSlippage float64
+	backtestEquity   bool
+
+	backtestFast       int
+	backtestSlow       int
+	backtestRSIPeriod  int
+	backtestOversold   float64
+	backtestOverbought float64
+)
+
+// backtestDefaultCandles is how far back a backtest without --from starts
+const backtestDefaultCandles = 1000
+
+// newStrategy creates the strategy selected with --strategy
+func newStrategy() (backtest.Strategy, error) {
+	switch backtestStrategy {
+	case "sma-cross":
+		return backtest.NewSMACross(backtestFast, backtestSlow)
+	case "rsi":
+		return backtest.NewRSIReversion(backtestRSIPeriod, backtestOversold, backtestOverbought)
+	default:
+		return nil, fmt.Errorf("unknown strategy %q (available: sma-cross, rsi)", backtestStrategy)
+	}
+}
+
+var backtestCmd = &cobra.Command{
+	Use:   "backtest",
+	Short: "Test a trading strategy on historical candles",
+	Long: `Replay historical candles through a trading strategy and report how a
+simulated account would have done: return against buy and hold, maximum
+drawdown, annualized Sharpe ratio and every trade.
+
+A strategy decides at each candle's close and its orders fill at the next
+candle's open, moved against it by --slippage and charged --fee. Every trade
+uses the whole account: all cash on a buy, the whole position on a sell.
+
+Strategies:
+  sma-cross   buy when the --fast SMA crosses above the --slow SMA, sell when it crosses below
+  rsi         buy when the RSI(--rsi-period) falls below --oversold, sell above --overbought
+
+--from and --to take the same formats as in 'terminalcrypto candles'; without
+--from the last 1000 candles are used. Structured output (--output) lists the
+trades, or the equity curve with --equity.
+
+Examples:
+  terminalcrypto backtest --strategy sma-cross --symbol BTC --interval 1h --from 2026-01-01
+  terminalcrypto backtest -s sma-cross --fast 10 --slow 30 --symbol ETH -i 4h --from 2025-06-01
+  terminalcrypto backtest -s rsi --symbol SOL -i 1h --fee 0.00075 --slippage 0.001
+  terminalcrypto backtest --symbol BTC --from 2026-01-01 --equity -o csv > equity.csv`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{structuredOutputAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		strategy, err := newStrategy()
+		if err != nil {
+			return err
+		}
+
+		// Create exchange client
+		client, err := newExchangeClient()
+		if err != nil {
+			return err
+		}
+
+		step, ok := exchange.IntervalDuration(backtestInterval)
+		if !ok || !client.Capabilities().SupportsInterval(backtestInterval) {
+			return &exchange.UnsupportedIntervalError{Exchange: client.GetName(), Interval: backtestInterval}
+		}
+
+		to := time.Now().UTC()
+		if backtestTo != "" {
+			if to, err = parseTimeFlag("to", backtestTo); err != nil {
+				return err
+			}
+		}
+
+		from := to.Add(-step * backtestDefaultCandles)
+		if backtestFrom != "" {
+			if from, err = parseTimeFlag("from", backtestFrom); err != nil {
+				return err
+			}
+		}
+
+		if !from.Before(to) {
+			return fmt.Errorf("--from (%s) must be before --to (%s)", from.Format(time.RFC3339), to.Format(time.RFC3339))
+		}
+
+		symbol, err := resolveSymbol(ctx, client, backtestSymbol)
+		if err != nil {
+			return err
+		}
+
+		candles, err := exchange.FetchCandleHistory(ctx, client, symbol, backtestInterval, from, to)
+		if err != nil {
+			return err
+		}
+
+		result, err := backtest.Run(candles, strategy, backtest.Config{
+			InitialCash:    backtestCash,
+			FeeRate:        backtestFee,
+			Slippage:       backtestSlippage,
+			PeriodsPerYear: float64(365*24*time.Hour) / float64(step),
+		})
+		if err != nil {
+			return err
+		}
+
+		normalizedSymbol := client.NormalizeSymbol(symbol)
+		switch {
+		case outputFormat == outputText:
+			printBacktest(client.GetName(), normalizedSymbol, backtestInterval, result)
+			return nil
+		case backtestEquity:
+			records := make([]equityRecord, len(result.Equity))
+			for i, p := range result.Equity {
+				records[i] = equityRecord{Symbol: normalizedSymbol, EquityPoint: p}
+			}
+			return writeRecords(os.Stdout, outputFormat, records)
+		default:
+			records := make([]backtestTradeRecord, len(result.Trades))
+			for i, t := range result.Trades {
+				records[i] = backtestTradeRecord{Strategy: result.Strategy, Symbol: normalizedSymbol, Trade: t}
+			}
+			return writeRecords(os.Stdout, outputFormat, records)
+		}
+	},
+}
+
+// backtestTradeRecord is one simulated trade in structured output
+type backtestTradeRecord struct {
+	Strategy string `json:"strategy"`
+	Symbol   string `json:"symbol"`
+	backtest.Trade
+}
+
+func (r backtestTradeRecord) columns() []string {
+	return []string{"strategy", "symbol", "time", "side", "price", "quantity", "value", "fee", "pnl"}
+}
+
+func (r backtestTradeRecord) values() []string {
+	return []string{
+		r.Strategy,
+		r.Symbol,
+		formatTime(r.Time),
+		string(r.Side),
+		formatFloat(r.Price),
+		formatFloat(r.Quantity),
+		formatFloat(r.Value),
+		formatFloat(r.Fee),
+		formatFloat(r.PnL),
+	}
+}
+
+// equityRecord is one point of the equity curve in structured output
+type equityRecord struct {
+	Symbol string `json:"symbol"`
+	backtest.EquityPoint
+}
+
+func (r equityRecord) columns() []string {
+	return []string{"symbol", "time", "price", "equity"}
+}
+
+func (r equityRecord) values() []string {
+	return []string{r.Symbol, formatTime(r.Time), formatFloat(r.Price), formatFloat(r.Equity)}
+}
+
+// printBacktest writes a backtest summary and its trades
+func printBacktest(exchangeName, symbol, interval string, r *backtest.Result) {
+	// Define styles
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFF00"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888888"))
+
+	valueStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFFFF"))
+
+	positiveStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#00FF87"))
+
+	negativeStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF0087"))
+
+	// signed colours a percentage or amount by its sign
+	signed := func(text string, v float64) string {
+		if v < 0 {
+			return negativeStyle.Render(text)
+		}
+		return positiveStyle.Render(text)
+	}
+
+	fmt.Println(headerStyle.Render(fmt.Sprintf("\nBacktest of %s on %s %s (%s):", r.Strategy, symbol, interval, strings.ToUpper(exchangeName))))
+	fmt.Println(labelStyle.Render(fmt.Sprintf("%s → %s, %d candles",
+		r.Start.Local().Format("2006-01-02 15:04"), r.End.Local().Format("2006-01-02 15:04"), len(r.Equity))))
+	fmt.Println(strings.Repeat("─", 60))
+
+	row := func(label, value string) {
+		fmt.Printf("%s %s\n", labelStyle.Render(fmt.Sprintf("%-16s", label)), value)
+	}
+
+	winRate := "-"
+	if rate := r.WinRate(); !math.IsNaN(rate) {
+		winRate = fmt.Sprintf("%.1f%%", rate)
+	}
+
+	row("Start equity", valueStyle.Render(formatAmount(r.InitialCash)))
+	row("End equity", valueStyle.Render(formatAmount(r.FinalEquity)))
+	row("Return", signed(fmt.Sprintf("%+.2f%%", r.Return), r.Return))
+	row("Buy and hold", signed(fmt.Sprintf("%+.2f%%", r.BuyAndHold), r.BuyAndHold))
+	row("Max drawdown", negativeStyle.Render(fmt.Sprintf("%.2f%%", r.MaxDrawdown)))
+	row("Sharpe", fmt.Sprintf("%.2f", r.Sharpe))
+	row("Trades", fmt.Sprintf("%d (win rate %s)", len(r.Trades), winRate))
+	row("Fees", formatAmount(r.Fees))
+
+	if len(r.Trades) == 0 {
+		fmt.Println()
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(labelStyle.Render(fmt.Sprintf("%-16s %-5s %14s %16s %14s %14s", "Time", "Side", "Price", "Quantity", "Value", "P&L")))
+	fmt.Println(strings.Repeat("─", 84))
+
+	for _, t := range r.Trades {
+		side := positiveStyle.Render(fmt.Sprintf("%-5s", t.Side))
+		pnl := fmt.Sprintf("%14s", "")
+		if t.Side == models.SideSell {
+			side = negativeStyle.Render(fmt.Sprintf("%-5s", t.Side))
+			pnl = signed(fmt.Sprintf("%14s", formatSignedAmount(t.PnL)), t.PnL)
+		}
+
+		fmt.Printf("%-16s %s %14s %16s %14s %s\n",
+			t.Time.Local().Format("2006-01-02 15:04"),
+			side,
+			formatAmount(t.Price),
+			formatQuantity(t.Quantity),
+			formatAmount(t.Value),
+			pnl)
+	}
+
+	fmt.Println(strings.Repeat("─", 84))
+	fmt.Println()
+}
+
+func init() {
+	rootCmd.AddCommand(backtestCmd)
+
+	backtestCmd.Flags().StringVarP(&backtestStrategy, "strategy", "s", "sma-cross", "strategy to test: sma-cross or rsi")
+	backtestCmd.Flags().StringVar(&backtestSymbol, "symbol", "", "symbol to trade, e.g. BTC or ETH/USDT")
+	backtestCmd.Flags().StringVarP(&backtestInterval, "interval", "i", "1h", "candle interval (e.g. 15m, 1h, 4h, 1d)")
+	backtestCmd.Flags().StringVar(&backtestFrom, "from", "", "start of the test (default 1000 candles before --to)")
+	backtestCmd.Flags().StringVar(&backtestTo, "to", "", "end of the test (default now)")
+	backtestCmd.Flags().Float64Var(&backtestCash, "cash", 10000, "starting cash in the quote currency")
+	backtestCmd.Flags().Float64Var(&backtestFee, "fee", 0.001, "fee per fill as a fraction of its value")
+	backtestCmd.Flags().Float64Var(&backtestSlippage, "slippage", 0.0005, "price slippage per fill as a fraction of the price")
+	backtestCmd.Flags().BoolVar(&backtestEquity, "equity", false, "write the equity curve instead of the trades in structured output")
+
+	backtestCmd.Flags().IntVar(&backtestFast, "fast", 20, "fast SMA period (sma-cross)")
+	backtestCmd.Flags().IntVar(&backtestSlow, "slow", 50, "slow SMA period (sma-cross)")
+	backtestCmd.Flags().IntVar(&backtestRSIPeriod, "rsi-period", 14, "RSI period (rsi)")
+	backtestCmd.Flags().Float64Var(&backtestOversold, "oversold", 30, "RSI level to buy below (rsi)")
+	backtestCmd.Flags().Float64Var(&backtestOverbought, "overbought", 70, "RSI level to sell above (rsi)")
+
+	backtestCmd.MarkFlagRequired("symbol")
+}
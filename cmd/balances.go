@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/config"
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var balancesMinValue float64
+
+var balancesCmd = &cobra.Command{
+	Use:   "balances",
+	Short: "Show the balances of your exchange account",
+	Long: `Show the free and locked amount of every asset in your account on the
+selected exchange, valued at live prices in display.currency (default USDT).
+Requires API credentials stored with 'terminalcrypto setup'; read-only keys
+are enough.
+
+Examples:
+  terminalcrypto balances
+  terminalcrypto --exchange coinbase balances
+  terminalcrypto balances --min-value 1 -o csv`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{structuredOutputAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		// Create exchange client
+		client, err := newExchangeClient()
+		if err != nil {
+			return err
+		}
+
+		provider, ok := exchange.As[exchange.AccountProvider](client)
+		if !ok {
+			return fmt.Errorf("%s does not provide account balances: %w", client.GetName(), exchange.ErrUnsupported)
+		}
+		if !client.Capabilities().Authenticated {
+			return fmt.Errorf("no API credentials for %s; run 'terminalcrypto setup %s' first", client.GetName(), client.GetName())
+		}
+
+		balances, err := provider.GetBalances(ctx)
+		if err != nil {
+			return err
+		}
+
+		// Value each asset; those without a market in the display currency are left unvalued
+		currency := strings.ToUpper(config.GetDisplayCurrency())
+		records := make([]balanceRecord, 0, len(balances))
+		for _, b := range balances {
+			r := balanceRecord{Exchange: client.GetName(), Balance: b, Total: b.Total(), Currency: currency}
+			if strings.EqualFold(b.Asset, currency) {
+				r.Price = 1
+			} else if symbol, err := resolveSymbol(ctx, client, b.Asset+"/"+currency); err == nil {
+				r.Price, _ = client.GetPrice(ctx, symbol)
+			}
+			r.Value = r.Total * r.Price
+
+			if r.Price > 0 && r.Value < balancesMinValue {
+				continue
+			}
+			records = append(records, r)
+		}
+
+		// Largest holdings first
+		sort.SliceStable(records, func(i, j int) bool { return records[i].Value > records[j].Value })
+
+		if outputFormat != outputText {
+			return writeRecords(os.Stdout, outputFormat, records)
+		}
+
+		printBalances(client.GetName(), currency, records)
+		return nil
+	},
+}
+
+// balanceRecord is one asset balance in structured output; Price and Value are
+// zero when the asset could not be valued
+type balanceRecord struct {
+	Exchange string `json:"exchange"`
+	models.Balance
+	Total    float64 `json:"total"`
+	Currency string  `json:"currency"`
+	Price    float64 `json:"price"`
+	Value    float64 `json:"value"`
+}
+
+func (r balanceRecord) columns() []string {
+	return []string{"exchange", "asset", "free", "locked", "total", "currency", "price", "value"}
+}
+
+func (r balanceRecord) values() []string {
+	return []string{
+		r.Exchange,
+		r.Asset,
+		formatFloat(r.Free),
+		formatFloat(r.Locked),
+		formatFloat(r.Total),
+		r.Currency,
+		formatFloat(r.Price),
+		formatFloat(r.Value),
+	}
+}
+
+// printBalances writes balances as a styled table
+func printBalances(exchangeName, currency string, records []balanceRecord) {
+	// Define styles
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFF00"))
+
+	assetStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#00D4FF"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888888"))
+
+	totalStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFFFF"))
+
+	fmt.Println(headerStyle.Render(fmt.Sprintf("\nBalances on %s in %s:", strings.ToUpper(exchangeName), currency)))
+	fmt.Println(labelStyle.Render(fmt.Sprintf("%-10s %16s %16s %16s %14s %14s", "Asset", "Free", "Locked", "Total", "Price", "Value")))
+	fmt.Println(strings.Repeat("─", 91))
+
+	total := 0.0
+	for _, r := range records {
+		price, value := "-", "-"
+		if r.Price > 0 {
+			price = formatAmount(r.Price)
+			value = formatAmount(r.Value)
+			total += r.Value
+		}
+
+		fmt.Printf("%s %16s %16s %16s %14s %14s\n",
+			assetStyle.Render(fmt.Sprintf("%-10s", r.Asset)),
+			formatQuantity(r.Free),
+			formatQuantity(r.Locked),
+			formatQuantity(r.Total),
+			price,
+			value)
+	}
+
+	fmt.Println(strings.Repeat("─", 91))
+	fmt.Printf("%s %14s\n", totalStyle.Render(fmt.Sprintf("%-76s", "Total")), totalStyle.Render(formatAmount(total)))
+	fmt.Println()
+}
+
+func init() {
+	rootCmd.AddCommand(balancesCmd)
+	balancesCmd.Flags().Float64Var(&balancesMinValue, "min-value", 0, "hide assets worth less than this (in display.currency)")
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	candlesInterval string
+	candlesFrom     string
+	candlesTo       string
+	candlesLimit    int
+)
+
+// timeLayouts are the formats accepted by --from and --to, tried in order
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+var candlesCmd = &cobra.Command{
+	Use:   "candles [symbol]",
+	Short: "Fetch OHLCV candle history for a cryptocurrency symbol",
+	Long: `Fetch open/high/low/close/volume candles for a symbol over a time range.
+Long ranges are fetched page by page, past the per-request limit of the exchange.
+
+--from and --to accept a date (2026-01-01), a date and time (2026-01-01T15:04)
+or an RFC 3339 timestamp; times without a zone are UTC. Without --from, the
+last --limit candles before --to are fetched.
+
+Examples:
+  terminalcrypto candles BTC
+  terminalcrypto candles BTC --interval 15m --from 2026-01-01 --to 2026-02-01
+  terminalcrypto candles ETH --interval 1d --limit 365 --output csv > eth.csv
+  terminalcrypto candles SOL -i 1m --from 2026-03-01T12:00 -o ndjson`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{structuredOutputAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		// Create exchange client
+		client, err := newExchangeClient()
+		if err != nil {
+			return err
+		}
+
+		step, ok := exchange.IntervalDuration(candlesInterval)
+		if !ok || !client.Capabilities().SupportsInterval(candlesInterval) {
+			return &exchange.UnsupportedIntervalError{Exchange: client.GetName(), Interval: candlesInterval}
+		}
+
+		to := time.Now().UTC()
+		if candlesTo != "" {
+			if to, err = parseTimeFlag("to", candlesTo); err != nil {
+				return err
+			}
+		}
+
+		var from time.Time
+		if candlesFrom != "" {
+			if from, err = parseTimeFlag("from", candlesFrom); err != nil {
+				return err
+			}
+		} else {
+			if candlesLimit <= 0 {
+				return fmt.Errorf("--limit must be positive")
+			}
+			from = to.Add(-step * time.Duration(candlesLimit))
+		}
+
+		if !from.Before(to) {
+			return fmt.Errorf("--from (%s) must be before --to (%s)", from.Format(time.RFC3339), to.Format(time.RFC3339))
+		}
+
+		symbol, err := resolveSymbol(ctx, client, args[0])
+		if err != nil {
+			return err
+		}
+
+		candles, err := exchange.FetchCandleHistory(ctx, client, symbol, candlesInterval, from, to)
+		if err != nil {
+			return err
+		}
+
+		normalizedSymbol := client.NormalizeSymbol(symbol)
+		if outputFormat == outputText {
+			printCandles(client.GetName(), normalizedSymbol, candlesInterval, candles)
+			return nil
+		}
+
+		records := make([]candleRecord, len(candles))
+		for i, c := range candles {
+			records[i] = candleRecord{
+				Exchange: client.GetName(),
+				Symbol:   normalizedSymbol,
+				Interval: candlesInterval,
+				Candle:   c,
+			}
+		}
+		return writeRecords(os.Stdout, outputFormat, records)
+	},
+}
+
+// candleRecord is one candle in structured output
+type candleRecord struct {
+	Exchange string `json:"exchange"`
+	Symbol   string `json:"symbol"`
+	Interval string `json:"interval"`
+	models.Candle
+}
+
+func (r candleRecord) columns() []string {
+	return []string{"exchange", "symbol", "interval", "time", "close_time", "open", "high", "low", "close", "volume", "quote_volume"}
+}
+
+func (r candleRecord) values() []string {
+	return []string{
+		r.Exchange,
+		r.Symbol,
+		r.Interval,
+		formatTime(r.Time),
+		formatTime(r.CloseTime),
+		formatFloat(r.Open),
+		formatFloat(r.High),
+		formatFloat(r.Low),
+		formatFloat(r.Close),
+		formatFloat(r.Volume),
+		formatFloat(r.QuoteVolume),
+	}
+}
+
+// parseTimeFlag parses the value of a time flag such as --from
+func parseTimeFlag(name, value string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid --%s %q (use e.g. 2026-01-01, 2026-01-01T15:04 or an RFC 3339 timestamp)", name, value)
+}
+
+// printCandles writes candles as a styled table
+func printCandles(exchangeName, symbol, interval string, candles []models.Candle) {
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFF00"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888888"))
+
+	upStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#00FF87"))
+
+	downStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF0087"))
+
+	fmt.Println(headerStyle.Render(fmt.Sprintf("\n%s %s candles from %s:",
+		symbol, interval, strings.ToUpper(exchangeName))))
+	fmt.Println(labelStyle.Render(fmt.Sprintf("%-16s %14s %14s %14s %14s %14s",
+		"Time (UTC)", "Open", "High", "Low", "Close", "Volume")))
+	fmt.Println(strings.Repeat("─", 92))
+
+	for _, c := range candles {
+		style := upStyle
+		if c.Close < c.Open {
+			style = downStyle
+		}
+
+		fmt.Printf("%s %14s %14s %14s %s %14s\n",
+			labelStyle.Render(c.Time.UTC().Format("2006-01-02 15:04")),
+			formatPrice(c.Open),
+			formatPrice(c.High),
+			formatPrice(c.Low),
+			style.Render(fmt.Sprintf("%14s", formatPrice(c.Close))),
+			formatQuantity(c.Volume))
+	}
+
+	fmt.Println(strings.Repeat("─", 92))
+	fmt.Println(labelStyle.Render(fmt.Sprintf("%d candles", len(candles))))
+	fmt.Println()
+}
+
+func init() {
+	rootCmd.AddCommand(candlesCmd)
+	candlesCmd.Flags().StringVarP(&candlesInterval, "interval", "i", "1h", "candle interval (e.g. 1m, 15m, 1h, 1d)")
+	candlesCmd.Flags().StringVar(&candlesFrom, "from", "", "start of the range (inclusive)")
+	candlesCmd.Flags().StringVar(&candlesTo, "to", "", "end of the range (exclusive, default now)")
+	candlesCmd.Flags().IntVarP(&candlesLimit, "limit", "n", 100, "number of candles to fetch when --from is not set")
+}
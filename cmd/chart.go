@@ -0,0 +1,704 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/chart"
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	chartInterval string
+	chartRefresh  int
+	chartOpts     indicatorOptions
+)
+
+// chartIntervals are the intervals the chart can switch between, bound to keys 1-4
+var chartIntervals = []string{"1m", "5m", "1h", "1d"}
+
+// chartHistory is how many candles are loaded at a time
+const chartHistory = 500
+
+// candlesMsg delivers candles fetched for the chart
+type candlesMsg struct {
+	interval string
+	candles  []models.Candle
+
+	// live marks a refresh of the newest candles; older marks history
+	// loaded to the left of the current candles
+	live  bool
+	older bool
+	err   error
+}
+
+type chartTickMsg time.Time
+
+type chartModel struct {
+	ctx       context.Context
+	client    exchange.Exchange
+	symbol    string
+	intervals []string
+	interval  string
+	updates   <-chan models.PriceUpdate
+
+	// indicators are drawn over the candles; panel selects the oscillator pane
+	// shown below them, with panelCount meaning none
+	indicators indicatorOptions
+	panel      int
+
+	candles []models.Candle
+
+	// cursor is the candle under the crosshair; offset is the number of candles
+	// hidden to the right of the view. follow keeps both pinned to the latest candle.
+	cursor int
+	offset int
+	follow bool
+	slot   int
+
+	width  int
+	height int
+
+	loading      bool
+	loadingOlder bool
+	historyDone  bool
+	err          error
+}
+
+func (m chartModel) Init() tea.Cmd {
+	cmds := []tea.Cmd{m.fetchCandles(), chartTickCmd()}
+	if m.updates != nil {
+		cmds = append(cmds, waitForUpdate(m.updates))
+	}
+	return tea.Batch(cmds...)
+}
+
+func chartTickCmd() tea.Cmd {
+	return tea.Tick(time.Duration(chartRefresh)*time.Second, func(t time.Time) tea.Msg {
+		return chartTickMsg(t)
+	})
+}
+
+// fetchCandles loads the latest candles for the current interval
+func (m chartModel) fetchCandles() tea.Cmd {
+	client, symbol, interval := m.client, m.symbol, m.interval
+	limit := chartHistory
+	if maxLimit := client.Capabilities().MaxCandleLimit; maxLimit > 0 && maxLimit < limit {
+		limit = maxLimit
+	}
+
+	return func() tea.Msg {
+		candles, err := client.GetCandles(m.ctx, symbol, interval, limit)
+		return candlesMsg{interval: interval, candles: candles, err: err}
+	}
+}
+
+// refreshLast re-fetches the newest candles so the last one stays live
+func (m chartModel) refreshLast() tea.Cmd {
+	client, symbol, interval := m.client, m.symbol, m.interval
+	return func() tea.Msg {
+		candles, err := client.GetCandles(m.ctx, symbol, interval, 2)
+		return candlesMsg{interval: interval, candles: candles, live: true, err: err}
+	}
+}
+
+// fetchOlder loads the history just before the first loaded candle
+func (m chartModel) fetchOlder() tea.Cmd {
+	client, symbol, interval := m.client, m.symbol, m.interval
+	step, ok := exchange.IntervalDuration(interval)
+	if !ok || len(m.candles) == 0 {
+		return nil
+	}
+
+	end := m.candles[0].Time
+	start := end.Add(-step * chartHistory)
+	return func() tea.Msg {
+		candles, err := exchange.FetchCandleHistory(m.ctx, client, symbol, interval, start, end)
+		return candlesMsg{interval: interval, candles: candles, older: true, err: err}
+	}
+}
+
+// canLoadOlder reports whether history before the first candle can be requested
+func (m chartModel) canLoadOlder() bool {
+	_, ok := exchange.As[exchange.CandleHistoryProvider](m.client)
+	return ok && m.client.Capabilities().CandleHistory && !m.loadingOlder && !m.historyDone && len(m.candles) > 0
+}
+
+// capacity is the number of candles that fit on screen
+func (m chartModel) capacity() int {
+	return max(chart.Capacity(m.width, m.slot), 1)
+}
+
+// visible returns the [start, end) range of candles on screen
+func (m chartModel) visible() (int, int) {
+	end := len(m.candles) - m.offset
+	start := max(end-m.capacity(), 0)
+	return start, end
+}
+
+// clampView keeps offset in range and the cursor on screen
+func (m *chartModel) clampView() {
+	if m.follow {
+		m.offset = 0
+		m.cursor = len(m.candles) - 1
+		return
+	}
+
+	m.offset = min(max(m.offset, 0), max(len(m.candles)-m.capacity(), 0))
+	m.cursor = min(max(m.cursor, 0), len(m.candles)-1)
+
+	start, end := m.visible()
+	if m.cursor < start {
+		m.cursor = start
+	} else if m.cursor >= end {
+		m.cursor = end - 1
+	}
+}
+
+// moveCursor moves the crosshair by delta candles, scrolling the view to keep it visible
+func (m *chartModel) moveCursor(delta int) {
+	if len(m.candles) == 0 {
+		return
+	}
+
+	m.follow = false
+	m.cursor = min(max(m.cursor+delta, 0), len(m.candles)-1)
+
+	start, end := m.visible()
+	if m.cursor < start {
+		m.offset += start - m.cursor
+	} else if m.cursor >= end {
+		m.offset -= m.cursor - end + 1
+	}
+
+	m.follow = m.cursor == len(m.candles)-1 && m.offset == 0
+	m.clampView()
+}
+
+// pan scrolls the view by delta candles, dragging the crosshair along
+func (m *chartModel) pan(delta int) {
+	if len(m.candles) == 0 {
+		return
+	}
+
+	m.follow = false
+	m.offset -= delta
+	m.cursor += delta
+	m.clampView()
+	m.follow = m.cursor == len(m.candles)-1 && m.offset == 0
+}
+
+// atOldest reports whether the view shows the first loaded candle
+func (m chartModel) atOldest() bool {
+	start, _ := m.visible()
+	return start == 0
+}
+
+// merge applies freshly fetched candles to the loaded ones
+func (m *chartModel) merge(msg candlesMsg) {
+	switch {
+	case msg.older:
+		// Keep only candles before the first loaded one
+		var older []models.Candle
+		for _, c := range msg.candles {
+			if c.Time.Before(m.candles[0].Time) {
+				older = append(older, c)
+			}
+		}
+		if len(older) == 0 {
+			m.historyDone = true
+			return
+		}
+		m.candles = append(older, m.candles...)
+		m.cursor += len(older)
+
+	case !msg.live:
+		m.candles = msg.candles
+		m.offset = 0
+		m.follow = true
+		m.historyDone = false
+
+	case len(m.candles) > 0:
+		// Replace the live candle and append any that opened since
+		for _, c := range msg.candles {
+			last := &m.candles[len(m.candles)-1]
+			switch {
+			case c.Time.Equal(last.Time):
+				*last = c
+			case c.Time.After(last.Time):
+				m.candles = append(m.candles, c)
+				if !m.follow && m.offset > 0 {
+					m.offset++
+				}
+			}
+		}
+	}
+
+	m.clampView()
+}
+
+// applyUpdate folds a streamed trade price into the live candle
+func (m *chartModel) applyUpdate(update models.PriceUpdate) {
+	if len(m.candles) == 0 {
+		return
+	}
+
+	last := &m.candles[len(m.candles)-1]
+	if !last.CloseTime.IsZero() && update.Timestamp.After(last.CloseTime) {
+		// A new candle has opened; the next refresh adds it
+		return
+	}
+
+	last.Close = update.Price
+	last.High = max(last.High, update.Price)
+	last.Low = min(last.Low, update.Price)
+}
+
+// switchInterval reloads the chart for another interval
+func (m chartModel) switchInterval(interval string) (chartModel, tea.Cmd) {
+	if interval == m.interval {
+		return m, nil
+	}
+
+	m.interval = interval
+	m.candles = nil
+	m.offset = 0
+	m.cursor = 0
+	m.follow = true
+	m.loading = true
+	m.loadingOlder = false
+	m.historyDone = false
+	m.err = nil
+	return m, m.fetchCandles()
+}
+
+func (m chartModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.clampView()
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		case "left", "h":
+			m.moveCursor(-1)
+		case "right", "l":
+			m.moveCursor(1)
+		case "shift+left", "H":
+			m.pan(-m.capacity() / 2)
+		case "shift+right", "L":
+			m.pan(m.capacity() / 2)
+		case "home", "g":
+			m.pan(-len(m.candles))
+			m.moveCursor(-len(m.candles))
+		case "p":
+			m.panel = (m.panel + 1) % (m.indicators.panelCount() + 1)
+		case "end", "G":
+			m.follow = true
+			m.clampView()
+		case "+", "=":
+			m.slot = min(m.slot+1, chart.MaxSlotWidth)
+			m.clampView()
+		case "-", "_":
+			m.slot = max(m.slot-1, 1)
+			m.clampView()
+		case "tab":
+			for i, interval := range m.intervals {
+				if interval == m.interval {
+					return m.switchInterval(m.intervals[(i+1)%len(m.intervals)])
+				}
+			}
+		default:
+			for i, interval := range m.intervals {
+				if msg.String() == fmt.Sprint(i+1) {
+					return m.switchInterval(interval)
+				}
+			}
+		}
+
+		// Reaching the left edge pulls in older history
+		if m.atOldest() && m.canLoadOlder() {
+			m.loadingOlder = true
+			return m, m.fetchOlder()
+		}
+		return m, nil
+
+	case chartTickMsg:
+		switch {
+		case m.loading:
+			return m, chartTickCmd()
+		case len(m.candles) == 0:
+			// The initial load failed; try again
+			m.loading = true
+			return m, tea.Batch(chartTickCmd(), m.fetchCandles())
+		}
+		return m, tea.Batch(chartTickCmd(), m.refreshLast())
+
+	case candlesMsg:
+		// Ignore results for an interval that is no longer shown
+		if msg.interval != m.interval {
+			return m, nil
+		}
+		switch {
+		case msg.older:
+			m.loadingOlder = false
+		case !msg.live:
+			m.loading = false
+		}
+
+		m.err = msg.err
+		if msg.err == nil {
+			m.merge(msg)
+		}
+		return m, nil
+
+	case models.PriceUpdate:
+		m.applyUpdate(msg)
+		return m, waitForUpdate(m.updates)
+
+	case streamClosedMsg:
+		m.updates = nil
+		return m, nil
+	}
+
+	return m, nil
+}
+
+func (m chartModel) View() string {
+	// Define styles
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFF00")).
+		Background(lipgloss.Color("#333333")).
+		Padding(0, 1)
+
+	tabStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888888")).
+		Padding(0, 1)
+
+	activeTabStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#000000")).
+		Background(lipgloss.Color("#00D4FF")).
+		Padding(0, 1)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888888"))
+
+	upStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#00FF87"))
+
+	downStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FF0087"))
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF0087"))
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888888")).
+		Italic(true)
+
+	var s strings.Builder
+
+	// Title and interval tabs
+	s.WriteString(titleStyle.Render(fmt.Sprintf("%s · %s", m.client.NormalizeSymbol(m.symbol), strings.ToUpper(m.client.GetName()))))
+	s.WriteString(" ")
+	for i, interval := range m.intervals {
+		label := fmt.Sprintf("%d:%s", i+1, interval)
+		if interval == m.interval {
+			s.WriteString(activeTabStyle.Render(label))
+		} else {
+			s.WriteString(tabStyle.Render(label))
+		}
+	}
+	if len(m.candles) > 0 {
+		last := m.candles[len(m.candles)-1]
+		style := upStyle
+		if last.Close < last.Open {
+			style = downStyle
+		}
+		s.WriteString("  ")
+		s.WriteString(style.Render(formatPrice(last.Close)))
+	}
+	s.WriteString("\n")
+
+	// Crosshair readout
+	if m.cursor >= 0 && m.cursor < len(m.candles) {
+		c := m.candles[m.cursor]
+		style := upStyle
+		if c.Close < c.Open {
+			style = downStyle
+		}
+
+		change := 0.0
+		if c.Open != 0 {
+			change = (c.Close - c.Open) / c.Open * 100
+		}
+
+		s.WriteString(labelStyle.Render(c.Time.Format("2006-01-02 15:04")))
+		for _, field := range []struct {
+			label string
+			value string
+		}{
+			{"O", formatPrice(c.Open)},
+			{"H", formatPrice(c.High)},
+			{"L", formatPrice(c.Low)},
+			{"C", formatPrice(c.Close)},
+			{"V", formatQuantity(c.Volume)},
+		} {
+			s.WriteString(labelStyle.Render("  " + field.label + " "))
+			s.WriteString(field.value)
+		}
+		s.WriteString("  ")
+		s.WriteString(style.Render(fmt.Sprintf("%+.2f%%", change)))
+	}
+	s.WriteString("\n")
+
+	// Indicator values at the crosshair
+	var overlays []chart.Series
+	var panel *chart.Panel
+	headerHeight := 2
+	if !m.indicators.empty() {
+		series := computeIndicators(m.candles, m.interval, m.indicators)
+		start, end := m.visible()
+		overlays, panel = chartSeries(series, m.panel, start, end)
+		if panel != nil {
+			panel.Height = 6
+			if m.height < 30 {
+				panel.Height = 4
+			}
+		}
+
+		if m.cursor >= 0 && m.cursor < len(m.candles) {
+			used := 0
+			for _, is := range series {
+				if is.panel != "" && (panel == nil || is.panel != panel.Label) {
+					continue
+				}
+				value := "-"
+				if v := is.values[m.cursor]; !math.IsNaN(v) {
+					value = formatPrice(v)
+				}
+				// Drop what does not fit rather than wrap and push the chart down
+				entry := is.name + " " + value
+				if used+lipgloss.Width(entry) > m.width {
+					break
+				}
+				used += lipgloss.Width(entry) + 2
+				style := lipgloss.NewStyle().Foreground(lipgloss.Color(is.color))
+				s.WriteString(style.Render(entry))
+				s.WriteString("  ")
+			}
+		}
+		s.WriteString("\n")
+		headerHeight++
+	}
+
+	// Chart body: everything between the header lines and the footer
+	volumeHeight := 4
+	if m.height < 24 {
+		volumeHeight = 2
+	}
+	panelHeight := 0
+	if panel != nil {
+		panelHeight = panel.Height
+	}
+	priceHeight := max(m.height-headerHeight-volumeHeight-panelHeight-1-1, 3)
+
+	switch {
+	case len(m.candles) == 0 && m.err != nil:
+		s.WriteString(errorStyle.Render(fmt.Sprintf("Error: %s", describeError(m.err))))
+		s.WriteString(strings.Repeat("\n", priceHeight+volumeHeight+panelHeight+1))
+	case len(m.candles) == 0:
+		s.WriteString("Loading candles...")
+		s.WriteString(strings.Repeat("\n", priceHeight+volumeHeight+panelHeight+1))
+	default:
+		start, end := m.visible()
+		s.WriteString(chart.Render(m.candles[start:end], chart.Options{
+			Width:        m.width,
+			PriceHeight:  priceHeight,
+			VolumeHeight: volumeHeight,
+			SlotWidth:    m.slot,
+			Cursor:       m.cursor - start,
+			TimeFormat:   chartTimeFormat(m.interval),
+			Overlays:     overlays,
+			Panel:        panel,
+		}))
+		s.WriteString("\n")
+	}
+
+	// Footer
+	status := ""
+	switch {
+	case m.loading || m.loadingOlder:
+		status = "loading… • "
+	case m.err != nil && len(m.candles) > 0:
+		status = errorStyle.Render("update failed: "+errorKind(m.err)) + helpStyle.Render(" • ")
+	case m.updates != nil:
+		status = "live • "
+	}
+	s.WriteString(helpStyle.Render(status))
+	help := "←/→ move • H/L pan • +/- zoom • 1-4/tab interval • g/G start/end • q quit"
+	if m.indicators.panelCount() > 0 {
+		help = "←/→ move • H/L pan • +/- zoom • 1-4/tab interval • p panel • g/G start/end • q quit"
+	}
+	s.WriteString(helpStyle.Render(help))
+
+	return s.String()
+}
+
+// chartSeries turns indicator series into chart overlays for candles [start, end)
+// and the panel-th oscillator pane, if there is one
+func chartSeries(series []indicatorSeries, panel, start, end int) ([]chart.Series, *chart.Panel) {
+	var overlays []chart.Series
+	var panels []*chart.Panel
+	for _, is := range series {
+		cs := chart.Series{Label: is.name, Values: is.values[start:end], Color: is.color, Bars: is.bars}
+		if is.panel == "" {
+			overlays = append(overlays, cs)
+			continue
+		}
+
+		if len(panels) == 0 || panels[len(panels)-1].Label != is.panel {
+			p := &chart.Panel{Label: is.panel}
+			if strings.HasPrefix(is.panel, "RSI") {
+				p.Min, p.Max, p.Levels = 0, 100, []float64{30, 70}
+			} else if strings.HasPrefix(is.panel, "MACD") {
+				p.Levels = []float64{0}
+			}
+			panels = append(panels, p)
+		}
+		p := panels[len(panels)-1]
+		p.Series = append(p.Series, cs)
+	}
+
+	if panel < len(panels) {
+		return overlays, panels[panel]
+	}
+	return overlays, nil
+}
+
+// chartTimeFormat picks time axis labels that suit the interval
+func chartTimeFormat(interval string) string {
+	d, _ := exchange.IntervalDuration(interval)
+	switch {
+	case d >= 24*time.Hour:
+		return "2006-01-02"
+	case d >= time.Hour:
+		return "01-02 15:04"
+	default:
+		return "15:04"
+	}
+}
+
+var chartCmd = &cobra.Command{
+	Use:   "chart [symbol]",
+	Short: "Show an interactive candlestick chart",
+	Long: `Show a full-screen candlestick chart with price and time axes and volume bars.
+The last candle updates live.
+
+Keys:
+  ←/→ or h/l        move the crosshair one candle
+  shift+←/→ or H/L  pan half a screen
+  + / -             zoom in / out
+  1-4 or tab        switch interval (1m, 5m, 1h, 1d)
+  p                 cycle the oscillator panel (RSI, MACD, ATR) or hide it
+  g / G             jump to the oldest / latest candle
+  q                 quit
+
+Indicator flags draw moving averages, Bollinger Bands and VWAP over the
+candles, and RSI, MACD and ATR in a panel below them.
+
+Examples:
+  terminalcrypto chart ETH
+  terminalcrypto chart BTC --interval 5m
+  terminalcrypto chart BTC --ema 20,50 --bb 20 --rsi 14
+  terminalcrypto --exchange okx chart SOL -i 1d`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := chartOpts.validate(); err != nil {
+			return err
+		}
+
+		// Create exchange client
+		client, err := newExchangeClient()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		symbol, err := resolveSymbol(ctx, client, args[0])
+		if err != nil {
+			return err
+		}
+
+		// Offer the chart intervals this exchange supports
+		caps := client.Capabilities()
+		var intervals []string
+		for _, interval := range chartIntervals {
+			if caps.SupportsInterval(interval) {
+				intervals = append(intervals, interval)
+			}
+		}
+		if !caps.SupportsInterval(chartInterval) {
+			return &exchange.UnsupportedIntervalError{Exchange: client.GetName(), Interval: chartInterval}
+		}
+		found := false
+		for _, interval := range intervals {
+			found = found || interval == chartInterval
+		}
+		if !found {
+			intervals = append(intervals, chartInterval)
+		}
+
+		m := chartModel{
+			ctx:        ctx,
+			client:     client,
+			symbol:     symbol,
+			intervals:  intervals,
+			interval:   chartInterval,
+			indicators: chartOpts,
+			follow:     true,
+			slot:       2,
+			width:      80,
+			height:     24,
+			loading:    true,
+		}
+
+		// Stream trades into the live candle when the exchange supports it
+		if streamer, ok := exchange.As[exchange.Streamer](client); ok && caps.Streaming {
+			if updates, err := streamer.SubscribeTickers(ctx, []string{symbol}); err == nil {
+				m.updates = updates
+			}
+		}
+
+		p := tea.NewProgram(m, tea.WithAltScreen())
+		if _, err := p.Run(); err != nil {
+			return fmt.Errorf("error running chart: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(chartCmd)
+	chartCmd.Flags().StringVarP(&chartInterval, "interval", "i", "1h", "initial candle interval")
+	chartCmd.Flags().IntVarP(&chartRefresh, "refresh", "r", 5, "seconds between refreshes of the last candle")
+	addIndicatorFlags(chartCmd, &chartOpts)
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/config"
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+	"github.com/Carpe-Wang/terminalCrypto/internal/keyring"
+	"github.com/Carpe-Wang/terminalCrypto/internal/store"
+)
+
+// paperPrefix selects a paper trading account on top of an exchange, e.g. "paper:binance"
+const paperPrefix = "paper:"
+
+// compositeName selects the composite exchange, which combines the prices of several exchanges
+const compositeName = "composite"
+
+// newExchangeClient creates a client for the selected exchange, using stored
+// credentials when available (they may be empty for public access)
+func newExchangeClient() (exchange.Exchange, error) {
+	return newExchangeClientFor(exchangeName)
+}
+
+// newExchangeClientFor creates a client for the named exchange, like newExchangeClient.
+// A "paper:" name wraps the exchange in a paper trading account, and "composite"
+// combines the exchanges in composite.exchanges. Unless store.enabled is off,
+// the market data it returns is kept in ~/.terminalcrypto/data.
+func newExchangeClientFor(name string) (exchange.Exchange, error) {
+	if inner, ok := strings.CutPrefix(name, paperPrefix); ok {
+		return newPaperClient(inner)
+	}
+	if name == compositeName {
+		return newCompositeClient()
+	}
+
+	var apiKey, apiSecret string
+	creds, err := keyring.GetCredentials(name)
+	if err == nil {
+		apiKey = creds.APIKey
+		apiSecret = creds.APISecret
+	}
+
+	client, err := exchange.Factory(name, apiKey, apiSecret)
+	if err != nil || !config.GetStoreEnabled() {
+		return client, err
+	}
+
+	// Keep the market data we fetch, so candles can be served offline
+	dir, err := config.Dir()
+	if err != nil {
+		return nil, err
+	}
+	data := store.New(filepath.Join(dir, "data"))
+	data.SetRetention(config.GetStoreRetention())
+	return store.NewCachedExchange(client, data), nil
+}
+
+// newPaperClient creates a paper trading account that trades at the named
+// exchange's prices, with its ledger in ~/.terminalcrypto/paper-<exchange>.json
+func newPaperClient(name string) (*exchange.PaperExchange, error) {
+	if strings.HasPrefix(name, paperPrefix) {
+		return nil, fmt.Errorf("invalid exchange: %s%s", paperPrefix, name)
+	}
+
+	client, err := newExchangeClientFor(name)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := config.Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	// Fund the account in the currency the exchange quotes bare symbols in, so
+	// that "order buy BTC" can be paid for: USDT on Binance, USD on Coinbase
+	quote := quoteCurrency(client, "BTC")
+	if quote == "" {
+		quote = strings.ToUpper(config.GetDisplayCurrency())
+	}
+
+	return exchange.NewPaperExchange(client, filepath.Join(dir, "paper-"+client.GetName()+".json"), exchange.PaperOptions{
+		FeeRate:  config.GetPaperFeeRate(),
+		Balances: map[string]float64{quote: config.GetPaperStartingBalance()},
+	}), nil
+}
+
+// newCompositeClient creates the composite exchange from the composite.* settings
+func newCompositeClient() (*exchange.AggregateExchange, error) {
+	var sources []exchange.Exchange
+	for _, name := range config.GetCompositeExchanges() {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == compositeName || strings.HasPrefix(name, paperPrefix) {
+			return nil, fmt.Errorf("invalid composite source: %s", name)
+		}
+
+		client, err := newExchangeClientFor(name)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, client)
+	}
+
+	return exchange.NewAggregateExchange(sources, exchange.AggregateOptions{
+		Method:       exchange.AggregateMethod(config.GetCompositeMethod()),
+		MaxDeviation: config.GetCompositeMaxDeviation(),
+		MaxAge:       config.GetCompositeMaxAge(),
+	})
+}
@@ -0,0 +1,634 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	compareExchanges []string
+	compareWatch     bool
+	compareInterval  int
+	compareThreshold float64
+)
+
+var compareCmd = &cobra.Command{
+	Use:   "compare [symbols...]",
+	Short: "Compare prices for cryptocurrency symbols across exchanges",
+	Long: `Compare the price of one or more symbols on several exchanges at once and
+show the spread between the cheapest and the dearest venue.
+
+Every exchange is queried concurrently. A bare symbol such as BTC uses each
+exchange's default quote currency (USDT on Binance and OKX, USD on Coinbase).
+Prices are converted to USD so they can be compared: a quote currency is
+converted at its USD price on the first exchange that lists one, and a USD
+stablecoin that none of them lists is taken at par. The best bid and ask are
+shown where the exchange provides an order book; when one venue's bid is
+above another's ask, the crossed prices are shown as an arbitrage.
+
+Spreads wider than --threshold basis points are highlighted. With --watch the
+comparison refreshes every --interval seconds.
+
+Examples:
+  terminalcrypto compare BTC
+  terminalcrypto compare BTC ETH --exchanges binance,coinbase,okx
+  terminalcrypto compare SOL --exchanges binance,okx --threshold 5
+  terminalcrypto compare BTC ETH --watch --interval 3
+  terminalcrypto compare BTC ETH -o csv > spreads.csv`,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{structuredOutputAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if compareWatch && outputFormat != outputText {
+			return fmt.Errorf("--watch cannot be combined with --output %s", outputFormat)
+		}
+		if compareWatch && compareInterval < 1 {
+			return fmt.Errorf("--interval must be positive")
+		}
+		if len(compareExchanges) < 2 {
+			return fmt.Errorf("--exchanges needs at least two exchanges to compare")
+		}
+
+		// Create a client for every venue
+		clients := make([]exchange.Exchange, len(compareExchanges))
+		for i, name := range compareExchanges {
+			client, err := newExchangeClientFor(strings.ToLower(strings.TrimSpace(name)))
+			if err != nil {
+				return err
+			}
+			clients[i] = client
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		if compareWatch {
+			p := tea.NewProgram(compareModel{ctx: ctx, cancel: cancel, clients: clients, assets: args})
+			if _, err := p.Run(); err != nil {
+				return fmt.Errorf("error running compare: %w", err)
+			}
+			return nil
+		}
+
+		comparisons := fetchComparisons(ctx, clients, args)
+
+		if outputFormat == outputText {
+			fmt.Print(renderComparisons(comparisons, compareThreshold))
+		} else {
+			var records []venueQuote
+			for _, c := range comparisons {
+				records = append(records, c.Quotes...)
+			}
+			if err := writeRecords(os.Stdout, outputFormat, records); err != nil {
+				return err
+			}
+		}
+
+		// A symbol no exchange could price fails the command; other errors were reported per venue
+		for _, c := range comparisons {
+			if c.Low < 0 {
+				return &reportedError{err: c.Quotes[0].err}
+			}
+		}
+		return nil
+	},
+}
+
+// venueQuote is one exchange's price for a symbol, converted to USD
+type venueQuote struct {
+	Asset    string `json:"asset"`
+	Exchange string `json:"exchange"`
+	Symbol   string `json:"symbol"`
+	Quote    string `json:"quote,omitempty"`
+
+	// Rate is the USD value of one unit of the quote currency
+	Rate float64 `json:"rate,omitzero"`
+
+	Price float64 `json:"price_usd,omitzero"`
+	Bid   float64 `json:"bid_usd,omitzero"`
+	Ask   float64 `json:"ask_usd,omitzero"`
+
+	// Premium is how far the price is above the cheapest venue's, in basis points
+	Premium float64 `json:"premium_bps"`
+
+	// Spread and SpreadBps are the gap between the cheapest and dearest venues for the asset
+	Spread    float64 `json:"spread_usd"`
+	SpreadBps float64 `json:"spread_bps"`
+
+	Error     string `json:"error,omitempty"`
+	ErrorKind string `json:"error_kind,omitempty"`
+
+	err error
+}
+
+func (q venueQuote) columns() []string {
+	return []string{"asset", "exchange", "symbol", "quote", "rate", "price_usd", "bid_usd", "ask_usd",
+		"premium_bps", "spread_usd", "spread_bps", "error", "error_kind"}
+}
+
+func (q venueQuote) values() []string {
+	optional := func(v float64) string {
+		if v == 0 {
+			return ""
+		}
+		return formatFloat(v)
+	}
+
+	if q.err != nil {
+		return []string{q.Asset, q.Exchange, q.Symbol, q.Quote, "", "", "", "", "", "", "", q.Error, q.ErrorKind}
+	}
+	return []string{
+		q.Asset,
+		q.Exchange,
+		q.Symbol,
+		q.Quote,
+		formatFloat(q.Rate),
+		formatFloat(q.Price),
+		optional(q.Bid),
+		optional(q.Ask),
+		formatFloat(q.Premium),
+		formatFloat(q.Spread),
+		formatFloat(q.SpreadBps),
+		"",
+		"",
+	}
+}
+
+// comparison is one symbol's prices across exchanges
+type comparison struct {
+	Asset  string
+	Quotes []venueQuote
+
+	// Low and High index the cheapest and dearest venues, -1 when no venue has a price
+	Low, High int
+
+	// Buy and Sell index the venues with the lowest ask and the highest bid when
+	// that bid is above that ask, -1 otherwise
+	Buy, Sell int
+}
+
+// compareTimeout bounds one round of requests, so that a venue that hangs is
+// reported as failing instead of holding up the comparison
+const compareTimeout = 15 * time.Second
+
+// fetchComparisons queries every exchange for every symbol, one goroutine per exchange
+func fetchComparisons(ctx context.Context, clients []exchange.Exchange, assets []string) []comparison {
+	ctx, cancel := context.WithTimeout(ctx, compareTimeout)
+	defer cancel()
+
+	byVenue := make([][]venueQuote, len(clients))
+
+	var wg sync.WaitGroup
+	for i, client := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			byVenue[i] = fetchVenueQuotes(ctx, client, assets)
+		}()
+	}
+	wg.Wait()
+
+	convertQuotes(ctx, clients, byVenue)
+
+	comparisons := make([]comparison, len(assets))
+	for j, asset := range assets {
+		c := comparison{Asset: strings.ToUpper(asset), Quotes: make([]venueQuote, len(clients))}
+		for i := range clients {
+			c.Quotes[i] = byVenue[i][j]
+		}
+		c.rank()
+		comparisons[j] = c
+	}
+	return comparisons
+}
+
+// fetchVenueQuotes fetches the tickers of every symbol on one exchange in a
+// single batch, in each symbol's own quote currency. A ticker without the best
+// bid and ask takes them from the order book where the exchange has one.
+func fetchVenueQuotes(ctx context.Context, client exchange.Exchange, assets []string) []venueQuote {
+	quotes := make([]venueQuote, len(assets))
+	var resolved []string
+	var index []int
+	for j, asset := range assets {
+		quotes[j] = venueQuote{Asset: strings.ToUpper(asset), Exchange: client.GetName(), Symbol: asset}
+
+		r, err := resolveSymbol(ctx, client, asset)
+		if err != nil {
+			quotes[j].fail(err)
+			continue
+		}
+		quotes[j].Symbol = client.NormalizeSymbol(r)
+		quotes[j].Quote = quoteCurrency(client, r)
+		resolved = append(resolved, r)
+		index = append(index, j)
+	}
+
+	provider, hasBook := exchange.As[exchange.OrderBookProvider](client)
+	hasBook = hasBook && client.Capabilities().OrderBook
+
+	for k, r := range client.GetTickers(ctx, resolved) {
+		q := &quotes[index[k]]
+		if r.Err != nil {
+			q.fail(r.Err)
+			continue
+		}
+		q.Price, q.Bid, q.Ask = r.Value.Price, r.Value.Bid, r.Value.Ask
+
+		// The book only adds the bid and ask, so a venue without one still has a price
+		if (q.Bid == 0 || q.Ask == 0) && hasBook {
+			if book, err := provider.GetOrderBook(ctx, resolved[k], 1); err == nil && len(book.Bids) > 0 && len(book.Asks) > 0 {
+				q.Bid = book.Bids[0].Price
+				q.Ask = book.Asks[0].Price
+			}
+		}
+	}
+	return quotes
+}
+
+// fail records err in the quote
+func (q *venueQuote) fail(err error) {
+	q.Error = describeError(err)
+	q.ErrorKind = errorKind(err)
+	q.err = err
+}
+
+// convertQuotes converts every price to USD at the rates of usdRates
+func convertQuotes(ctx context.Context, clients []exchange.Exchange, byVenue [][]venueQuote) {
+	var currencies []string
+	for i := range byVenue {
+		for _, q := range byVenue[i] {
+			if q.err == nil && !slices.Contains(currencies, q.Quote) {
+				currencies = append(currencies, q.Quote)
+			}
+		}
+	}
+
+	rates, rateErrs := usdRates(ctx, clients, currencies)
+
+	for i := range byVenue {
+		for j := range byVenue[i] {
+			q := &byVenue[i][j]
+			if q.err != nil {
+				continue
+			}
+
+			rate, ok := rates[q.Quote]
+			if !ok {
+				q.fail(rateErrs[q.Quote])
+				continue
+			}
+
+			q.Rate = rate
+			q.Price *= rate
+			q.Bid *= rate
+			q.Ask *= rate
+		}
+	}
+}
+
+// usdRates returns the USD price of each quote currency, or why it has none.
+// Each exchange in turn is asked, in one batch, for the currencies the ones
+// before it could not price; a USD stablecoin that none of them prices is
+// taken at par.
+func usdRates(ctx context.Context, clients []exchange.Exchange, currencies []string) (map[string]float64, map[string]error) {
+	rates := map[string]float64{"USD": 1}
+	errs := make(map[string]error)
+
+	var pending []string
+	for _, currency := range currencies {
+		switch currency {
+		case "USD":
+		case "":
+			errs[currency] = fmt.Errorf("cannot tell the quote currency: %w", exchange.ErrUnsupported)
+		default:
+			pending = append(pending, currency)
+		}
+	}
+
+	for _, client := range clients {
+		if len(pending) == 0 {
+			break
+		}
+
+		var pairs, priced []string
+		for _, currency := range pending {
+			if resolved, err := resolveSymbol(ctx, client, currency+"/USD"); err == nil {
+				pairs = append(pairs, resolved)
+				priced = append(priced, currency)
+			}
+		}
+		if len(pairs) == 0 {
+			continue
+		}
+
+		for k, r := range client.GetPrices(ctx, pairs) {
+			if r.Err == nil && r.Value > 0 {
+				rates[priced[k]] = r.Value
+			}
+		}
+		pending = slices.DeleteFunc(pending, func(currency string) bool {
+			_, ok := rates[currency]
+			return ok
+		})
+	}
+
+	for _, currency := range pending {
+		if exchange.IsUSDStablecoin(currency) {
+			rates[currency] = 1
+		} else {
+			errs[currency] = fmt.Errorf("no exchange prices %s in USD: %w", currency, exchange.ErrUnsupported)
+		}
+	}
+	return rates, errs
+}
+
+// rank finds the cheapest and dearest venues and any crossed bid and ask, and
+// fills in every quote's premium and the spread
+func (c *comparison) rank() {
+	c.Low, c.High, c.Buy, c.Sell = -1, -1, -1, -1
+
+	for i, q := range c.Quotes {
+		if q.err != nil {
+			continue
+		}
+		if c.Low < 0 || q.Price < c.Quotes[c.Low].Price {
+			c.Low = i
+		}
+		if c.High < 0 || q.Price > c.Quotes[c.High].Price {
+			c.High = i
+		}
+		if q.Ask > 0 && (c.Buy < 0 || q.Ask < c.Quotes[c.Buy].Ask) {
+			c.Buy = i
+		}
+		if q.Bid > 0 && (c.Sell < 0 || q.Bid > c.Quotes[c.Sell].Bid) {
+			c.Sell = i
+		}
+	}
+
+	if c.Buy < 0 || c.Sell < 0 || c.Buy == c.Sell || c.Quotes[c.Sell].Bid <= c.Quotes[c.Buy].Ask {
+		c.Buy, c.Sell = -1, -1
+	}
+
+	if c.Low < 0 {
+		return
+	}
+
+	low := c.Quotes[c.Low].Price
+	spread, spreadBps := c.spread()
+	for i := range c.Quotes {
+		q := &c.Quotes[i]
+		if q.err != nil {
+			continue
+		}
+		if low > 0 {
+			q.Premium = (q.Price - low) / low * 10000
+		}
+		q.Spread = spread
+		q.SpreadBps = spreadBps
+	}
+}
+
+// spread returns the gap between the dearest and cheapest prices, in USD and in
+// basis points of the cheapest
+func (c *comparison) spread() (float64, float64) {
+	if c.Low < 0 {
+		return 0, 0
+	}
+
+	low, high := c.Quotes[c.Low].Price, c.Quotes[c.High].Price
+	if low <= 0 {
+		return high - low, 0
+	}
+	return high - low, (high - low) / low * 10000
+}
+
+// renderComparisons draws a table of venues per symbol, followed by its spread
+func renderComparisons(comparisons []comparison, threshold float64) string {
+	// Define styles
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFF00"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888888"))
+
+	lowStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#00FF87"))
+
+	highStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FF0087"))
+
+	valueStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFFFF"))
+
+	alertStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#000000")).
+		Background(lipgloss.Color("#FFFF00")).
+		Padding(0, 1)
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF0087"))
+
+	// optional formats a bid or ask the venue may not have
+	optional := func(v float64) string {
+		if v == 0 {
+			return "-"
+		}
+		return formatPrice(v)
+	}
+
+	var s strings.Builder
+
+	for _, c := range comparisons {
+		s.WriteString(headerStyle.Render(fmt.Sprintf("\n%s across %d exchanges (USD):", c.Asset, len(c.Quotes))))
+		s.WriteString("\n")
+		s.WriteString(labelStyle.Render(fmt.Sprintf("%-16s %-12s %-6s %14s %14s %14s %10s",
+			"Exchange", "Symbol", "Quote", "Price", "Bid", "Ask", "Premium")))
+		s.WriteString("\n")
+		s.WriteString(strings.Repeat("─", 92))
+		s.WriteString("\n")
+
+		for i, q := range c.Quotes {
+			if q.err != nil {
+				s.WriteString(fmt.Sprintf("%-16s %-12s %s\n",
+					strings.ToUpper(q.Exchange), q.Symbol, errorStyle.Render(fmt.Sprintf("Error: %s", q.Error))))
+				continue
+			}
+
+			price := valueStyle.Render(fmt.Sprintf("%14s", formatPrice(q.Price)))
+			switch {
+			case c.Low == c.High:
+				// A single price has nothing to compare against
+			case i == c.Low:
+				price = lowStyle.Render(fmt.Sprintf("%14s", formatPrice(q.Price)))
+			case i == c.High:
+				price = highStyle.Render(fmt.Sprintf("%14s", formatPrice(q.Price)))
+			}
+
+			s.WriteString(fmt.Sprintf("%-16s %-12s %-6s %s %14s %14s %10s\n",
+				strings.ToUpper(q.Exchange),
+				q.Symbol,
+				q.Quote,
+				price,
+				optional(q.Bid),
+				optional(q.Ask),
+				fmt.Sprintf("%.2f bps", q.Premium)))
+		}
+
+		s.WriteString(strings.Repeat("─", 92))
+		s.WriteString("\n")
+
+		if c.Low < 0 {
+			s.WriteString(errorStyle.Render("No exchange has a price"))
+			s.WriteString("\n")
+			continue
+		}
+		if c.Low == c.High {
+			s.WriteString(labelStyle.Render(fmt.Sprintf("Only %s has a price", strings.ToUpper(c.Quotes[c.Low].Exchange))))
+			s.WriteString("\n")
+			continue
+		}
+
+		spread, spreadBps := c.spread()
+		line := fmt.Sprintf("Spread: %s (%.2f bps) • cheapest %s, dearest %s",
+			formatPrice(spread), spreadBps, strings.ToUpper(c.Quotes[c.Low].Exchange), strings.ToUpper(c.Quotes[c.High].Exchange))
+		if threshold > 0 && spreadBps > threshold {
+			s.WriteString(alertStyle.Render(fmt.Sprintf("%s • above %g bps", line, threshold)))
+		} else {
+			s.WriteString(valueStyle.Render(line))
+		}
+		s.WriteString("\n")
+
+		if c.Buy >= 0 {
+			buy, sell := c.Quotes[c.Buy], c.Quotes[c.Sell]
+			s.WriteString(lowStyle.Render(fmt.Sprintf("Arbitrage: buy on %s at %s, sell on %s at %s (%+.2f bps before fees)",
+				strings.ToUpper(buy.Exchange), formatPrice(buy.Ask), strings.ToUpper(sell.Exchange), formatPrice(sell.Bid),
+				(sell.Bid-buy.Ask)/buy.Ask*10000)))
+			s.WriteString("\n")
+		}
+	}
+
+	s.WriteString("\n")
+	return s.String()
+}
+
+// compareMsg delivers a refreshed comparison to the watch view
+type compareMsg struct {
+	comparisons []comparison
+	at          time.Time
+}
+
+// compareTickMsg asks the watch view to refresh
+type compareTickMsg struct{}
+
+// compareModel is the --watch view; the next refresh is scheduled only once the
+// previous one has arrived, so slow exchanges never pile up requests
+type compareModel struct {
+	ctx         context.Context
+	cancel      context.CancelFunc
+	clients     []exchange.Exchange
+	assets      []string
+	comparisons []comparison
+	updated     time.Time
+	quitting    bool
+}
+
+func (m compareModel) Init() tea.Cmd {
+	return m.fetch()
+}
+
+func (m compareModel) fetch() tea.Cmd {
+	return func() tea.Msg {
+		return compareMsg{comparisons: fetchComparisons(m.ctx, m.clients, m.assets), at: time.Now()}
+	}
+}
+
+func (m compareModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c":
+			// Stop the requests in flight rather than waiting for them
+			m.cancel()
+			m.quitting = true
+			return m, tea.Quit
+		}
+
+	case compareTickMsg:
+		return m, m.fetch()
+
+	case compareMsg:
+		m.comparisons = msg.comparisons
+		m.updated = msg.at
+		return m, tea.Tick(time.Duration(compareInterval)*time.Second, func(time.Time) tea.Msg {
+			return compareTickMsg{}
+		})
+	}
+
+	return m, nil
+}
+
+func (m compareModel) View() string {
+	if m.quitting {
+		return "Goodbye!\n"
+	}
+
+	// Define styles
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFF00")).
+		Background(lipgloss.Color("#333333")).
+		Padding(0, 1)
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888888")).
+		Italic(true)
+
+	names := make([]string, len(m.clients))
+	for i, client := range m.clients {
+		names[i] = strings.ToUpper(client.GetName())
+	}
+
+	var s strings.Builder
+	s.WriteString(titleStyle.Render(fmt.Sprintf(" Cross-exchange Prices (%s) ", strings.Join(names, ", "))))
+	s.WriteString("\n")
+
+	if m.comparisons == nil {
+		s.WriteString("\nLoading prices...\n\n")
+	} else {
+		s.WriteString(renderComparisons(m.comparisons, compareThreshold))
+	}
+
+	help := fmt.Sprintf("Refreshing every %d seconds", compareInterval)
+	if !m.updated.IsZero() {
+		help += " • updated " + m.updated.Format("15:04:05")
+	}
+	if compareThreshold > 0 {
+		help += fmt.Sprintf(" • highlighting spreads above %g bps", compareThreshold)
+	}
+	s.WriteString(helpStyle.Render(help + " • Press 'q' to quit"))
+	s.WriteString("\n")
+
+	return s.String()
+}
+
+func init() {
+	rootCmd.AddCommand(compareCmd)
+	compareCmd.Flags().StringSliceVar(&compareExchanges, "exchanges", exchange.SupportedExchanges, "exchanges to compare, comma separated")
+	compareCmd.Flags().BoolVarP(&compareWatch, "watch", "w", false, "keep refreshing the comparison")
+	compareCmd.Flags().IntVarP(&compareInterval, "interval", "i", 5, "refresh interval in seconds with --watch")
+	compareCmd.Flags().Float64Var(&compareThreshold, "threshold", 10, "highlight spreads wider than this many basis points (0 to disable)")
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+)
+
+// stubVenue serves the tickers set by the test, keyed by "BASE/QUOTE", and
+// counts the batches it is asked for
+type stubVenue struct {
+	name    string
+	quote   string
+	tickers map[string]models.Ticker
+	batches int
+}
+
+func (s *stubVenue) GetPrice(ctx context.Context, symbol string) (float64, error) {
+	t, err := s.GetTicker(ctx, symbol)
+	if err != nil {
+		return 0, err
+	}
+	return t.Price, nil
+}
+
+func (s *stubVenue) GetTicker(ctx context.Context, symbol string) (*models.Ticker, error) {
+	t, ok := s.tickers[s.NormalizeSymbol(symbol)]
+	if !ok {
+		return nil, &exchange.Error{Exchange: s.name, Kind: exchange.ErrUnknownSymbol, Err: errors.New("unknown symbol " + symbol)}
+	}
+	t.Symbol = s.NormalizeSymbol(symbol)
+	return &t, nil
+}
+
+func (s *stubVenue) GetPrices(ctx context.Context, symbols []string) []exchange.Result[float64] {
+	s.batches++
+	return exchange.EachSymbol(ctx, symbols, s.GetPrice)
+}
+
+func (s *stubVenue) GetTickers(ctx context.Context, symbols []string) []exchange.Result[*models.Ticker] {
+	s.batches++
+	return exchange.EachSymbol(ctx, symbols, s.GetTicker)
+}
+
+func (s *stubVenue) GetCandles(ctx context.Context, symbol, interval string, limit int) ([]models.Candle, error) {
+	return nil, exchange.ErrUnsupported
+}
+
+// NormalizeSymbol spells a bare symbol as a pair with the venue's default quote
+func (s *stubVenue) NormalizeSymbol(symbol string) string {
+	symbol = strings.ToUpper(symbol)
+	if !strings.Contains(symbol, "/") {
+		symbol += "/" + s.quote
+	}
+	return symbol
+}
+
+func (s *stubVenue) GetName() string {
+	return s.name
+}
+
+func (s *stubVenue) Capabilities() exchange.Capabilities {
+	return exchange.Capabilities{}
+}
+
+func TestUSDRates(t *testing.T) {
+	first := &stubVenue{name: "first", tickers: map[string]models.Ticker{
+		"EUR/USD": {Price: 1.08},
+	}}
+	second := &stubVenue{name: "second", tickers: map[string]models.Ticker{
+		"EUR/USD":  {Price: 2}, // the first exchange that prices a currency wins
+		"USDT/USD": {Price: 0.999},
+	}}
+
+	rates, errs := usdRates(context.Background(), []exchange.Exchange{first, second},
+		[]string{"USD", "EUR", "USDT", "FDUSD", "XYZ", ""})
+
+	want := map[string]float64{"USD": 1, "EUR": 1.08, "USDT": 0.999, "FDUSD": 1}
+	for currency, rate := range want {
+		if rates[currency] != rate {
+			t.Errorf("rate of %q = %v, want %v", currency, rates[currency], rate)
+		}
+	}
+	for _, currency := range []string{"XYZ", ""} {
+		if _, ok := rates[currency]; ok || !errors.Is(errs[currency], exchange.ErrUnsupported) {
+			t.Errorf("%q: rate %v, err %v; want unsupported", currency, rates[currency], errs[currency])
+		}
+	}
+
+	// The second exchange is only asked for what the first could not price
+	if first.batches != 1 || second.batches != 1 {
+		t.Errorf("batches = %d and %d, want one each", first.batches, second.batches)
+	}
+}
+
+func TestFetchComparisons(t *testing.T) {
+	usdt := &stubVenue{name: "usdt", quote: "USDT", tickers: map[string]models.Ticker{
+		"BTC/USDT": {Price: 50000, Bid: 49990, Ask: 50010},
+		"ETH/USDT": {Price: 3000},
+		"USDT/USD": {Price: 1.002},
+	}}
+	usd := &stubVenue{name: "usd", quote: "USD", tickers: map[string]models.Ticker{
+		"BTC/USD": {Price: 50300, Bid: 50200, Ask: 50400},
+	}}
+	venues := []exchange.Exchange{usdt, usd}
+
+	comparisons := fetchComparisons(context.Background(), venues, []string{"BTC", "ETH"})
+
+	// One ticker batch per venue, and one batch for the USDT rate
+	if usdt.batches != 2 || usd.batches != 1 {
+		t.Errorf("batches = %d and %d, want 2 and 1", usdt.batches, usd.batches)
+	}
+
+	near := func(got, want float64) bool { return math.Abs(got-want) < 1e-6 }
+
+	btc := comparisons[0]
+	cheap, dear := btc.Quotes[0], btc.Quotes[1]
+	if cheap.Quote != "USDT" || cheap.Rate != 1.002 || !near(cheap.Price, 50100) ||
+		!near(cheap.Bid, 49990*1.002) || !near(cheap.Ask, 50010*1.002) {
+		t.Errorf("USDT venue = %+v, want its prices converted at 1.002", cheap)
+	}
+	if dear.Quote != "USD" || dear.Rate != 1 || dear.Price != 50300 {
+		t.Errorf("USD venue = %+v", dear)
+	}
+
+	// 200 USD on the cheapest 50100 is 39.92 bps
+	if btc.Low != 0 || btc.High != 1 {
+		t.Errorf("low, high = %d, %d; want 0, 1", btc.Low, btc.High)
+	}
+	spread, spreadBps := btc.spread()
+	if !near(spread, 200) || !near(spreadBps, 200/50100.0*10000) {
+		t.Errorf("spread = %v (%v bps), want 200 (39.92 bps)", spread, spreadBps)
+	}
+	if cheap.Premium != 0 || !near(dear.Premium, spreadBps) || !near(cheap.SpreadBps, spreadBps) {
+		t.Errorf("premiums = %v and %v, want 0 and %v", cheap.Premium, dear.Premium, spreadBps)
+	}
+
+	// The USD venue's bid of 50200 is above the USDT venue's ask of 50110.02
+	if btc.Buy != 0 || btc.Sell != 1 {
+		t.Errorf("buy, sell = %d, %d; want an arbitrage from usdt to usd", btc.Buy, btc.Sell)
+	}
+
+	// A venue that does not list the symbol fails alone
+	eth := comparisons[1]
+	if eth.Quotes[1].err == nil || eth.Quotes[1].ErrorKind == "" {
+		t.Errorf("ETH on usd = %+v, want an error", eth.Quotes[1])
+	}
+	if eth.Low != 0 || eth.High != 0 {
+		t.Errorf("ETH low, high = %d, %d; want only the usdt venue", eth.Low, eth.High)
+	}
+}
+
+func TestComparisonRank(t *testing.T) {
+	c := comparison{Quotes: []venueQuote{
+		{Exchange: "a", Price: 100, Bid: 99.9, Ask: 100.1},
+		{Exchange: "b", Price: 100.5, Bid: 100.4, Ask: 100.6},
+		{Exchange: "c", err: exchange.ErrUnavailable},
+		{Exchange: "d", Price: 99.5},
+	}}
+	c.rank()
+
+	if c.Low != 3 || c.High != 1 {
+		t.Errorf("low, high = %d, %d; want 3, 1", c.Low, c.High)
+	}
+	spread, spreadBps := c.spread()
+	if math.Abs(spread-1) > 1e-9 || math.Abs(spreadBps-1/99.5*10000) > 1e-9 {
+		t.Errorf("spread = %v (%v bps), want 1 (100.50 bps)", spread, spreadBps)
+	}
+
+	// b's bid is above a's ask
+	if c.Buy != 0 || c.Sell != 1 {
+		t.Errorf("buy, sell = %d, %d; want 0, 1", c.Buy, c.Sell)
+	}
+
+	// Books that do not cross are no arbitrage
+	c.Quotes[1].Bid = 100
+	c.rank()
+	if c.Buy != -1 || c.Sell != -1 {
+		t.Errorf("buy, sell = %d, %d; want none", c.Buy, c.Sell)
+	}
+
+	none := comparison{Quotes: []venueQuote{{err: exchange.ErrUnavailable}}}
+	none.rank()
+	if spread, bps := none.spread(); none.Low != -1 || spread != 0 || bps != 0 {
+		t.Errorf("no prices: low %d, spread %v (%v bps)", none.Low, spread, bps)
+	}
+}
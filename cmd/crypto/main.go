@@ -134,12 +134,14 @@ func main() {
 		sb.WriteString(labelStyle.Render("成交量: ") + priceStyle.Render(formatVolume(ticker.Volume24h)) + "\n")
 	}
 
-	// 获取 K 线数据绘制走势图
-	candles, err := client.GetCandles(ctx, symbol, "1h", 24)
-	if err == nil && len(candles) > 0 {
-		sb.WriteString("\n")
-		sb.WriteString(labelStyle.Render("━━━ 24小时走势 ━━━\n"))
-		sb.WriteString(renderMiniChart(candles))
+	// 获取 K 线数据绘制走势图（交易所不支持小时 K 线时不显示）
+	if client.Capabilities().SupportsInterval("1h") {
+		candles, err := client.GetCandles(ctx, symbol, "1h", 24)
+		if err == nil && len(candles) > 0 {
+			sb.WriteString("\n")
+			sb.WriteString(labelStyle.Render("━━━ 24小时走势 ━━━\n"))
+			sb.WriteString(renderMiniChart(candles))
+		}
 	}
 
 	// 底部信息
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var depthLevels int
+
+// depthBarWidth is the width of the cumulative size bar next to each level
+const depthBarWidth = 20
+
+var depthCmd = &cobra.Command{
+	Use:   "depth [symbol]",
+	Short: "Show the order book ladder for a cryptocurrency symbol",
+	Long: `Show a two-sided order book ladder with cumulative size, spread and mid price.
+
+Examples:
+  terminalcrypto depth BTC
+  terminalcrypto depth ETH --levels 20
+  terminalcrypto --exchange okx depth BTC/USDT`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+
+		// Create exchange client
+		client, err := newExchangeClient()
+		if err != nil {
+			return err
+		}
+
+		provider, ok := exchange.As[exchange.OrderBookProvider](client)
+		if !ok || !client.Capabilities().OrderBook {
+			return fmt.Errorf("%s does not provide order book data: %w", client.GetName(), exchange.ErrUnsupported)
+		}
+
+		symbol, err := resolveSymbol(ctx, client, args[0])
+		if err != nil {
+			return err
+		}
+
+		book, err := provider.GetOrderBook(ctx, symbol, depthLevels)
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(renderOrderBook(book, client.GetName()))
+		return nil
+	},
+}
+
+// renderOrderBook draws asks above bids, best prices meeting at the spread line
+func renderOrderBook(book *models.OrderBook, exchangeName string) string {
+	// Define styles
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFF00"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888888"))
+
+	askStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF0087"))
+
+	bidStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#00FF87"))
+
+	valueStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFFFF"))
+
+	askCumulative := cumulativeSizes(book.Asks)
+	bidCumulative := cumulativeSizes(book.Bids)
+
+	maxCumulative := 0.0
+	if len(askCumulative) > 0 {
+		maxCumulative = askCumulative[len(askCumulative)-1]
+	}
+	if len(bidCumulative) > 0 && bidCumulative[len(bidCumulative)-1] > maxCumulative {
+		maxCumulative = bidCumulative[len(bidCumulative)-1]
+	}
+
+	var s strings.Builder
+
+	s.WriteString(headerStyle.Render(fmt.Sprintf("\nOrder Book %s (%s):", book.Symbol, strings.ToUpper(exchangeName))))
+	s.WriteString("\n")
+	s.WriteString(strings.Repeat("═", 70))
+	s.WriteString("\n")
+	s.WriteString(labelStyle.Render(fmt.Sprintf("%16s %14s %14s", "Price", "Size", "Cumulative")))
+	s.WriteString("\n")
+
+	// Asks are listed from the furthest level down to the best ask
+	for i := len(book.Asks) - 1; i >= 0; i-- {
+		s.WriteString(renderLevel(book.Asks[i], askCumulative[i], maxCumulative, askStyle))
+	}
+
+	// Spread and mid price
+	if len(book.Asks) > 0 && len(book.Bids) > 0 {
+		bestAsk := book.Asks[0].Price
+		bestBid := book.Bids[0].Price
+		mid := (bestAsk + bestBid) / 2
+		spread := bestAsk - bestBid
+		spreadBps := 0.0
+		if mid > 0 {
+			spreadBps = spread / mid * 10000
+		}
+
+		s.WriteString(labelStyle.Render(strings.Repeat("─", 8)))
+		s.WriteString(valueStyle.Render(fmt.Sprintf(" Spread: %s (%.2f bps) • Mid: %s ",
+			formatPrice(spread), spreadBps, formatPrice(mid))))
+		s.WriteString(labelStyle.Render(strings.Repeat("─", 8)))
+		s.WriteString("\n")
+	} else {
+		s.WriteString(labelStyle.Render("──────── One side of the book is empty ────────"))
+		s.WriteString("\n")
+	}
+
+	// Bids are listed from the best bid down
+	for i, level := range book.Bids {
+		s.WriteString(renderLevel(level, bidCumulative[i], maxCumulative, bidStyle))
+	}
+
+	s.WriteString(strings.Repeat("═", 70))
+	s.WriteString("\n")
+	s.WriteString(labelStyle.Render(fmt.Sprintf("Update ID %d • %s", book.UpdateID, book.Timestamp.Format("2006-01-02 15:04:05"))))
+	s.WriteString("\n\n")
+
+	return s.String()
+}
+
+// renderLevel renders one ladder row with a bar proportional to the cumulative size
+func renderLevel(level models.OrderBookLevel, cumulative, maxCumulative float64, style lipgloss.Style) string {
+	barLen := 0
+	if maxCumulative > 0 {
+		barLen = int(cumulative / maxCumulative * depthBarWidth)
+	}
+
+	return fmt.Sprintf("%s %14s %14s  %s\n",
+		style.Render(fmt.Sprintf("%16s", formatPrice(level.Price))),
+		formatQuantity(level.Quantity),
+		formatQuantity(cumulative),
+		style.Render(strings.Repeat("█", barLen)))
+}
+
+// cumulativeSizes returns the running total of quantity from the best level outwards
+func cumulativeSizes(levels []models.OrderBookLevel) []float64 {
+	totals := make([]float64, len(levels))
+	running := 0.0
+	for i, level := range levels {
+		running += level.Quantity
+		totals[i] = running
+	}
+	return totals
+}
+
+func init() {
+	rootCmd.AddCommand(depthCmd)
+	depthCmd.Flags().IntVarP(&depthLevels, "levels", "l", 10, "number of price levels to show on each side")
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+)
+
+// Exit codes, one per class of exchange error, so scripts can react to each
+const (
+	exitCodeError         = 1
+	exitCodeUnknownSymbol = 2
+	exitCodeRateLimited   = 3
+	exitCodeUnavailable   = 4
+	exitCodeUnsupported   = 5
+	exitCodeAuth          = 6
+)
+
+// reportedError wraps an error whose details were already printed, so that
+// Execute only sets the exit code
+type reportedError struct {
+	err error
+}
+
+func (e *reportedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *reportedError) Unwrap() error {
+	return e.err
+}
+
+// exitCode maps an error to the process exit code for its class
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, exchange.ErrUnknownSymbol):
+		return exitCodeUnknownSymbol
+	case errors.Is(err, exchange.ErrRateLimited):
+		return exitCodeRateLimited
+	case errors.Is(err, exchange.ErrUnavailable):
+		return exitCodeUnavailable
+	case errors.Is(err, exchange.ErrUnsupported):
+		return exitCodeUnsupported
+	case errors.Is(err, exchange.ErrAuth):
+		return exitCodeAuth
+	default:
+		return exitCodeError
+	}
+}
+
+// errorKind names an error's class for machine-readable output
+func errorKind(err error) string {
+	switch exitCode(err) {
+	case exitCodeUnknownSymbol:
+		return "unknown_symbol"
+	case exitCodeRateLimited:
+		return "rate_limited"
+	case exitCodeUnavailable:
+		return "unavailable"
+	case exitCodeUnsupported:
+		return "unsupported"
+	case exitCodeAuth:
+		return "auth"
+	default:
+		return "error"
+	}
+}
+
+// describeError turns an error into a message that says what went wrong and what to do about it
+func describeError(err error) string {
+	// Name the exchange that failed, which is not the selected one when several are queried
+	exchangeName := exchangeName
+	var exchangeErr *exchange.Error
+	if errors.As(err, &exchangeErr) && exchangeErr.Exchange != "" {
+		exchangeName = exchangeErr.Exchange
+	}
+
+	switch {
+	case errors.Is(err, exchange.ErrUnknownSymbol):
+		return fmt.Sprintf("%v (run 'terminalcrypto markets --search <name>' to find the right symbol)", err)
+	case errors.Is(err, exchange.ErrRateLimited):
+		if wait, ok := exchange.RetryAfter(err); ok {
+			return fmt.Sprintf("rate limited by %s, retry in %s", exchangeName, wait.Round(time.Second))
+		}
+		return fmt.Sprintf("rate limited by %s, wait a moment or poll less often (e.g. a larger --interval)", exchangeName)
+	case errors.Is(err, exchange.ErrUnavailable):
+		return fmt.Sprintf("%s is unreachable (%v); check your connection or try another exchange with --exchange", exchangeName, err)
+	case errors.Is(err, exchange.ErrUnsupported):
+		return fmt.Sprintf("%v (run 'terminalcrypto exchanges' to see what each exchange supports)", err)
+	case errors.Is(err, exchange.ErrAuth):
+		return fmt.Sprintf("%s rejected the API credentials (%v); run '%s'", exchangeName, err, setupCommand(exchangeName))
+	default:
+		return err.Error()
+	}
+}
+
+// setupCommand returns the command that stores the API credentials of the named
+// exchange; a testnet client such as "binance-testnet" is set up with --testnet
+func setupCommand(name string) string {
+	if base, ok := strings.CutSuffix(name, "-testnet"); ok {
+		return "terminalcrypto setup " + base + " --testnet"
+	}
+	return "terminalcrypto setup " + name
+}
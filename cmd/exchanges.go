@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/config"
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+	"github.com/Carpe-Wang/terminalCrypto/internal/keyring"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var exchangesCmd = &cobra.Command{
+	Use:   "exchanges",
+	Short: "List supported exchanges and what each one can do",
+	Long: `List every supported exchange with its capabilities: candle intervals,
+streaming, order book, trades, candle history, market listing and authenticated access.
+
+Example:
+  terminalcrypto exchanges`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Define styles
+		headerStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#FFFF00"))
+
+		nameStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#00D4FF"))
+
+		labelStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#888888"))
+
+		yesStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#00FF87"))
+
+		noStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF0087"))
+
+		mark := func(ok bool) string {
+			if ok {
+				return yesStyle.Render(fmt.Sprintf("%-7s", "yes"))
+			}
+			return noStyle.Render(fmt.Sprintf("%-7s", "no"))
+		}
+
+		fmt.Println(headerStyle.Render("\nSupported exchanges:"))
+		fmt.Println(strings.Repeat("═", 104))
+		fmt.Println(labelStyle.Render(fmt.Sprintf("  %-10s %-7s %-7s %-7s %-7s %-7s %-7s %-7s %-7s %-11s %s",
+			"Exchange", "Batch", "Stream", "Book", "Trades", "History", "Markets", "Auth", "Trade", "Max Candles", "Intervals")))
+
+		for _, name := range exchange.SupportedExchanges {
+			var apiKey, apiSecret string
+			if creds, err := keyring.GetCredentials(name); err == nil {
+				apiKey = creds.APIKey
+				apiSecret = creds.APISecret
+			}
+
+			client, err := exchange.Factory(name, apiKey, apiSecret)
+			if err != nil {
+				fmt.Printf("  %s %s\n", nameStyle.Render(fmt.Sprintf("%-10s", name)), noStyle.Render(err.Error()))
+				continue
+			}
+
+			current := " "
+			if name == exchangeName {
+				current = "*"
+			}
+
+			caps := client.Capabilities()
+			intervals := "none"
+			if len(caps.Intervals) > 0 {
+				intervals = strings.Join(caps.Intervals, " ")
+			}
+
+			fmt.Printf("%s %s %s %s %s %s %s %s %s %s %-11d %s\n",
+				current,
+				nameStyle.Render(fmt.Sprintf("%-10s", name)),
+				mark(caps.Batch),
+				mark(caps.Streaming),
+				mark(caps.OrderBook),
+				mark(caps.Trades),
+				mark(caps.CandleHistory),
+				mark(caps.Markets),
+				mark(caps.Authenticated),
+				mark(caps.Trading),
+				caps.MaxCandleLimit,
+				intervals)
+		}
+
+		fmt.Println(strings.Repeat("═", 104))
+		fmt.Println(labelStyle.Render("* current exchange"))
+		fmt.Println(labelStyle.Render("Prefix any exchange with paper: (e.g. paper:binance) to paper trade at its prices"))
+		fmt.Println(labelStyle.Render("Use composite for a price combined from composite.exchanges (" + strings.Join(config.GetCompositeExchanges(), ", ") + ")"))
+		fmt.Println()
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exchangesCmd)
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+)
+
+// formatPrice formats a price with precision that suits its magnitude
+func formatPrice(price float64) string {
+	if price >= 100 {
+		return fmt.Sprintf("$%.2f", price)
+	} else if price >= 1 {
+		return fmt.Sprintf("$%.4f", price)
+	} else if price >= 0.01 {
+		return fmt.Sprintf("$%.6f", price)
+	}
+	return fmt.Sprintf("$%.8f", price)
+}
+
+// formatQuotePrice formats a price in its quote currency: USD and USD
+// stablecoins in dollars, e.g. $0.000012, and any other currency by its code,
+// e.g. 0.051200 BTC
+func formatQuotePrice(price float64, quote string) string {
+	if quote == "" || quote == "USD" || exchange.IsUSDStablecoin(quote) {
+		return formatPrice(price)
+	}
+	return strings.TrimPrefix(formatPrice(price), "$") + " " + quote
+}
+
+// formatQuantity formats a base-asset quantity compactly
+func formatQuantity(quantity float64) string {
+	if quantity >= 1000 {
+		return fmt.Sprintf("%.2f", quantity)
+	} else if quantity >= 1 {
+		return fmt.Sprintf("%.4f", quantity)
+	}
+	return fmt.Sprintf("%.6f", quantity)
+}
+
+// formatCompact formats a large amount with a K, M or B suffix, e.g. 12.35M
+func formatCompact(amount float64) string {
+	switch abs := math.Abs(amount); {
+	case abs >= 1e9:
+		return fmt.Sprintf("%.2fB", amount/1e9)
+	case abs >= 1e6:
+		return fmt.Sprintf("%.2fM", amount/1e6)
+	case abs >= 1e3:
+		return fmt.Sprintf("%.2fK", amount/1e3)
+	}
+	return fmt.Sprintf("%.2f", amount)
+}
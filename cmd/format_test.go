@@ -0,0 +1,24 @@
+package cmd
+
+import "testing"
+
+func TestFormatQuotePrice(t *testing.T) {
+	tests := []struct {
+		price float64
+		quote string
+		want  string
+	}{
+		{65000.5, "USDT", "$65000.50"},
+		{1.5, "USD", "$1.5000"},
+		{0.00001234, "USDC", "$0.00001234"},
+		{0.0512, "BTC", "0.051200 BTC"},
+		{2500, "EUR", "2500.00 EUR"},
+		{0.5, "", "$0.500000"},
+	}
+
+	for _, tt := range tests {
+		if got := formatQuotePrice(tt.price, tt.quote); got != tt.want {
+			t.Errorf("formatQuotePrice(%v, %q) = %q, want %q", tt.price, tt.quote, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,380 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+	"github.com/Carpe-Wang/terminalCrypto/internal/indicators"
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+// indicatorOptions are the indicators requested on the command line
+type indicatorOptions struct {
+	sma      []int
+	ema      []int
+	rsi      int
+	macd     []int
+	bb       int
+	bbStdDev float64
+	atr      int
+	vwap     bool
+}
+
+// addIndicatorFlags registers the indicator flags shared by the indicators and chart commands
+func addIndicatorFlags(cmd *cobra.Command, o *indicatorOptions) {
+	cmd.Flags().IntSliceVar(&o.sma, "sma", nil, "simple moving average periods, e.g. 20,50")
+	cmd.Flags().IntSliceVar(&o.ema, "ema", nil, "exponential moving average periods, e.g. 20,50")
+	cmd.Flags().IntVar(&o.rsi, "rsi", 0, "relative strength index period, e.g. 14")
+	cmd.Flags().IntSliceVar(&o.macd, "macd", nil, "MACD fast,slow,signal periods, e.g. 12,26,9")
+	cmd.Flags().IntVar(&o.bb, "bb", 0, "Bollinger Bands period, e.g. 20")
+	cmd.Flags().Float64Var(&o.bbStdDev, "bb-stddev", 2, "Bollinger Bands width in standard deviations")
+	cmd.Flags().IntVar(&o.atr, "atr", 0, "average true range period, e.g. 14")
+	cmd.Flags().BoolVar(&o.vwap, "vwap", false, "volume-weighted average price (reset daily on intraday intervals)")
+}
+
+// validate checks the indicator periods
+func (o indicatorOptions) validate() error {
+	for _, p := range append(append([]int{}, o.sma...), o.ema...) {
+		if p <= 0 {
+			return fmt.Errorf("moving average periods must be positive, got %d", p)
+		}
+	}
+	if o.rsi < 0 || o.bb < 0 || o.atr < 0 {
+		return fmt.Errorf("indicator periods must be positive")
+	}
+	if len(o.macd) > 0 {
+		if len(o.macd) != 3 || o.macd[0] <= 0 || o.macd[1] <= o.macd[0] || o.macd[2] <= 0 {
+			return fmt.Errorf("--macd takes fast,slow,signal periods with fast < slow, e.g. 12,26,9")
+		}
+	}
+	return nil
+}
+
+// empty reports whether no indicator was requested
+func (o indicatorOptions) empty() bool {
+	return len(o.sma) == 0 && len(o.ema) == 0 && o.rsi == 0 && len(o.macd) == 0 && o.bb == 0 && o.atr == 0 && !o.vwap
+}
+
+// panelCount returns how many oscillator panes the indicators need
+func (o indicatorOptions) panelCount() int {
+	n := 0
+	if o.rsi > 0 {
+		n++
+	}
+	if len(o.macd) > 0 {
+		n++
+	}
+	if o.atr > 0 {
+		n++
+	}
+	return n
+}
+
+// warmup returns how many candles the slowest indicator needs before its values settle
+func (o indicatorOptions) warmup() int {
+	longest := max(o.rsi, o.bb, o.atr)
+	for _, p := range o.sma {
+		longest = max(longest, p)
+	}
+	for _, p := range o.ema {
+		longest = max(longest, p)
+	}
+	if len(o.macd) == 3 {
+		longest = max(longest, o.macd[1]+o.macd[2])
+	}
+
+	// Exponential smoothing (EMA, RSI, ATR) needs a few periods to forget its seed
+	return longest * 4
+}
+
+// indicatorSeries is one line of indicator values aligned with the candles
+type indicatorSeries struct {
+	name   string
+	values []float64
+	color  string
+
+	// panel groups oscillators drawn together below the chart; empty for price overlays
+	panel string
+	bars  bool
+}
+
+// seriesColors are assigned to indicator lines in turn
+var seriesColors = []string{"#FFD700", "#00D4FF", "#FF8C00", "#DA70D6", "#7FFFD4", "#FF69B4"}
+
+// computeIndicators evaluates the requested indicators over candles
+func computeIndicators(candles []models.Candle, interval string, o indicatorOptions) []indicatorSeries {
+	closes := indicators.Closes(candles)
+	var series []indicatorSeries
+	add := func(s indicatorSeries) {
+		s.color = seriesColors[len(series)%len(seriesColors)]
+		series = append(series, s)
+	}
+
+	for _, p := range o.sma {
+		add(indicatorSeries{name: fmt.Sprintf("SMA(%d)", p), values: indicators.Series(closes, indicators.NewSMA(p).Update)})
+	}
+	for _, p := range o.ema {
+		add(indicatorSeries{name: fmt.Sprintf("EMA(%d)", p), values: indicators.Series(closes, indicators.NewEMA(p).Update)})
+	}
+
+	if o.bb > 0 {
+		bands := indicators.NewBollinger(o.bb, o.bbStdDev)
+		upper := make([]float64, len(closes))
+		middle := make([]float64, len(closes))
+		lower := make([]float64, len(closes))
+		for i, c := range closes {
+			band, ok := bands.Update(c)
+			if !ok {
+				upper[i], middle[i], lower[i] = math.NaN(), math.NaN(), math.NaN()
+				continue
+			}
+			upper[i], middle[i], lower[i] = band.Upper, band.Middle, band.Lower
+		}
+
+		name := fmt.Sprintf("BB(%d,%g)", o.bb, o.bbStdDev)
+		add(indicatorSeries{name: name + " upper", values: upper})
+		add(indicatorSeries{name: name + " mid", values: middle})
+		add(indicatorSeries{name: name + " lower", values: lower})
+	}
+
+	if o.vwap {
+		vwap := indicators.NewVWAP()
+		if d, ok := exchange.IntervalDuration(interval); ok && d < 24*time.Hour {
+			vwap = indicators.NewDailyVWAP()
+		}
+		add(indicatorSeries{name: "VWAP", values: indicators.CandleSeries(candles, vwap.Update)})
+	}
+
+	if o.rsi > 0 {
+		name := fmt.Sprintf("RSI(%d)", o.rsi)
+		add(indicatorSeries{name: name, panel: name, values: indicators.Series(closes, indicators.NewRSI(o.rsi).Update)})
+	}
+
+	if len(o.macd) == 3 {
+		m := indicators.NewMACD(o.macd[0], o.macd[1], o.macd[2])
+		line := make([]float64, len(closes))
+		signal := make([]float64, len(closes))
+		histogram := make([]float64, len(closes))
+		for i, c := range closes {
+			v, ok := m.Update(c)
+			if !ok {
+				line[i], signal[i], histogram[i] = math.NaN(), math.NaN(), math.NaN()
+				continue
+			}
+			line[i], signal[i], histogram[i] = v.MACD, v.Signal, v.Histogram
+		}
+
+		name := fmt.Sprintf("MACD(%d,%d,%d)", o.macd[0], o.macd[1], o.macd[2])
+		add(indicatorSeries{name: name, panel: name, values: line})
+		add(indicatorSeries{name: "signal", panel: name, values: signal})
+		add(indicatorSeries{name: "histogram", panel: name, values: histogram, bars: true})
+	}
+
+	if o.atr > 0 {
+		name := fmt.Sprintf("ATR(%d)", o.atr)
+		add(indicatorSeries{name: name, panel: name, values: indicators.CandleSeries(candles, indicators.NewATR(o.atr).Update)})
+	}
+
+	return series
+}
+
+// fetchRecentCandles returns the latest count candles, paging through history
+// when that is more than one request returns
+func fetchRecentCandles(ctx context.Context, client exchange.Exchange, symbol, interval string, count int) ([]models.Candle, error) {
+	caps := client.Capabilities()
+	if count <= caps.MaxCandleLimit || !caps.CandleHistory {
+		return client.GetCandles(ctx, symbol, interval, min(count, caps.MaxCandleLimit))
+	}
+
+	step, ok := exchange.IntervalDuration(interval)
+	if !ok {
+		return nil, &exchange.UnsupportedIntervalError{Exchange: client.GetName(), Interval: interval}
+	}
+
+	end := time.Now()
+	return exchange.FetchCandleHistory(ctx, client, symbol, interval, end.Add(-step*time.Duration(count)), end)
+}
+
+var (
+	indicatorsInterval string
+	indicatorsRows     int
+	indicatorsOpts     indicatorOptions
+)
+
+var indicatorsCmd = &cobra.Command{
+	Use:   "indicators [symbol]",
+	Short: "Compute technical indicators for a cryptocurrency symbol",
+	Long: `Compute technical indicators over recent candles: simple and exponential
+moving averages, RSI, MACD, Bollinger Bands, ATR and VWAP.
+Enough extra history is fetched for the values shown to be settled.
+
+Examples:
+  terminalcrypto indicators BTC --interval 1h --rsi 14 --ema 20,50
+  terminalcrypto indicators ETH --macd 12,26,9 --bb 20 --rows 5
+  terminalcrypto indicators SOL -i 15m --vwap --atr 14 -o csv`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{structuredOutputAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		if err := indicatorsOpts.validate(); err != nil {
+			return err
+		}
+		if indicatorsOpts.empty() {
+			return fmt.Errorf("no indicator requested (use e.g. --rsi 14 or --ema 20,50)")
+		}
+		if indicatorsRows <= 0 {
+			return fmt.Errorf("--rows must be positive")
+		}
+
+		// Create exchange client
+		client, err := newExchangeClient()
+		if err != nil {
+			return err
+		}
+
+		if !client.Capabilities().SupportsInterval(indicatorsInterval) {
+			return &exchange.UnsupportedIntervalError{Exchange: client.GetName(), Interval: indicatorsInterval}
+		}
+
+		symbol, err := resolveSymbol(ctx, client, args[0])
+		if err != nil {
+			return err
+		}
+
+		candles, err := fetchRecentCandles(ctx, client, symbol, indicatorsInterval, indicatorsRows+indicatorsOpts.warmup())
+		if err != nil {
+			return err
+		}
+
+		series := computeIndicators(candles, indicatorsInterval, indicatorsOpts)
+		first := max(len(candles)-indicatorsRows, 0)
+
+		normalizedSymbol := client.NormalizeSymbol(symbol)
+		if outputFormat == outputText {
+			printIndicators(client.GetName(), normalizedSymbol, indicatorsInterval, candles, series, first)
+			return nil
+		}
+
+		records := make([]indicatorRecord, 0, len(candles)-first)
+		for i := first; i < len(candles); i++ {
+			r := indicatorRecord{
+				Exchange:   client.GetName(),
+				Symbol:     normalizedSymbol,
+				Interval:   indicatorsInterval,
+				Time:       candles[i].Time,
+				Close:      candles[i].Close,
+				Indicators: make(map[string]any, len(series)),
+				series:     series,
+				index:      i,
+			}
+			for _, s := range series {
+				r.Indicators[seriesKey(s)] = nullable(s.values[i])
+			}
+			records = append(records, r)
+		}
+		return writeRecords(os.Stdout, outputFormat, records)
+	},
+}
+
+// seriesKey names a series in structured output; panel members are prefixed with their panel
+func seriesKey(s indicatorSeries) string {
+	if s.panel != "" && s.panel != s.name {
+		return s.panel + " " + s.name
+	}
+	return s.name
+}
+
+// nullable turns NaN into nil so that it encodes as JSON null
+func nullable(v float64) any {
+	if math.IsNaN(v) {
+		return nil
+	}
+	return v
+}
+
+// indicatorRecord is one candle's indicator values in structured output
+type indicatorRecord struct {
+	Exchange   string         `json:"exchange"`
+	Symbol     string         `json:"symbol"`
+	Interval   string         `json:"interval"`
+	Time       time.Time      `json:"time"`
+	Close      float64        `json:"close"`
+	Indicators map[string]any `json:"indicators"`
+
+	series []indicatorSeries
+	index  int
+}
+
+func (r indicatorRecord) columns() []string {
+	columns := []string{"exchange", "symbol", "interval", "time", "close"}
+	for _, s := range r.series {
+		columns = append(columns, seriesKey(s))
+	}
+	return columns
+}
+
+func (r indicatorRecord) values() []string {
+	values := []string{r.Exchange, r.Symbol, r.Interval, formatTime(r.Time), formatFloat(r.Close)}
+	for _, s := range r.series {
+		v := ""
+		if !math.IsNaN(s.values[r.index]) {
+			v = formatFloat(s.values[r.index])
+		}
+		values = append(values, v)
+	}
+	return values
+}
+
+// printIndicators writes indicator values from candle first onwards as a styled table
+func printIndicators(exchangeName, symbol, interval string, candles []models.Candle, series []indicatorSeries, first int) {
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFF00"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888888"))
+
+	fmt.Println(headerStyle.Render(fmt.Sprintf("\n%s %s indicators from %s:",
+		symbol, interval, strings.ToUpper(exchangeName))))
+
+	widths := make([]int, len(series))
+	header := fmt.Sprintf("%-16s %14s", "Time (UTC)", "Close")
+	for i, s := range series {
+		widths[i] = max(len(seriesKey(s)), 12)
+		header += fmt.Sprintf(" %*s", widths[i], seriesKey(s))
+	}
+	fmt.Println(labelStyle.Render(header))
+	fmt.Println(strings.Repeat("─", lipgloss.Width(header)))
+
+	for i := first; i < len(candles); i++ {
+		fmt.Printf("%s %14s", labelStyle.Render(candles[i].Time.UTC().Format("2006-01-02 15:04")), formatPrice(candles[i].Close))
+		for j, s := range series {
+			cell := "-"
+			if !math.IsNaN(s.values[i]) {
+				cell = fmt.Sprintf("%.4f", s.values[i])
+			}
+			style := lipgloss.NewStyle().Foreground(lipgloss.Color(s.color))
+			fmt.Printf(" %s", style.Render(fmt.Sprintf("%*s", widths[j], cell)))
+		}
+		fmt.Println()
+	}
+
+	fmt.Println()
+}
+
+func init() {
+	rootCmd.AddCommand(indicatorsCmd)
+	indicatorsCmd.Flags().StringVarP(&indicatorsInterval, "interval", "i", "1h", "candle interval (e.g. 1m, 15m, 1h, 1d)")
+	indicatorsCmd.Flags().IntVarP(&indicatorsRows, "rows", "n", 10, "number of most recent candles to show")
+	addIndicatorFlags(indicatorsCmd, &indicatorsOpts)
+}
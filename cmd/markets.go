@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	marketsQuote   string
+	marketsSearch  string
+	marketsRefresh bool
+	marketsAll     bool
+)
+
+var marketsCmd = &cobra.Command{
+	Use:   "markets",
+	Short: "List the markets available on an exchange",
+	Long: `List the spot markets listed on an exchange with their status, tick size and lot size.
+The catalog is cached under ~/.terminalcrypto/cache and refreshed after markets.cache_ttl.
+
+Examples:
+  terminalcrypto markets --quote USDT
+  terminalcrypto markets --search sol
+  terminalcrypto --exchange coinbase markets --quote USD --refresh`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+
+		// Create exchange client
+		client, err := newExchangeClient()
+		if err != nil {
+			return err
+		}
+
+		catalog, err := loadCatalog(ctx, client, marketsRefresh)
+		if err != nil {
+			return err
+		}
+		if catalog == nil {
+			return fmt.Errorf("%s does not provide a market list: %w", client.GetName(), exchange.ErrUnsupported)
+		}
+
+		// Define styles
+		headerStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#FFFF00"))
+
+		symbolStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#00D4FF"))
+
+		labelStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#888888"))
+
+		haltedStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FF0087"))
+
+		fmt.Println(headerStyle.Render(fmt.Sprintf("\nMarkets on %s:", strings.ToUpper(client.GetName()))))
+		fmt.Println(labelStyle.Render(fmt.Sprintf("%-16s %-10s %-8s %-9s %14s %14s", "Symbol", "Base", "Quote", "Status", "Tick Size", "Lot Size")))
+		fmt.Println(strings.Repeat("─", 76))
+
+		shown := 0
+		for _, m := range catalog.Filter(marketsQuote, marketsSearch) {
+			if !marketsAll && m.Status != models.MarketStatusTrading {
+				continue
+			}
+
+			status := m.Status
+			if m.Status != models.MarketStatusTrading {
+				status = haltedStyle.Render(fmt.Sprintf("%-9s", m.Status))
+			} else {
+				status = fmt.Sprintf("%-9s", status)
+			}
+
+			fmt.Printf("%s %-10s %-8s %s %14s %14s\n",
+				symbolStyle.Render(fmt.Sprintf("%-16s", m.Symbol)),
+				m.Base,
+				m.Quote,
+				status,
+				strconv.FormatFloat(m.TickSize, 'f', -1, 64),
+				strconv.FormatFloat(m.LotSize, 'f', -1, 64))
+			shown++
+		}
+
+		fmt.Println(strings.Repeat("─", 76))
+		fmt.Println(labelStyle.Render(fmt.Sprintf("%d of %d markets • catalog fetched %s",
+			shown, len(catalog.Markets), catalog.FetchedAt.Format("2006-01-02 15:04:05"))))
+		fmt.Println()
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(marketsCmd)
+	marketsCmd.Flags().StringVarP(&marketsQuote, "quote", "q", "", "only show markets quoted in this currency")
+	marketsCmd.Flags().StringVarP(&marketsSearch, "search", "s", "", "only show markets whose symbol contains this text")
+	marketsCmd.Flags().BoolVar(&marketsRefresh, "refresh", false, "ignore the cached catalog and fetch it again")
+	marketsCmd.Flags().BoolVar(&marketsAll, "all", false, "include halted markets")
+}
@@ -0,0 +1,462 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+	"github.com/Carpe-Wang/terminalCrypto/internal/keyring"
+	"github.com/Carpe-Wang/terminalCrypto/internal/markets"
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+// testnetKeyringName is the keyring entry holding Binance spot testnet credentials
+const testnetKeyringName = "binance-testnet"
+
+var (
+	orderTestnet bool
+	orderPrice   float64
+	orderStop    float64
+)
+
+// newTraderClient creates the client orders are sent to: the selected exchange,
+// or the Binance spot testnet with --testnet
+func newTraderClient() (exchange.Exchange, exchange.Trader, error) {
+	var client exchange.Exchange
+	var err error
+	if orderTestnet {
+		if exchangeName != "binance" {
+			return nil, nil, fmt.Errorf("--testnet is only available for binance")
+		}
+
+		var apiKey, apiSecret string
+		if creds, err := keyring.GetCredentials(testnetKeyringName); err == nil {
+			apiKey = creds.APIKey
+			apiSecret = creds.APISecret
+		}
+		client, err = exchange.NewBinanceTestnetClient(apiKey, apiSecret)
+	} else {
+		client, err = newExchangeClient()
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	trader, ok := exchange.As[exchange.Trader](client)
+	if !ok {
+		return nil, nil, fmt.Errorf("%s does not support trading: %w", client.GetName(), exchange.ErrUnsupported)
+	}
+	if !client.Capabilities().Trading {
+		return nil, nil, fmt.Errorf("no API credentials for %s; run '%s' first", client.GetName(), setupCommand(client.GetName()))
+	}
+
+	return client, trader, nil
+}
+
+var orderCmd = &cobra.Command{
+	Use:   "order",
+	Short: "Place and manage orders",
+	Long: `Place, cancel and inspect orders on the selected exchange (currently Binance).
+Requires API credentials with trading permission, stored with
+'terminalcrypto setup'.
+
+Every order is shown for confirmation before it is sent and is only placed
+after you type "yes". Use --testnet to trade with play money on the Binance
+spot testnet (set up its keys with 'terminalcrypto setup binance --testnet'),
+or --exchange paper:<exchange> to simulate trading against live prices
+without an account (see 'terminalcrypto paper').
+
+Examples:
+  terminalcrypto order buy BTC 0.001 --testnet
+  terminalcrypto order sell ETH 0.5 --price 4000
+  terminalcrypto --exchange paper:binance order buy BTC 0.01
+  terminalcrypto order list
+  terminalcrypto order cancel ETH 123456`,
+}
+
+var orderBuyCmd = &cobra.Command{
+	Use:   "buy [symbol] [quantity]",
+	Short: "Place a buy order",
+	Long: `Place a buy order for a quantity of the base asset.
+
+Without --price the order is a market order. With --price it is a limit order
+that rests until filled or canceled; adding --stop makes it a stop-limit order
+that is placed at --price once the market trades at the stop price.
+
+Examples:
+  terminalcrypto order buy BTC 0.001
+  terminalcrypto order buy ETH 0.5 --price 3000
+  terminalcrypto order buy SOL 10 --stop 180 --price 181 --testnet`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return placeOrder(cmd, models.SideBuy, args)
+	},
+}
+
+var orderSellCmd = &cobra.Command{
+	Use:   "sell [symbol] [quantity]",
+	Short: "Place a sell order",
+	Long: `Place a sell order for a quantity of the base asset.
+
+Without --price the order is a market order. With --price it is a limit order
+that rests until filled or canceled; adding --stop makes it a stop-limit order
+that is placed at --price once the market trades at the stop price.
+
+Examples:
+  terminalcrypto order sell BTC 0.001
+  terminalcrypto order sell ETH 0.5 --price 4000
+  terminalcrypto order sell SOL 10 --stop 150 --price 149 --testnet`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return placeOrder(cmd, models.SideSell, args)
+	},
+}
+
+// placeOrder validates an order, asks for confirmation and sends it
+func placeOrder(cmd *cobra.Command, side models.Side, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	quantity, err := strconv.ParseFloat(args[1], 64)
+	if err != nil {
+		return fmt.Errorf("invalid quantity %q: %w", args[1], err)
+	}
+
+	req := models.OrderRequest{Symbol: args[0], Side: side, Type: models.OrderTypeMarket, Quantity: quantity}
+	switch {
+	case cmd.Flags().Changed("stop") && !cmd.Flags().Changed("price"):
+		return fmt.Errorf("a stop-limit order needs --price as well as --stop")
+	case cmd.Flags().Changed("stop"):
+		req.Type = models.OrderTypeStopLimit
+		req.Price = orderPrice
+		req.StopPrice = orderStop
+	case cmd.Flags().Changed("price"):
+		req.Type = models.OrderTypeLimit
+		req.Price = orderPrice
+	}
+
+	client, trader, err := newTraderClient()
+	if err != nil {
+		return err
+	}
+
+	catalog, err := loadCatalog(ctx, client, false)
+	if err != nil {
+		return err
+	}
+	var market *models.Market
+	quote := ""
+	if catalog != nil {
+		resolved, err := catalog.Resolve(req.Symbol, client.NormalizeSymbol)
+		if err != nil {
+			return err
+		}
+		market = &resolved
+		req.Symbol = market.Base + "/" + market.Quote
+		quote = market.Quote
+	}
+
+	// Estimate what the order is worth at its limit price, or the last price for market orders
+	price := req.Price
+	if req.Type == models.OrderTypeMarket {
+		if price, err = client.GetPrice(ctx, req.Symbol); err != nil {
+			return err
+		}
+	}
+
+	// Check the order against the market's tick size, lot size and minimum value before asking
+	if market != nil {
+		if err := markets.CheckOrder(*market, req, price); err != nil {
+			return fmt.Errorf("invalid order: %w", err)
+		}
+	}
+
+	if !confirmOrder(client, req, price*req.Quantity, quote) {
+		fmt.Println("Order not placed.")
+		return nil
+	}
+
+	order, err := trader.PlaceOrder(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Placed order %s: %s %s %s %s (%s)\n",
+		order.ID, order.Side, formatFloat(order.Quantity), order.Symbol, order.Type, order.Status)
+	if order.ExecutedQuantity > 0 {
+		fmt.Printf("Filled %s for %s %s\n", formatFloat(order.ExecutedQuantity), formatAmount(order.QuoteQuantity), quote)
+	}
+	return nil
+}
+
+// confirmOrder shows an order and returns true only if the user types "yes"
+func confirmOrder(client exchange.Exchange, req models.OrderRequest, notional float64, quote string) bool {
+	// Define styles
+	liveStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FF0087"))
+
+	// Testnet and paper orders do not touch real funds
+	testnetStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFF00"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888888"))
+
+	marker := liveStyle.Render("LIVE")
+	if _, paper := exchange.As[*exchange.PaperExchange](client); paper {
+		marker = testnetStyle.Render("PAPER")
+	} else if orderTestnet {
+		marker = testnetStyle.Render("TESTNET")
+	}
+
+	fmt.Printf("\n%s order on %s\n", marker, strings.ToUpper(client.GetName()))
+	fmt.Printf("  %s %s %s, %s", strings.ToUpper(string(req.Side)), formatFloat(req.Quantity), req.Symbol, req.Type)
+	switch req.Type {
+	case models.OrderTypeLimit:
+		fmt.Printf(" at %s", formatFloat(req.Price))
+	case models.OrderTypeStopLimit:
+		fmt.Printf(" at %s, triggered at %s", formatFloat(req.Price), formatFloat(req.StopPrice))
+	}
+	fmt.Println()
+	fmt.Println(labelStyle.Render(fmt.Sprintf("  Estimated value %s %s", formatAmount(notional), quote)))
+
+	fmt.Print("\nType 'yes' to place this order: ")
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Println()
+		return false
+	}
+	return strings.TrimSpace(answer) == "yes"
+}
+
+var orderCancelCmd = &cobra.Command{
+	Use:   "cancel [symbol] [order-id]",
+	Short: "Cancel an open order",
+	Long: `Cancel an open order by symbol and order ID (see 'terminalcrypto order list').
+
+Examples:
+  terminalcrypto order cancel BTC 123456
+  terminalcrypto order cancel ETH 654321 --testnet`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		client, trader, err := newTraderClient()
+		if err != nil {
+			return err
+		}
+
+		symbol, err := resolveSymbol(ctx, client, args[0])
+		if err != nil {
+			return err
+		}
+
+		order, err := trader.CancelOrder(ctx, symbol, args[1])
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Canceled order %s: %s %s %s %s (%s)\n",
+			order.ID, order.Side, formatFloat(order.Quantity), order.Symbol, order.Type, order.Status)
+		return nil
+	},
+}
+
+var orderListCmd = &cobra.Command{
+	Use:     "list [symbol]",
+	Aliases: []string{"ls"},
+	Short:   "List open orders",
+	Long: `List open orders for a symbol, or for every symbol if none is given.
+
+Examples:
+  terminalcrypto order list
+  terminalcrypto order list BTC -o json`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{structuredOutputAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		client, trader, err := newTraderClient()
+		if err != nil {
+			return err
+		}
+
+		symbol := ""
+		if len(args) > 0 {
+			if symbol, err = resolveSymbol(ctx, client, args[0]); err != nil {
+				return err
+			}
+		}
+
+		orders, err := trader.ListOpenOrders(ctx, symbol)
+		if err != nil {
+			return err
+		}
+
+		if outputFormat != outputText {
+			return writeOrders(client.GetName(), orders)
+		}
+
+		if len(orders) == 0 {
+			fmt.Println("No open orders.")
+			return nil
+		}
+
+		printOrders(fmt.Sprintf("Open orders on %s:", strings.ToUpper(client.GetName())), orders)
+		return nil
+	},
+}
+
+var orderGetCmd = &cobra.Command{
+	Use:   "get [symbol] [order-id]",
+	Short: "Show an order",
+	Long: `Show the current state of an order, open or not.
+
+Examples:
+  terminalcrypto order get BTC 123456
+  terminalcrypto order get BTC 123456 -o json`,
+	Args:        cobra.ExactArgs(2),
+	Annotations: map[string]string{structuredOutputAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		client, trader, err := newTraderClient()
+		if err != nil {
+			return err
+		}
+
+		symbol, err := resolveSymbol(ctx, client, args[0])
+		if err != nil {
+			return err
+		}
+
+		order, err := trader.GetOrder(ctx, symbol, args[1])
+		if err != nil {
+			return err
+		}
+
+		if outputFormat != outputText {
+			return writeOrders(client.GetName(), []models.Order{*order})
+		}
+
+		printOrders(fmt.Sprintf("Order %s on %s:", order.ID, strings.ToUpper(client.GetName())), []models.Order{*order})
+		return nil
+	},
+}
+
+// orderRecord is one order in structured output
+type orderRecord struct {
+	Exchange string `json:"exchange"`
+	models.Order
+}
+
+func (r orderRecord) columns() []string {
+	return []string{"exchange", "id", "symbol", "side", "type", "status", "price", "stop_price", "quantity", "executed_quantity", "quote_quantity", "time", "update_time"}
+}
+
+func (r orderRecord) values() []string {
+	return []string{
+		r.Exchange,
+		r.ID,
+		r.Symbol,
+		string(r.Side),
+		string(r.Type),
+		r.Status,
+		formatFloat(r.Price),
+		formatFloat(r.StopPrice),
+		formatFloat(r.Quantity),
+		formatFloat(r.ExecutedQuantity),
+		formatFloat(r.QuoteQuantity),
+		formatTime(r.Time),
+		formatTime(r.UpdateTime),
+	}
+}
+
+// writeOrders writes orders in the selected structured format
+func writeOrders(exchangeName string, orders []models.Order) error {
+	records := make([]orderRecord, len(orders))
+	for i, o := range orders {
+		records[i] = orderRecord{Exchange: exchangeName, Order: o}
+	}
+	return writeRecords(os.Stdout, outputFormat, records)
+}
+
+// printOrders writes orders as a styled table
+func printOrders(title string, orders []models.Order) {
+	// Define styles
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFF00"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888888"))
+
+	buyStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#00FF87"))
+
+	sellStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF0087"))
+
+	fmt.Println(headerStyle.Render("\n" + title))
+	fmt.Println(labelStyle.Render(fmt.Sprintf("%-12s %-10s %-5s %-10s %-16s %14s %14s %16s %-19s",
+		"ID", "Symbol", "Side", "Type", "Status", "Price", "Stop", "Filled", "Time")))
+	fmt.Println(strings.Repeat("─", 124))
+
+	for _, o := range orders {
+		side := buyStyle.Render(fmt.Sprintf("%-5s", o.Side))
+		if o.Side == models.SideSell {
+			side = sellStyle.Render(fmt.Sprintf("%-5s", o.Side))
+		}
+
+		price, stopPrice := "-", "-"
+		if o.Price > 0 {
+			price = formatFloat(o.Price)
+		}
+		if o.StopPrice > 0 {
+			stopPrice = formatFloat(o.StopPrice)
+		}
+
+		placed := "-"
+		if !o.Time.IsZero() {
+			placed = o.Time.Local().Format("2006-01-02 15:04:05")
+		}
+
+		fmt.Printf("%-12s %-10s %s %-10s %-16s %14s %14s %16s %-19s\n",
+			o.ID,
+			o.Symbol,
+			side,
+			o.Type,
+			o.Status,
+			price,
+			stopPrice,
+			formatFloat(o.ExecutedQuantity)+"/"+formatFloat(o.Quantity),
+			placed)
+	}
+
+	fmt.Println(strings.Repeat("─", 124))
+	fmt.Println()
+}
+
+func init() {
+	rootCmd.AddCommand(orderCmd)
+	orderCmd.AddCommand(orderBuyCmd, orderSellCmd, orderCancelCmd, orderListCmd, orderGetCmd)
+
+	orderCmd.PersistentFlags().BoolVar(&orderTestnet, "testnet", false, "send orders to the Binance spot testnet")
+
+	for _, c := range []*cobra.Command{orderBuyCmd, orderSellCmd} {
+		c.Flags().Float64Var(&orderPrice, "price", 0, "limit price; without it the order is a market order")
+		c.Flags().Float64Var(&orderStop, "stop", 0, "stop price that triggers a stop-limit order at --price")
+	}
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// Output formats accepted by --output
+const (
+	outputText   = "text"
+	outputJSON   = "json"
+	outputCSV    = "csv"
+	outputNDJSON = "ndjson"
+	outputTable  = "table"
+)
+
+var outputFormats = []string{outputText, outputJSON, outputCSV, outputNDJSON, outputTable}
+
+// structuredOutputAnnotation marks commands that can write --output formats other than text
+const structuredOutputAnnotation = "structured-output"
+
+var outputFormat string
+
+// setupOutput validates --output for cmd and turns styling off when stdout is not a terminal
+func setupOutput(cmd *cobra.Command) error {
+	valid := false
+	for _, f := range outputFormats {
+		if outputFormat == f {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("invalid output format %q (use one of: %s)", outputFormat, strings.Join(outputFormats, ", "))
+	}
+
+	if outputFormat != outputText && cmd.Annotations[structuredOutputAnnotation] != "true" {
+		return fmt.Errorf("--output %s is not supported by the %s command", outputFormat, cmd.Name())
+	}
+
+	// Escape codes only get in the way when output is piped or redirected
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+
+	return nil
+}
+
+// record is one row of structured output
+type record interface {
+	columns() []string
+	values() []string
+}
+
+// writeRecords writes records to w in a structured output format
+func writeRecords[R record](w io.Writer, format string, records []R) error {
+	switch format {
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if records == nil {
+			records = []R{}
+		}
+		return enc.Encode(records)
+
+	case outputNDJSON:
+		enc := json.NewEncoder(w)
+		for _, r := range records {
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case outputCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(recordColumns(records)); err != nil {
+			return err
+		}
+		for _, r := range records {
+			if err := cw.Write(r.values()); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+
+	case outputTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(recordColumns(records), "\t")))
+		for _, r := range records {
+			fmt.Fprintln(tw, strings.Join(r.values(), "\t"))
+		}
+		return tw.Flush()
+
+	default:
+		return fmt.Errorf("output format %q is not a structured format", format)
+	}
+}
+
+// recordColumns returns the column names of records. Records may choose their
+// columns at run time, so the first record is asked when there is one.
+func recordColumns[R record](records []R) []string {
+	if len(records) > 0 {
+		return records[0].columns()
+	}
+	var zero R
+	return zero.columns()
+}
+
+// formatFloat formats a number for machine-readable output without losing precision
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// formatTime formats a timestamp for machine-readable output, empty if unset
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
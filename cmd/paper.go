@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/config"
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+// newSelectedPaperClient returns the paper account for the selected exchange,
+// whether or not it was given with the "paper:" prefix
+func newSelectedPaperClient() (*exchange.PaperExchange, error) {
+	return newPaperClient(strings.TrimPrefix(exchangeName, paperPrefix))
+}
+
+var paperCmd = &cobra.Command{
+	Use:   "paper",
+	Short: "Inspect and reset paper trading accounts",
+	Long: `Paper trading simulates an account on top of a real exchange: select it with
+--exchange paper:<exchange> (e.g. paper:binance) and use the order and
+balances commands as usual. Orders fill against the exchange's live prices
+and pay paper.fee_rate (default 0.1%) of their value in fees; nothing is sent
+to the exchange and no API credentials are needed.
+
+Market orders fill at once at the last price. Limit orders rest until the last
+price reaches them, which is checked whenever the account is used. A new
+account starts with paper.starting_balance (default 10000) of display.currency.
+Each exchange's account is kept in ~/.terminalcrypto/paper-<exchange>.json.
+
+Examples:
+  terminalcrypto --exchange paper:binance order buy BTC 0.01
+  terminalcrypto --exchange paper:binance balances
+  terminalcrypto --exchange paper:binance paper fills
+  terminalcrypto --exchange paper:okx paper reset`,
+}
+
+var paperFillsCmd = &cobra.Command{
+	Use:         "fills",
+	Short:       "List the fills of a paper account",
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{structuredOutputAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		client, err := newSelectedPaperClient()
+		if err != nil {
+			return err
+		}
+
+		fills, err := client.Fills(ctx)
+		if err != nil {
+			return err
+		}
+
+		if outputFormat != outputText {
+			records := make([]fillRecord, len(fills))
+			for i, f := range fills {
+				records[i] = fillRecord{Exchange: client.GetName(), PaperFill: f}
+			}
+			return writeRecords(os.Stdout, outputFormat, records)
+		}
+
+		if len(fills) == 0 {
+			fmt.Printf("No fills on %s yet.\n", client.GetName())
+			return nil
+		}
+
+		printFills(client.GetName(), fills)
+		return nil
+	},
+}
+
+// fillRecord is one paper fill in structured output
+type fillRecord struct {
+	Exchange string `json:"exchange"`
+	exchange.PaperFill
+}
+
+func (r fillRecord) columns() []string {
+	return []string{"exchange", "order_id", "symbol", "side", "price", "quantity", "quote_quantity", "fee", "fee_asset", "time"}
+}
+
+func (r fillRecord) values() []string {
+	return []string{
+		r.Exchange,
+		r.OrderID,
+		r.Symbol,
+		string(r.Side),
+		formatFloat(r.Price),
+		formatFloat(r.Quantity),
+		formatFloat(r.QuoteQuantity),
+		formatFloat(r.Fee),
+		r.FeeAsset,
+		formatTime(r.Time),
+	}
+}
+
+// printFills writes paper fills as a styled table
+func printFills(exchangeName string, fills []exchange.PaperFill) {
+	// Define styles
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFF00"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888888"))
+
+	buyStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#00FF87"))
+
+	sellStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF0087"))
+
+	fmt.Println(headerStyle.Render(fmt.Sprintf("\nFills on %s:", strings.ToUpper(exchangeName))))
+	fmt.Println(labelStyle.Render(fmt.Sprintf("%-19s %-8s %-10s %-5s %14s %14s %14s %12s",
+		"Time", "Order", "Symbol", "Side", "Price", "Quantity", "Value", "Fee")))
+	fmt.Println(strings.Repeat("─", 104))
+
+	fees := make(map[string]float64)
+	for _, f := range fills {
+		side := buyStyle.Render(fmt.Sprintf("%-5s", f.Side))
+		if f.Side == models.SideSell {
+			side = sellStyle.Render(fmt.Sprintf("%-5s", f.Side))
+		}
+
+		fmt.Printf("%-19s %-8s %-10s %s %14s %14s %14s %12s\n",
+			f.Time.Local().Format("2006-01-02 15:04:05"),
+			f.OrderID,
+			f.Symbol,
+			side,
+			formatAmount(f.Price),
+			formatQuantity(f.Quantity),
+			formatAmount(f.QuoteQuantity),
+			formatAmount(f.Fee))
+		fees[f.FeeAsset] += f.Fee
+	}
+
+	fmt.Println(strings.Repeat("─", 104))
+	paid := make([]string, 0, len(fees))
+	for asset, fee := range fees {
+		paid = append(paid, formatAmount(fee)+" "+asset)
+	}
+	sort.Strings(paid)
+	fmt.Println(labelStyle.Render(fmt.Sprintf("%d fills, %s paid in fees", len(fills), strings.Join(paid, " + "))))
+	fmt.Println()
+}
+
+var paperResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Start a paper account over",
+	Long: `Delete a paper account's balances, orders and fills. The next command that
+uses it starts a new account with paper.starting_balance of display.currency.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newSelectedPaperClient()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Type 'yes' to delete the %s account in %s: ", client.GetName(), client.Path())
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if strings.TrimSpace(answer) != "yes" {
+			fmt.Println("Account kept.")
+			return nil
+		}
+
+		if err := client.Reset(); err != nil {
+			return err
+		}
+
+		fmt.Printf("Reset %s; it starts over with %s %s\n", client.GetName(),
+			formatAmount(config.GetPaperStartingBalance()), strings.ToUpper(config.GetDisplayCurrency()))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(paperCmd)
+	paperCmd.AddCommand(paperFillsCmd, paperResetCmd)
+}
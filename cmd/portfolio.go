@@ -0,0 +1,356 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/config"
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+	"github.com/Carpe-Wang/terminalCrypto/internal/portfolio"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	portfolioCost  float64
+	portfolioPrice float64
+	portfolioLabel string
+
+	portfolioRmAll bool
+)
+
+// portfolioPath returns the location of the holdings file
+func portfolioPath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return portfolio.Path(dir), nil
+}
+
+var portfolioCmd = &cobra.Command{
+	Use:   "portfolio",
+	Short: "Track holdings and value them at live prices",
+	Long: `Show your holdings valued at live prices from the selected exchange, with
+each one's share of the total, unrealized profit and loss against its cost
+basis and the change in value over the last 24 hours. Values are in
+display.currency (default USDT).
+
+Holdings are stored in ~/.terminalcrypto/portfolio.yaml.
+
+Examples:
+  terminalcrypto portfolio add BTC 0.5 --price 42000 --label ledger
+  terminalcrypto portfolio add ETH 4 --cost 9000 --label binance
+  terminalcrypto portfolio
+  terminalcrypto portfolio -o csv > portfolio.csv
+  terminalcrypto portfolio rm 2`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{structuredOutputAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		path, err := portfolioPath()
+		if err != nil {
+			return err
+		}
+
+		holdings, err := portfolio.Load(path)
+		if err != nil {
+			return err
+		}
+		if len(holdings) == 0 && outputFormat == outputText {
+			fmt.Println("No holdings. Add one with 'terminalcrypto portfolio add BTC 0.5 --price 42000'.")
+			return nil
+		}
+
+		// Create exchange client
+		client, err := newExchangeClient()
+		if err != nil {
+			return err
+		}
+
+		// Fetch one ticker per asset, however many holdings it has
+		currency := strings.ToUpper(config.GetDisplayCurrency())
+		tickers := make(map[string]*models.Ticker)
+		errs := make(map[string]error)
+		for _, h := range holdings {
+			if _, ok := tickers[h.Asset]; ok || errs[h.Asset] != nil || h.Asset == currency {
+				continue
+			}
+
+			symbol, err := resolveSymbol(ctx, client, h.Asset+"/"+currency)
+			if err == nil {
+				tickers[h.Asset], err = client.GetTicker(ctx, symbol)
+			}
+			if err != nil {
+				delete(tickers, h.Asset)
+				errs[h.Asset] = err
+			}
+		}
+
+		valuation := portfolio.Value(holdings, currency, tickers, errs)
+
+		if outputFormat == outputText {
+			printPortfolio(client.GetName(), valuation)
+		} else {
+			records := make([]positionRecord, len(valuation.Positions))
+			for i, p := range valuation.Positions {
+				records[i] = positionRecord{Position: p, Currency: currency}
+				if p.Err != nil {
+					records[i].Error = describeError(p.Err)
+					records[i].ErrorKind = errorKind(p.Err)
+				}
+			}
+			if err := writeRecords(os.Stdout, outputFormat, records); err != nil {
+				return err
+			}
+		}
+
+		for _, p := range valuation.Positions {
+			if p.Err != nil {
+				return &reportedError{p.Err}
+			}
+		}
+		return nil
+	},
+}
+
+// positionRecord is one valued holding in structured output
+type positionRecord struct {
+	portfolio.Position
+	Currency  string `json:"currency"`
+	Error     string `json:"error,omitempty"`
+	ErrorKind string `json:"error_kind,omitempty"`
+}
+
+func (r positionRecord) columns() []string {
+	return []string{"id", "asset", "label", "quantity", "cost_basis", "currency", "price", "value", "allocation", "unrealized_pnl", "pnl_24h", "error", "error_kind"}
+}
+
+func (r positionRecord) values() []string {
+	return []string{
+		strconv.Itoa(r.ID),
+		r.Asset,
+		r.Label,
+		formatFloat(r.Quantity),
+		formatFloat(r.CostBasis),
+		r.Currency,
+		formatFloat(r.Price),
+		formatFloat(r.Value),
+		formatFloat(r.Allocation),
+		formatFloat(r.UnrealizedPnL),
+		formatFloat(r.PnL24h),
+		r.Error,
+		r.ErrorKind,
+	}
+}
+
+// printPortfolio writes a valuation as a styled table
+func printPortfolio(exchangeName string, v portfolio.Valuation) {
+	// Define styles
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFF00"))
+
+	assetStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#00D4FF"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888888"))
+
+	positiveStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#00FF87"))
+
+	negativeStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF0087"))
+
+	totalStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFFFF"))
+
+	// pnl colours a signed amount, padded to width
+	pnl := func(amount float64, width int) string {
+		text := fmt.Sprintf("%*s", width, formatSignedAmount(amount))
+		if amount < 0 {
+			return negativeStyle.Render(text)
+		}
+		return positiveStyle.Render(text)
+	}
+
+	fmt.Println(headerStyle.Render(fmt.Sprintf("\nPortfolio in %s at %s prices:", v.Currency, strings.ToUpper(exchangeName))))
+	fmt.Println(labelStyle.Render(fmt.Sprintf("%-4s %-8s %-10s %14s %14s %14s %7s %14s %12s",
+		"ID", "Asset", "Label", "Quantity", "Price", "Value", "Alloc", "Unrealized", "24h")))
+	fmt.Println(strings.Repeat("─", 105))
+
+	for _, p := range v.Positions {
+		prefix := fmt.Sprintf("%-4s %s %-10s %14s", "#"+strconv.Itoa(p.ID), assetStyle.Render(fmt.Sprintf("%-8s", p.Asset)), p.Label, formatQuantity(p.Quantity))
+		if p.Err != nil {
+			fmt.Printf("%s  %s\n", prefix, negativeStyle.Render(fmt.Sprintf("Error: %s", describeError(p.Err))))
+			continue
+		}
+
+		unrealized := fmt.Sprintf("%14s", "-")
+		if p.CostBasis > 0 {
+			unrealized = pnl(p.UnrealizedPnL, 14)
+		}
+
+		fmt.Printf("%s %14s %14s %6.1f%% %s %s\n",
+			prefix,
+			formatAmount(p.Price),
+			formatAmount(p.Value),
+			p.Allocation,
+			unrealized,
+			pnl(p.PnL24h, 12))
+	}
+
+	fmt.Println(strings.Repeat("─", 105))
+
+	unrealized := fmt.Sprintf("%14s", "-")
+	if v.CostBasis > 0 {
+		unrealized = pnl(v.UnrealizedPnL, 14)
+	}
+	fmt.Printf("%s %14s %7s %s %s\n",
+		totalStyle.Render(fmt.Sprintf("%-53s", "Total")),
+		totalStyle.Render(formatAmount(v.Value)),
+		"",
+		unrealized,
+		pnl(v.PnL24h, 12))
+
+	if v.CostBasis > 0 {
+		fmt.Println(labelStyle.Render(fmt.Sprintf("Cost basis %s %s • unrealized %+.2f%%",
+			formatAmount(v.CostBasis), v.Currency, v.UnrealizedPnL/v.CostBasis*100)))
+	}
+	if start := v.Value - v.PnL24h; start > 0 {
+		fmt.Println(labelStyle.Render(fmt.Sprintf("24h change %+.2f%%", v.PnL24h/start*100)))
+	}
+	fmt.Println()
+}
+
+// formatAmount formats an amount of the display currency
+func formatAmount(amount float64) string {
+	if amount != 0 && amount < 1 && amount > -1 {
+		return strconv.FormatFloat(amount, 'f', 6, 64)
+	}
+	return fmt.Sprintf("%.2f", amount)
+}
+
+// formatSignedAmount formats an amount of the display currency with its sign
+func formatSignedAmount(amount float64) string {
+	if amount >= 0 {
+		return "+" + formatAmount(amount)
+	}
+	return formatAmount(amount)
+}
+
+var portfolioAddCmd = &cobra.Command{
+	Use:   "add [asset] [quantity]",
+	Short: "Add a holding",
+	Long: `Add a quantity of an asset to the portfolio. Give what it cost either as the
+total paid (--cost) or as the average price per unit (--price), in
+display.currency; without either, no unrealized P&L is shown for it.
+Add an asset more than once to track it in several places with --label.
+
+Examples:
+  terminalcrypto portfolio add BTC 0.5 --price 42000 --label ledger
+  terminalcrypto portfolio add ETH 4 --cost 9000 --label binance
+  terminalcrypto portfolio add USDT 1500`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		quantity, err := strconv.ParseFloat(args[1], 64)
+		if err != nil {
+			return fmt.Errorf("invalid quantity %q: %w", args[1], err)
+		}
+
+		if cmd.Flags().Changed("cost") && cmd.Flags().Changed("price") {
+			return fmt.Errorf("give either --cost or --price, not both")
+		}
+		cost := portfolioCost
+		if cmd.Flags().Changed("price") {
+			cost = portfolioPrice * quantity
+		}
+
+		path, err := portfolioPath()
+		if err != nil {
+			return err
+		}
+
+		holding, err := portfolio.Add(path, portfolio.Holding{
+			Asset:     args[0],
+			Quantity:  quantity,
+			CostBasis: cost,
+			Label:     portfolioLabel,
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Added holding #%d: %s %s", holding.ID, strconv.FormatFloat(holding.Quantity, 'f', -1, 64), holding.Asset)
+		if holding.Label != "" {
+			fmt.Printf(" (%s)", holding.Label)
+		}
+		fmt.Println()
+		return nil
+	},
+}
+
+var portfolioRmCmd = &cobra.Command{
+	Use:     "rm [id...]",
+	Aliases: []string{"remove"},
+	Short:   "Remove holdings",
+	Long: `Remove holdings by ID (see 'terminalcrypto portfolio').
+
+Examples:
+  terminalcrypto portfolio rm 3
+  terminalcrypto portfolio rm --all`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if portfolioRmAll == (len(args) > 0) {
+			return fmt.Errorf("give the IDs of the holdings to remove, or --all")
+		}
+
+		path, err := portfolioPath()
+		if err != nil {
+			return err
+		}
+
+		var removed []portfolio.Holding
+		if portfolioRmAll {
+			if removed, err = portfolio.Load(path); err != nil {
+				return err
+			}
+			err = portfolio.Save(path, nil)
+		} else {
+			ids := make([]int, len(args))
+			for i, arg := range args {
+				if ids[i], err = strconv.Atoi(strings.TrimPrefix(arg, "#")); err != nil {
+					return fmt.Errorf("invalid holding ID %q", arg)
+				}
+			}
+			removed, err = portfolio.Remove(path, ids...)
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, h := range removed {
+			fmt.Printf("Removed holding #%d: %s %s\n", h.ID, strconv.FormatFloat(h.Quantity, 'f', -1, 64), h.Asset)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(portfolioCmd)
+	portfolioCmd.AddCommand(portfolioAddCmd, portfolioRmCmd)
+
+	portfolioAddCmd.Flags().Float64Var(&portfolioCost, "cost", 0, "total paid for the holding")
+	portfolioAddCmd.Flags().Float64Var(&portfolioPrice, "price", 0, "average price paid per unit")
+	portfolioAddCmd.Flags().StringVarP(&portfolioLabel, "label", "l", "", "where the holding is kept, e.g. an exchange or wallet")
+
+	portfolioRmCmd.Flags().BoolVar(&portfolioRmAll, "all", false, "remove every holding")
+}
@@ -3,10 +3,12 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
-	"github.com/Carpe-Wang/terminalCrypto/internal/keyring"
+
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 )
@@ -20,66 +22,183 @@ Examples:
   terminalcrypto price BTC
   terminalcrypto price BTC ETH SOL
   terminalcrypto price BTC/USDT ETH/USDT
-  terminalcrypto --exchange binance price BTC`,
-	Args: cobra.MinimumNArgs(1),
+  terminalcrypto --exchange binance price BTC
+  terminalcrypto price BTC ETH --output json
+  terminalcrypto price BTC ETH -o csv > prices.csv`,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{structuredOutputAnnotation: "true"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
 
-		// Get credentials (may be empty for public access)
-		var apiKey, apiSecret string
-		creds, err := keyring.GetCredentials(exchangeName)
-		if err == nil {
-			apiKey = creds.APIKey
-			apiSecret = creds.APISecret
-		}
-
 		// Create exchange client
-		client, err := exchange.Factory(exchangeName, apiKey, apiSecret)
+		client, err := newExchangeClient()
 		if err != nil {
 			return err
 		}
 
-		// Define styles
-		symbolStyle := lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#00D4FF"))
-
-		priceStyle := lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#00FF87"))
-
-		errorStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF0087"))
-
-		headerStyle := lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#FFFF00"))
-
-		// Print header
-		fmt.Println(headerStyle.Render(fmt.Sprintf("\nPrices from %s:", strings.ToUpper(client.GetName()))))
-		fmt.Println(strings.Repeat("─", 50))
-
-		// Fetch and display prices
-		for _, symbol := range args {
-			price, err := client.GetPrice(ctx, symbol)
-			if err != nil {
-				fmt.Printf("%s: %s\n",
-					symbolStyle.Render(symbol),
-					errorStyle.Render(fmt.Sprintf("Error: %v", err)))
-				continue
+		// Fetch every price first; structured formats are written in one go
+		results := lookupPrices(ctx, client, args)
+		var firstErr error
+		for _, r := range results {
+			if r.err != nil && firstErr == nil {
+				firstErr = r.err
 			}
+		}
 
-			normalizedSymbol := client.NormalizeSymbol(symbol)
-			fmt.Printf("%s: %s\n",
-				symbolStyle.Render(normalizedSymbol),
-				priceStyle.Render(fmt.Sprintf("$%.2f", price)))
+		if outputFormat == outputText {
+			printPrices(client.GetName(), results)
+		} else if err := writeRecords(os.Stdout, outputFormat, results); err != nil {
+			return err
 		}
 
-		fmt.Println()
+		// Errors were reported per symbol; only the exit code is left to set
+		if firstErr != nil {
+			return &reportedError{err: firstErr}
+		}
 		return nil
 	},
 }
 
+// priceResult is the outcome of looking up one symbol's price
+type priceResult struct {
+	Exchange  string    `json:"exchange"`
+	Symbol    string    `json:"symbol"`
+	Price     float64   `json:"price,omitzero"`
+	Timestamp time.Time `json:"timestamp,omitzero"`
+	Error     string    `json:"error,omitempty"`
+	ErrorKind string    `json:"error_kind,omitempty"`
+
+	// Sources are the exchanges a composite price was combined from
+	Method  exchange.AggregateMethod `json:"method,omitempty"`
+	Sources []exchange.SourcePrice   `json:"sources,omitempty"`
+
+	quote string
+	err   error
+}
+
+func (r priceResult) columns() []string {
+	return []string{"exchange", "symbol", "price", "timestamp", "error", "error_kind"}
+}
+
+func (r priceResult) values() []string {
+	price := ""
+	if r.err == nil {
+		price = formatFloat(r.Price)
+	}
+	return []string{r.Exchange, r.Symbol, price, formatTime(r.Timestamp), r.Error, r.ErrorKind}
+}
+
+// lookupPrices resolves symbols and fetches their prices in one batch,
+// recording any error in the symbol's result
+func lookupPrices(ctx context.Context, client exchange.Exchange, symbols []string) []priceResult {
+	results := make([]priceResult, len(symbols))
+	var resolved []string
+	var index []int
+	for i, symbol := range symbols {
+		results[i] = priceResult{Exchange: client.GetName(), Symbol: symbol}
+
+		r, err := resolveSymbol(ctx, client, symbol)
+		if err != nil {
+			results[i].fail(err)
+			continue
+		}
+		results[i].Symbol = client.NormalizeSymbol(r)
+		results[i].quote = quoteCurrency(client, r)
+		resolved = append(resolved, r)
+		index = append(index, i)
+	}
+
+	composite, isComposite := exchange.As[*exchange.AggregateExchange](client)
+	now := time.Now()
+	for j, r := range client.GetPrices(ctx, resolved) {
+		result := &results[index[j]]
+		if r.Err != nil {
+			result.fail(r.Err)
+			continue
+		}
+
+		result.Price = r.Value
+		result.Timestamp = now
+		if isComposite {
+			if agg, ok := composite.LastAggregate(resolved[j]); ok {
+				result.Method = agg.Method
+				result.Sources = agg.Sources
+			}
+		}
+	}
+	return results
+}
+
+// fail records err in the result
+func (r *priceResult) fail(err error) {
+	r.Error = describeError(err)
+	r.ErrorKind = errorKind(err)
+	r.err = err
+}
+
+// printPrices writes price results as styled text
+func printPrices(exchangeName string, results []priceResult) {
+	// Define styles
+	symbolStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#00D4FF"))
+
+	priceStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#00FF87"))
+
+	errorStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF0087"))
+
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFF00"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888888"))
+
+	// Print header
+	fmt.Println(headerStyle.Render(fmt.Sprintf("\nPrices from %s:", strings.ToUpper(exchangeName))))
+	fmt.Println(strings.Repeat("─", 50))
+
+	for _, r := range results {
+		if r.err != nil {
+			fmt.Printf("%s: %s\n",
+				symbolStyle.Render(r.Symbol),
+				errorStyle.Render(fmt.Sprintf("Error: %s", r.Error)))
+			continue
+		}
+
+		fmt.Printf("%s: %s\n",
+			symbolStyle.Render(r.Symbol),
+			priceStyle.Render(formatQuotePrice(r.Price, r.quote)))
+
+		if len(r.Sources) > 0 {
+			fmt.Println(labelStyle.Render("  " + describeSources(r.Method, r.Sources)))
+		}
+	}
+
+	fmt.Println()
+}
+
 func init() {
 	rootCmd.AddCommand(priceCmd)
 }
+
+// describeSources says which exchanges a composite price was combined from and why any were left out
+func describeSources(method exchange.AggregateMethod, sources []exchange.SourcePrice) string {
+	var used, dropped []string
+	for _, s := range sources {
+		if s.Dropped == "" {
+			used = append(used, s.Exchange)
+		} else {
+			dropped = append(dropped, fmt.Sprintf("%s (%s)", s.Exchange, s.Dropped))
+		}
+	}
+
+	text := fmt.Sprintf("%s of %s", method, strings.Join(used, ", "))
+	if len(dropped) > 0 {
+		text += " • dropped " + strings.Join(dropped, ", ")
+	}
+	return text
+}
@@ -1,16 +1,20 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/Carpe-Wang/terminalCrypto/internal/config"
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
 	"github.com/spf13/cobra"
 )
 
 var (
 	cfgFile      string
 	exchangeName string
+	verbose      bool
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -26,8 +30,21 @@ Features:
   - Detailed market data (24h high/low, volume, price changes)
   - Support for multiple exchanges
   - Secure API credential storage in system keyring
-  - Beautiful terminal UI`,
+  - Beautiful terminal UI
+
+Exit codes:
+  0  success
+  1  general error
+  2  unknown symbol
+  3  rate limited by the exchange
+  4  exchange unavailable
+  5  feature not supported by the exchange
+  6  authentication failed`,
+	SilenceErrors: true,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Arguments are valid by now; later errors are not usage mistakes
+		cmd.SilenceUsage = true
+
 		// Initialize config
 		if err := config.InitConfig(); err != nil {
 			return fmt.Errorf("failed to initialize config: %w", err)
@@ -38,20 +55,44 @@ Features:
 			exchangeName = config.GetExchange()
 		}
 
+		if err := setupOutput(cmd); err != nil {
+			return err
+		}
+
+		// Log every exchange request with its outcome, latency and retry count
+		if verbose {
+			exchange.SetRequestObserver(logRequest)
+		}
+
 		return nil
 	},
 }
 
+// logRequest writes one line per exchange request to stderr
+func logRequest(m exchange.RequestMetrics) {
+	outcome := fmt.Sprintf("%d", m.Status)
+	if m.Err != nil {
+		outcome = m.Err.Error()
+	}
+	fmt.Fprintf(os.Stderr, "[%s] %s %s%s -> %s in %s (%d attempt(s))\n",
+		m.Exchange, m.Method, m.Host, m.Path, outcome, m.Duration.Round(time.Millisecond), m.Attempts)
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		var reported *reportedError
+		if !errors.As(err, &reported) {
+			fmt.Fprintln(os.Stderr, "Error:", describeError(err))
+		}
+		os.Exit(exitCode(err))
 	}
 }
 
 func init() {
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.terminalcrypto/config.yaml)")
-	rootCmd.PersistentFlags().StringVarP(&exchangeName, "exchange", "e", "", "exchange to use (binance, coinbase, okx)")
+	rootCmd.PersistentFlags().StringVarP(&exchangeName, "exchange", "e", "", "exchange to use (binance, coinbase, okx, composite to combine their prices, or paper:<exchange> to paper trade)")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", outputText, "output format: text, json, csv, ndjson or table")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "log each exchange request to stderr")
 }
@@ -13,6 +13,8 @@ import (
 	"golang.org/x/term"
 )
 
+var setupTestnet bool
+
 var setupCmd = &cobra.Command{
 	Use:   "setup [exchange]",
 	Short: "Configure API credentials for an exchange",
@@ -24,9 +26,14 @@ Supported exchanges: binance, coinbase, okx
 
 Example:
   terminalcrypto setup binance
+  terminalcrypto setup binance --testnet
 
 Note: For public data access (prices only), you can leave API credentials empty.
-However, some endpoints may require authentication for higher rate limits.`,
+However, some endpoints may require authentication for higher rate limits.
+
+With --testnet, the credentials are stored for the Binance spot testnet
+(https://testnet.binance.vision), used by 'terminalcrypto order --testnet',
+and the default exchange is left unchanged.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		exchangeName := strings.ToLower(args[0])
@@ -45,7 +52,15 @@ However, some endpoints may require authentication for higher rate limits.`,
 			return fmt.Errorf("invalid exchange: %s (valid options: binance, coinbase, okx)", exchangeName)
 		}
 
-		fmt.Printf("Setting up %s\n\n", exchangeName)
+		keyringName := exchangeName
+		if setupTestnet {
+			if exchangeName != "binance" {
+				return fmt.Errorf("--testnet is only available for binance")
+			}
+			keyringName = testnetKeyringName
+		}
+
+		fmt.Printf("Setting up %s\n\n", keyringName)
 		fmt.Println("Enter your API credentials (leave empty for public-only access):")
 
 		// Read API key
@@ -68,7 +83,7 @@ However, some endpoints may require authentication for higher rate limits.`,
 
 		// Store credentials if provided
 		if apiKey != "" || apiSecret != "" {
-			if err := keyring.StoreCredentials(exchangeName, apiKey, apiSecret); err != nil {
+			if err := keyring.StoreCredentials(keyringName, apiKey, apiSecret); err != nil {
 				return fmt.Errorf("failed to store credentials: %w", err)
 			}
 			fmt.Println("Credentials stored securely in system keyring")
@@ -76,6 +91,12 @@ However, some endpoints may require authentication for higher rate limits.`,
 			fmt.Println("No credentials provided. Using public-only access.")
 		}
 
+		if setupTestnet {
+			fmt.Println("\nYou can now place testnet orders with:")
+			fmt.Printf("  terminalcrypto order buy BTC 0.001 --testnet\n")
+			return nil
+		}
+
 		// Set as default exchange
 		if err := config.SetExchange(exchangeName); err != nil {
 			return fmt.Errorf("failed to update config: %w", err)
@@ -93,4 +114,5 @@ However, some endpoints may require authentication for higher rate limits.`,
 
 func init() {
 	rootCmd.AddCommand(setupCmd)
+	setupCmd.Flags().BoolVar(&setupTestnet, "testnet", false, "store credentials for the Binance spot testnet")
 }
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/config"
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+	"github.com/Carpe-Wang/terminalCrypto/internal/markets"
+)
+
+// catalogs holds the market catalogs loaded during this invocation, by exchange name.
+// Commands that query several exchanges at once load them concurrently.
+var (
+	catalogs   = make(map[string]*markets.Catalog)
+	catalogsMu sync.Mutex
+)
+
+// loadCatalog returns the (possibly cached) market catalog for client.
+// It returns nil without an error when the exchange cannot list its markets.
+// A wrapped exchange shares the catalog of the exchange that lists the markets.
+func loadCatalog(ctx context.Context, client exchange.Exchange, refresh bool) (*markets.Catalog, error) {
+	lister, ok := exchange.As[exchange.MarketLister](client)
+	if !ok || !client.Capabilities().Markets {
+		return nil, nil
+	}
+
+	name := client.GetName()
+	if named, ok := lister.(exchange.Exchange); ok {
+		name = named.GetName()
+	}
+
+	catalogsMu.Lock()
+	catalog, ok := catalogs[name]
+	catalogsMu.Unlock()
+	if !ok || refresh {
+		dir, err := config.Dir()
+		if err != nil {
+			return nil, err
+		}
+
+		catalog, err = markets.Load(ctx, lister, name, filepath.Join(dir, "cache"), config.GetMarketsCacheTTL(), refresh)
+		if err != nil {
+			return nil, err
+		}
+
+		catalogsMu.Lock()
+		catalogs[name] = catalog
+		catalogsMu.Unlock()
+	}
+
+	// Let the client read bare symbols such as WBETH against the listed markets
+	if aware, ok := exchange.As[exchange.MarketAware](client); ok {
+		aware.SetMarkets(catalog.Pair)
+	}
+	return catalog, nil
+}
+
+// resolveSymbol validates a user-supplied symbol against the exchange's market
+// catalog and returns it as an explicit BASE/QUOTE pair, which every client
+// normalizes unambiguously. When no catalog is available the symbol is
+// returned unchanged and left to the exchange to reject.
+func resolveSymbol(ctx context.Context, client exchange.Exchange, symbol string) (string, error) {
+	catalog, err := loadCatalog(ctx, client, false)
+	if err != nil || catalog == nil {
+		return symbol, nil
+	}
+
+	market, err := catalog.Resolve(symbol, client.NormalizeSymbol)
+	if err != nil {
+		return "", err
+	}
+
+	return market.Base + "/" + market.Quote, nil
+}
+
+// quoteCurrency returns the quote currency of a resolved symbol, e.g. "USDT" for
+// "BTC/USDT", falling back to the exchange's spelling of a bare symbol
+func quoteCurrency(client exchange.Exchange, symbol string) string {
+	for _, s := range []string{symbol, client.NormalizeSymbol(symbol)} {
+		for _, sep := range []string{"/", "-", "_"} {
+			if base, quote, ok := strings.Cut(strings.ToUpper(s), sep); ok && base != "" && quote != "" {
+				return quote
+			}
+		}
+	}
+
+	// A concatenated pair such as "BTCUSDT" ends in its quote
+	if quote, ok := strings.CutPrefix(client.NormalizeSymbol(symbol), strings.ToUpper(symbol)); ok && quote != "" {
+		return quote
+	}
+	return ""
+}
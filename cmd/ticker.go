@@ -3,10 +3,12 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
-	"github.com/Carpe-Wang/terminalCrypto/internal/keyring"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 )
@@ -20,102 +22,195 @@ Examples:
   terminalcrypto ticker BTC
   terminalcrypto ticker BTC ETH SOL
   terminalcrypto ticker BTC/USDT
-  terminalcrypto --exchange binance ticker BTC`,
-	Args: cobra.MinimumNArgs(1),
+  terminalcrypto --exchange binance ticker BTC
+  terminalcrypto ticker BTC ETH --output ndjson
+  terminalcrypto ticker BTC ETH -o table`,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{structuredOutputAnnotation: "true"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
 
-		// Get credentials (may be empty for public access)
-		var apiKey, apiSecret string
-		creds, err := keyring.GetCredentials(exchangeName)
-		if err == nil {
-			apiKey = creds.APIKey
-			apiSecret = creds.APISecret
-		}
-
 		// Create exchange client
-		client, err := exchange.Factory(exchangeName, apiKey, apiSecret)
+		client, err := newExchangeClient()
 		if err != nil {
 			return err
 		}
 
-		// Define styles
-		headerStyle := lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#FFFF00"))
+		// Fetch every ticker first; structured formats are written in one go
+		results := lookupTickers(ctx, client, args)
+		var firstErr error
+		for _, r := range results {
+			if r.err != nil && firstErr == nil {
+				firstErr = r.err
+			}
+		}
 
-		symbolStyle := lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#00D4FF"))
+		if outputFormat == outputText {
+			printTickers(client.GetName(), results)
+		} else if err := writeRecords(os.Stdout, outputFormat, results); err != nil {
+			return err
+		}
 
-		labelStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#888888"))
+		// Errors were reported per symbol; only the exit code is left to set
+		if firstErr != nil {
+			return &reportedError{err: firstErr}
+		}
+		return nil
+	},
+}
 
-		positiveStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#00FF87"))
+// tickerResult is the outcome of looking up one symbol's ticker. On success the
+// ticker's fields are promoted into the JSON object.
+type tickerResult struct {
+	Exchange string `json:"exchange"`
+	Symbol   string `json:"symbol"`
+	*models.Ticker
+	Error     string `json:"error,omitempty"`
+	ErrorKind string `json:"error_kind,omitempty"`
+
+	quote string
+	err   error
+}
 
-		negativeStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF0087"))
+func (r tickerResult) columns() []string {
+	return []string{"exchange", "symbol", "price", "change_24h", "volume_24h", "high_24h", "low_24h", "last_updated", "error", "error_kind"}
+}
 
-		valueStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF"))
+func (r tickerResult) values() []string {
+	if r.Ticker == nil {
+		return []string{r.Exchange, r.Symbol, "", "", "", "", "", "", r.Error, r.ErrorKind}
+	}
+	return []string{
+		r.Exchange,
+		r.Symbol,
+		formatFloat(r.Price),
+		formatFloat(r.Change24h),
+		formatFloat(r.Volume24h),
+		formatFloat(r.High24h),
+		formatFloat(r.Low24h),
+		formatTime(r.LastUpdated),
+		r.Error,
+		r.ErrorKind,
+	}
+}
 
-		// Print header
-		fmt.Println(headerStyle.Render(fmt.Sprintf("\n24h Market Data from %s:", strings.ToUpper(client.GetName()))))
-		fmt.Println(strings.Repeat("═", 60))
+// lookupTickers resolves symbols and fetches their tickers in one batch,
+// recording any error in the symbol's result
+func lookupTickers(ctx context.Context, client exchange.Exchange, symbols []string) []tickerResult {
+	results := make([]tickerResult, len(symbols))
+	var resolved []string
+	var index []int
+	for i, symbol := range symbols {
+		results[i] = tickerResult{Exchange: client.GetName(), Symbol: symbol}
 
-		// Fetch and display ticker data
-		for i, symbol := range args {
-			if i > 0 {
-				fmt.Println(strings.Repeat("─", 60))
-			}
+		r, err := resolveSymbol(ctx, client, symbol)
+		if err != nil {
+			results[i].fail(err)
+			continue
+		}
+		results[i].Symbol = client.NormalizeSymbol(r)
+		results[i].quote = quoteCurrency(client, r)
+		resolved = append(resolved, r)
+		index = append(index, i)
+	}
+
+	for j, r := range client.GetTickers(ctx, resolved) {
+		result := &results[index[j]]
+		if r.Err != nil {
+			result.fail(r.Err)
+			continue
+		}
 
-			ticker, err := client.GetTicker(ctx, symbol)
-			if err != nil {
-				fmt.Printf("\n%s: Error: %v\n", symbol, err)
-				continue
-			}
+		result.Ticker = r.Value
+		result.Symbol = r.Value.Symbol
+	}
+	return results
+}
 
-			// Display ticker information
-			fmt.Printf("\n%s\n", symbolStyle.Render(ticker.Symbol))
-
-			// Price
-			fmt.Printf("  %s  %s\n",
-				labelStyle.Render("Price:      "),
-				valueStyle.Render(fmt.Sprintf("$%.2f", ticker.Price)))
-
-			// 24h Change
-			changePercent := (ticker.Change24h / (ticker.Price - ticker.Change24h)) * 100
-			var changeStr string
-			if ticker.Change24h >= 0 {
-				changeStr = positiveStyle.Render(fmt.Sprintf("+$%.2f (+%.2f%%)", ticker.Change24h, changePercent))
-			} else {
-				changeStr = negativeStyle.Render(fmt.Sprintf("$%.2f (%.2f%%)", ticker.Change24h, changePercent))
-			}
-			fmt.Printf("  %s  %s\n",
-				labelStyle.Render("24h Change:"),
-				changeStr)
-
-			// 24h High
-			fmt.Printf("  %s  %s\n",
-				labelStyle.Render("24h High:  "),
-				valueStyle.Render(fmt.Sprintf("$%.2f", ticker.High24h)))
-
-			// 24h Low
-			fmt.Printf("  %s  %s\n",
-				labelStyle.Render("24h Low:   "),
-				valueStyle.Render(fmt.Sprintf("$%.2f", ticker.Low24h)))
-
-			// 24h Volume
-			fmt.Printf("  %s  %s\n",
-				labelStyle.Render("24h Volume:"),
-				valueStyle.Render(fmt.Sprintf("%.2f", ticker.Volume24h)))
+// fail records err in the result
+func (r *tickerResult) fail(err error) {
+	r.Ticker = nil
+	r.Error = describeError(err)
+	r.ErrorKind = errorKind(err)
+	r.err = err
+}
+
+// printTickers writes ticker results as styled text
+func printTickers(exchangeName string, results []tickerResult) {
+	// Define styles
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFF00"))
+
+	symbolStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#00D4FF"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888888"))
+
+	positiveStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#00FF87"))
+
+	negativeStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF0087"))
+
+	valueStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFFFFF"))
+
+	// Print header
+	fmt.Println(headerStyle.Render(fmt.Sprintf("\n24h Market Data from %s:", strings.ToUpper(exchangeName))))
+	fmt.Println(strings.Repeat("═", 60))
+
+	for i, r := range results {
+		if i > 0 {
+			fmt.Println(strings.Repeat("─", 60))
 		}
 
-		fmt.Println(strings.Repeat("═", 60))
-		fmt.Println()
-		return nil
-	},
+		if r.err != nil {
+			fmt.Printf("\n%s: %s\n", symbolStyle.Render(r.Symbol), negativeStyle.Render(fmt.Sprintf("Error: %s", r.Error)))
+			continue
+		}
+		ticker := r.Ticker
+
+		// Display ticker information
+		fmt.Printf("\n%s\n", symbolStyle.Render(ticker.Symbol))
+
+		// Price
+		fmt.Printf("  %s  %s\n",
+			labelStyle.Render("Price:      "),
+			valueStyle.Render(formatQuotePrice(ticker.Price, r.quote)))
+
+		// 24h Change
+		changePercent := (ticker.Change24h / (ticker.Price - ticker.Change24h)) * 100
+		var changeStr string
+		if ticker.Change24h >= 0 {
+			changeStr = positiveStyle.Render(fmt.Sprintf("+%s (+%.2f%%)", formatQuotePrice(ticker.Change24h, r.quote), changePercent))
+		} else {
+			changeStr = negativeStyle.Render(fmt.Sprintf("-%s (%.2f%%)", formatQuotePrice(-ticker.Change24h, r.quote), changePercent))
+		}
+		fmt.Printf("  %s  %s\n",
+			labelStyle.Render("24h Change:"),
+			changeStr)
+
+		// 24h High
+		fmt.Printf("  %s  %s\n",
+			labelStyle.Render("24h High:  "),
+			valueStyle.Render(formatQuotePrice(ticker.High24h, r.quote)))
+
+		// 24h Low
+		fmt.Printf("  %s  %s\n",
+			labelStyle.Render("24h Low:   "),
+			valueStyle.Render(formatQuotePrice(ticker.Low24h, r.quote)))
+
+		// 24h Volume
+		fmt.Printf("  %s  %s\n",
+			labelStyle.Render("24h Volume:"),
+			valueStyle.Render(fmt.Sprintf("%.2f", ticker.Volume24h)))
+	}
+
+	fmt.Println(strings.Repeat("═", 60))
+	fmt.Println()
 }
 
 func init() {
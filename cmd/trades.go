@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/config"
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tradesLimit    int
+	tradesFollow   bool
+	tradesInterval int
+	tradesLarge    float64
+)
+
+// maxTradesLimit is the most trades any exchange returns in one request
+const maxTradesLimit = 1000
+
+// maxSeenTrades bounds the set of trade IDs remembered while following
+const maxSeenTrades = 5000
+
+var tradesCmd = &cobra.Command{
+	Use:   "trades [symbol]",
+	Short: "Show recent trades for a cryptocurrency symbol",
+	Long: `Show the most recent public trades (the tape) for a symbol.
+Trades whose value exceeds the large-trade threshold are highlighted.
+
+Examples:
+  terminalcrypto trades BTC
+  terminalcrypto trades ETH --limit 50
+  terminalcrypto trades ETH --follow --large 250000`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		if tradesLimit < 1 || tradesLimit > maxTradesLimit {
+			return fmt.Errorf("--limit must be between 1 and %d", maxTradesLimit)
+		}
+		if tradesFollow && tradesInterval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+
+		// Create exchange client
+		client, err := newExchangeClient()
+		if err != nil {
+			return err
+		}
+
+		provider, ok := exchange.As[exchange.TradesProvider](client)
+		if !ok || !client.Capabilities().Trades {
+			return fmt.Errorf("%s does not provide trade data: %w", client.GetName(), exchange.ErrUnsupported)
+		}
+
+		if !cmd.Flags().Changed("large") {
+			tradesLarge = config.GetLargeTradeNotional()
+		}
+
+		symbol, err := resolveSymbol(ctx, client, args[0])
+		if err != nil {
+			return err
+		}
+
+		trades, err := provider.GetRecentTrades(ctx, symbol, tradesLimit)
+		if err != nil {
+			return err
+		}
+
+		headerStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#FFFF00"))
+
+		labelStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#888888"))
+
+		fmt.Println(headerStyle.Render(fmt.Sprintf("\nRecent trades for %s from %s:",
+			client.NormalizeSymbol(symbol), strings.ToUpper(client.GetName()))))
+		fmt.Println(labelStyle.Render(fmt.Sprintf("%-12s %-5s %16s %14s %16s", "Time", "Side", "Price", "Size", "Value")))
+		fmt.Println(strings.Repeat("─", 70))
+
+		seen := make(map[string]bool)
+		for _, t := range trades {
+			seen[t.ID] = true
+			fmt.Println(renderTrade(t, tradesLarge))
+		}
+
+		if !tradesFollow {
+			fmt.Println()
+			return nil
+		}
+
+		// Tail the tape by polling and printing trades not seen before
+		ticker := time.NewTicker(time.Duration(tradesInterval) * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				fmt.Println()
+				return nil
+			case <-ticker.C:
+			}
+
+			trades, err := provider.GetRecentTrades(ctx, symbol, tradesLimit)
+			if err != nil {
+				if ctx.Err() != nil {
+					continue
+				}
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				continue
+			}
+
+			for _, t := range trades {
+				if seen[t.ID] {
+					continue
+				}
+				seen[t.ID] = true
+				fmt.Println(renderTrade(t, tradesLarge))
+			}
+
+			// Only the latest batch can overlap with the next poll
+			if len(seen) > maxSeenTrades {
+				seen = make(map[string]bool)
+				for _, t := range trades {
+					seen[t.ID] = true
+				}
+			}
+		}
+	},
+}
+
+// renderTrade renders one line of the tape, highlighting prints above the large threshold
+func renderTrade(t models.Trade, largeNotional float64) string {
+	buyStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#00FF87"))
+
+	sellStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF0087"))
+
+	style := buyStyle
+	if t.Side == models.SideSell {
+		style = sellStyle
+	}
+
+	line := fmt.Sprintf("%-12s %-5s %16s %14s %16s",
+		t.Time.Local().Format("15:04:05.000"),
+		strings.ToUpper(string(t.Side)),
+		formatPrice(t.Price),
+		formatQuantity(t.Quantity),
+		formatPrice(t.Notional()))
+
+	if largeNotional > 0 && t.Notional() >= largeNotional {
+		return style.Bold(true).Reverse(true).Render(line) + " ◆"
+	}
+
+	return style.Render(line)
+}
+
+func init() {
+	rootCmd.AddCommand(tradesCmd)
+	tradesCmd.Flags().IntVarP(&tradesLimit, "limit", "n", 20, "number of recent trades to fetch (1-1000)")
+	tradesCmd.Flags().BoolVarP(&tradesFollow, "follow", "f", false, "keep printing new trades as they happen")
+	tradesCmd.Flags().IntVarP(&tradesInterval, "interval", "i", 2, "polling interval in seconds when following")
+	tradesCmd.Flags().Float64Var(&tradesLarge, "large", 0, "highlight trades worth more than this (default from config trades.large_notional)")
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/Carpe-Wang/terminalCrypto/internal/chart"
+	"github.com/Carpe-Wang/terminalCrypto/internal/config"
 	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
-	"github.com/Carpe-Wang/terminalCrypto/internal/keyring"
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
@@ -15,30 +18,197 @@ import (
 
 var (
 	refreshInterval int
+	noStream        bool
+	watchColumnList []string
+	sparkLength     int
 )
 
+// maxConcurrentFetches bounds the price requests in flight at once while polling
+const maxConcurrentFetches = 5
+
+// minSparkWidth is the narrowest sparkline worth drawing; a narrower terminal drops it
+const minSparkWidth = 5
+
 type priceData struct {
 	symbol    string
 	price     float64
 	lastPrice float64
 	err       error
+
+	// ticker holds the 24h statistics, fetched only when a column shows them
+	ticker *models.Ticker
+
+	// bid and ask are the best prices in the book, fetched only for the spread column
+	bid, ask float64
+}
+
+// watchColumn is an optional column of the watch table
+type watchColumn struct {
+	name   string
+	header string
+
+	// ticker and book say what the column needs fetched besides the price
+	ticker bool
+	book   bool
+
+	// value returns the cell text and whether it is a gain (+1), a loss (-1) or neither (0)
+	value func(d *priceData) (string, int)
+}
+
+// sparkColumn selects the sparkline of recent prices, always drawn last
+const sparkColumn = "spark"
+
+// watchColumns are the columns --columns can select, in the order they are listed in help
+var watchColumns = []watchColumn{
+	{name: "change", header: "24h %", ticker: true, value: func(d *priceData) (string, int) {
+		open := d.ticker.Price - d.ticker.Change24h
+		if open <= 0 {
+			return "-", 0
+		}
+		change := d.ticker.Change24h / open * 100
+		return fmt.Sprintf("%+.2f%%", change), sign(change)
+	}},
+	{name: "high", header: "24h High", ticker: true, value: func(d *priceData) (string, int) {
+		return formatPrice(d.ticker.High24h), 0
+	}},
+	{name: "low", header: "24h Low", ticker: true, value: func(d *priceData) (string, int) {
+		return formatPrice(d.ticker.Low24h), 0
+	}},
+	{name: "volume", header: "24h Volume", ticker: true, value: func(d *priceData) (string, int) {
+		return formatCompact(d.ticker.Volume24h), 0
+	}},
+	{name: "from-high", header: "From High", ticker: true, value: func(d *priceData) (string, int) {
+		if d.ticker.High24h <= 0 {
+			return "-", 0
+		}
+		return fmt.Sprintf("%+.2f%%", (d.price-d.ticker.High24h)/d.ticker.High24h*100), 0
+	}},
+	{name: "from-low", header: "From Low", ticker: true, value: func(d *priceData) (string, int) {
+		if d.ticker.Low24h <= 0 {
+			return "-", 0
+		}
+		return fmt.Sprintf("%+.2f%%", (d.price-d.ticker.Low24h)/d.ticker.Low24h*100), 0
+	}},
+	{name: "spread", header: "Spread", book: true, value: func(d *priceData) (string, int) {
+		if d.bid <= 0 || d.ask <= 0 {
+			return "-", 0
+		}
+		return fmt.Sprintf("%.2f bps", (d.ask-d.bid)/((d.ask+d.bid)/2)*10000), 0
+	}},
+}
+
+// sign returns +1, -1 or 0 for the sign of v
+func sign(v float64) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
+
+// parseWatchColumns looks up the named columns and reports whether the sparkline is among them
+func parseWatchColumns(names []string) ([]watchColumn, bool, error) {
+	var columns []watchColumn
+	spark := false
+
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == sparkColumn {
+			spark = true
+			continue
+		}
+
+		found := false
+		for _, c := range watchColumns {
+			if c.name == name {
+				columns = append(columns, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			available := []string{}
+			for _, c := range watchColumns {
+				available = append(available, c.name)
+			}
+			return nil, false, fmt.Errorf("unknown column %q (available: %s, %s)", name, strings.Join(available, ", "), sparkColumn)
+		}
+	}
+
+	return columns, spark, nil
+}
+
+// watchNeeds says what the selected columns need fetched besides the price
+type watchNeeds struct {
+	tickers bool
+	book    bool
 }
 
 type tickMsg time.Time
 
+// streamClosedMsg signals that the websocket stream stopped and polling should take over
+type streamClosedMsg struct{}
+
+// priceMsg delivers one symbol's fetched price; results carries the rest of the fetch
+type priceMsg struct {
+	data    *priceData
+	results <-chan *priceData
+}
+
+// fetchDoneMsg signals that every symbol of a fetch has been delivered
+type fetchDoneMsg struct{}
+
 type model struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
 	client   exchange.Exchange
 	symbols  []string
 	prices   map[string]*priceData
+	updates  <-chan models.PriceUpdate
+	fetching bool
 	quitting bool
 	err      error
+
+	columns []watchColumn
+	spark   bool
+	needs   watchNeeds
+
+	// history holds up to sparkLength of each symbol's latest prices, oldest first
+	history map[string][]float64
+
+	// width is the terminal width, zero until the first tea.WindowSizeMsg
+	width int
+}
+
+// polling reports whether the model refreshes on a timer: always without a
+// stream, and alongside one when columns show 24h statistics or the spread
+func (m model) polling() bool {
+	return m.updates == nil || m.needs.tickers || m.needs.book
 }
 
 func (m model) Init() tea.Cmd {
-	return tea.Batch(
-		tickCmd(),
-		fetchPrices(m.client, m.symbols),
-	)
+	// Fetch once up front so every row is populated (or shows its error) before the first stream update
+	cmds := []tea.Cmd{fetchPrices(m.ctx, m.client, m.symbols, m.needs)}
+	if m.updates != nil {
+		cmds = append(cmds, waitForUpdate(m.updates))
+	}
+	if m.polling() {
+		cmds = append(cmds, tickCmd())
+	}
+	return tea.Batch(cmds...)
+}
+
+// waitForUpdate delivers the next streamed price update to the model
+func waitForUpdate(updates <-chan models.PriceUpdate) tea.Cmd {
+	return func() tea.Msg {
+		update, ok := <-updates
+		if !ok {
+			return streamClosedMsg{}
+		}
+		return update
+	}
 }
 
 func tickCmd() tea.Cmd {
@@ -47,49 +217,173 @@ func tickCmd() tea.Cmd {
 	})
 }
 
-func fetchPrices(client exchange.Exchange, symbols []string) tea.Cmd {
+// fetchPrices fetches every symbol's price, and the 24h statistics or best bid
+// and ask when needs asks for them, delivering each symbol to the model as soon
+// as it arrives. An exchange with a batch endpoint is asked for all of them in
+// one request; otherwise a bounded pool of workers fetches them one by one.
+// Cancelling ctx stops the requests in flight.
+func fetchPrices(ctx context.Context, client exchange.Exchange, symbols []string, needs watchNeeds) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
-		results := make(map[string]*priceData)
+		batches := [][]string{symbols}
+		if !client.Capabilities().Batch {
+			batches = make([][]string, len(symbols))
+			for i, symbol := range symbols {
+				batches[i] = []string{symbol}
+			}
+		}
 
-		for _, symbol := range symbols {
-			price, err := client.GetPrice(ctx, symbol)
-			normalizedSymbol := client.NormalizeSymbol(symbol)
+		jobs := make(chan []string)
+		results := make(chan *priceData)
+
+		var wg sync.WaitGroup
+		for range min(maxConcurrentFetches, len(batches)) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for batch := range jobs {
+					for _, data := range fetchBatch(ctx, client, batch, needs) {
+						select {
+						case results <- data:
+						case <-ctx.Done():
+							return
+						}
+					}
+				}
+			}()
+		}
 
-			results[normalizedSymbol] = &priceData{
-				symbol: normalizedSymbol,
-				price:  price,
-				err:    err,
+		go func() {
+			defer close(results)
+			defer wg.Wait()
+			defer close(jobs)
+			for _, batch := range batches {
+				select {
+				case jobs <- batch:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		return waitForPrice(results)()
+	}
+}
+
+// fetchBatch fetches the prices, or tickers, of a batch of symbols and the book
+// of each when the spread is shown
+func fetchBatch(ctx context.Context, client exchange.Exchange, batch []string, needs watchNeeds) []*priceData {
+	data := make([]*priceData, len(batch))
+	if needs.tickers {
+		for i, r := range client.GetTickers(ctx, batch) {
+			data[i] = &priceData{symbol: client.NormalizeSymbol(batch[i]), ticker: r.Value, err: r.Err}
+			if r.Err == nil {
+				data[i].price = r.Value.Price
 			}
 		}
+	} else {
+		for i, r := range client.GetPrices(ctx, batch) {
+			data[i] = &priceData{symbol: client.NormalizeSymbol(batch[i]), price: r.Value, err: r.Err}
+		}
+	}
+
+	// The book only adds the bid and ask, so a failed one leaves the spread blank
+	provider, ok := exchange.As[exchange.OrderBookProvider](client)
+	if !needs.book || !ok || !client.Capabilities().OrderBook {
+		return data
+	}
+	for i, d := range data {
+		if d.err != nil {
+			continue
+		}
+		if book, err := provider.GetOrderBook(ctx, batch[i], 1); err == nil && len(book.Bids) > 0 && len(book.Asks) > 0 {
+			d.bid, d.ask = book.Bids[0].Price, book.Asks[0].Price
+		}
+	}
+	return data
+}
+
+// waitForPrice delivers the next price of a fetch to the model
+func waitForPrice(results <-chan *priceData) tea.Cmd {
+	return func() tea.Msg {
+		data, ok := <-results
+		if !ok {
+			return fetchDoneMsg{}
+		}
+		return priceMsg{data: data, results: results}
+	}
+}
 
-		return results
+// record keeps a new price for symbol in its sparkline history
+func (m model) record(symbol string, price float64) {
+	history := append(m.history[symbol], price)
+	if len(history) > sparkLength {
+		history = history[len(history)-sparkLength:]
 	}
+	m.history[symbol] = history
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		return m, nil
+
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "q", "ctrl+c":
+			// Stop the requests in flight rather than waiting for them
+			m.cancel()
 			m.quitting = true
 			return m, tea.Quit
 		}
 
 	case tickMsg:
+		// Skip this refresh if the previous one is still running
+		if m.fetching {
+			return m, tickCmd()
+		}
+		m.fetching = true
 		return m, tea.Batch(
 			tickCmd(),
-			fetchPrices(m.client, m.symbols),
+			fetchPrices(m.ctx, m.client, m.symbols, m.needs),
 		)
 
-	case map[string]*priceData:
-		// Update prices and track previous values
-		for symbol, newData := range msg {
-			if oldData, exists := m.prices[symbol]; exists {
-				newData.lastPrice = oldData.price
-			}
-			m.prices[symbol] = newData
+	case models.PriceUpdate:
+		// Keep the 24h statistics and book of the last fetch until the next one
+		newData := &priceData{
+			symbol: msg.Symbol,
+			price:  msg.Price,
+		}
+		if oldData, exists := m.prices[msg.Symbol]; exists {
+			newData.lastPrice = oldData.price
+			newData.ticker = oldData.ticker
+			newData.bid, newData.ask = oldData.bid, oldData.ask
 		}
+		m.prices[msg.Symbol] = newData
+		m.record(msg.Symbol, msg.Price)
+		return m, waitForUpdate(m.updates)
+
+	case streamClosedMsg:
+		wasPolling := m.polling()
+		m.updates = nil
+		if wasPolling {
+			return m, nil
+		}
+		return m, tickCmd()
+
+	case priceMsg:
+		// Update the price and track its previous value
+		if oldData, exists := m.prices[msg.data.symbol]; exists {
+			msg.data.lastPrice = oldData.price
+		}
+		m.prices[msg.data.symbol] = msg.data
+		if msg.data.err == nil {
+			m.record(msg.data.symbol, msg.data.price)
+		}
+		return m, waitForPrice(msg.results)
+
+	case fetchDoneMsg:
+		m.fetching = false
 		return m, nil
 
 	case error:
@@ -100,13 +394,19 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// watchCell is one cell of the watch table before styling
+type watchCell struct {
+	text  string
+	trend int
+}
+
 func (m model) View() string {
 	if m.quitting {
 		return "Goodbye!\n"
 	}
 
 	if m.err != nil {
-		return fmt.Sprintf("Error: %v\n", m.err)
+		return fmt.Sprintf("Error: %s\n", describeError(m.err))
 	}
 
 	// Define styles
@@ -118,8 +418,10 @@ func (m model) View() string {
 
 	symbolStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#00D4FF")).
-		Width(15)
+		Foreground(lipgloss.Color("#00D4FF"))
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#888888"))
 
 	priceUpStyle := lipgloss.NewStyle().
 		Bold(true).
@@ -133,6 +435,12 @@ func (m model) View() string {
 		Bold(true).
 		Foreground(lipgloss.Color("#FFFFFF"))
 
+	upStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#00FF87"))
+
+	downStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF0087"))
+
 	errorStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#FF0087"))
 
@@ -140,31 +448,105 @@ func (m model) View() string {
 		Foreground(lipgloss.Color("#888888")).
 		Italic(true)
 
+	// Lay out the table: the symbol, the price and the selected columns, each as
+	// wide as its widest cell, then the sparkline in whatever width is left
+	names := make([]string, len(m.symbols))
+	symbolWidth := len("Symbol")
+	for i, symbol := range m.symbols {
+		names[i] = m.client.NormalizeSymbol(symbol)
+		symbolWidth = max(symbolWidth, len(names[i]))
+	}
+
+	priceWidth := len("Price")
+	cells := make([][]watchCell, len(m.columns))
+	widths := make([]int, len(m.columns))
+	for c, column := range m.columns {
+		cells[c] = make([]watchCell, len(names))
+		widths[c] = len(column.header)
+	}
+	for i, name := range names {
+		data, ok := m.prices[name]
+		if !ok || data.err != nil {
+			continue
+		}
+		priceWidth = max(priceWidth, len(formatPrice(data.price)))
+
+		for c, column := range m.columns {
+			cell := watchCell{text: "-"}
+			if data.ticker != nil || !column.ticker {
+				cell.text, cell.trend = column.value(data)
+			}
+			cells[c][i] = cell
+			widths[c] = max(widths[c], len(cell.text))
+		}
+	}
+
+	// The price column carries a trend arrow after the price
+	tableWidth := symbolWidth + 2 + priceWidth + 2
+	shown := 0
+	for c := range m.columns {
+		if m.width > 0 && tableWidth+2+widths[c] > m.width {
+			break
+		}
+		tableWidth += 2 + widths[c]
+		shown++
+	}
+
+	sparkWidth := 0
+	if m.spark {
+		sparkWidth = sparkLength
+		if m.width > 0 {
+			sparkWidth = min(sparkWidth, m.width-tableWidth-2)
+		}
+		if sparkWidth < minSparkWidth {
+			sparkWidth = 0
+		} else {
+			tableWidth += 2 + sparkWidth
+		}
+	}
+
+	ruleWidth := max(tableWidth, 50)
+	if m.width > 0 {
+		ruleWidth = min(ruleWidth, m.width)
+	}
+
 	// Build the view
 	var s strings.Builder
 
 	// Title
 	s.WriteString(titleStyle.Render(fmt.Sprintf(" Real-time Prices (%s) ", strings.ToUpper(m.client.GetName()))))
 	s.WriteString("\n")
-	s.WriteString(strings.Repeat("═", 50))
+	s.WriteString(strings.Repeat("═", ruleWidth))
 	s.WriteString("\n\n")
 
 	// Price table
 	if len(m.prices) == 0 {
 		s.WriteString("Loading prices...\n")
 	} else {
-		for _, symbol := range m.symbols {
-			normalizedSymbol := m.client.NormalizeSymbol(symbol)
-			data, exists := m.prices[normalizedSymbol]
+		header := fmt.Sprintf("%-*s  %*s  ", symbolWidth, "Symbol", priceWidth, "Price")
+		for c := range shown {
+			header += fmt.Sprintf("  %*s", widths[c], m.columns[c].header)
+		}
+		if sparkWidth > 0 {
+			header += fmt.Sprintf("  %-*s", sparkWidth, "Trend")
+		}
+		s.WriteString(labelStyle.Render(header))
+		s.WriteString("\n")
+
+		for i, name := range names {
+			data, exists := m.prices[name]
 
 			if !exists {
+				s.WriteString(fmt.Sprintf("%s  %s\n",
+					symbolStyle.Render(fmt.Sprintf("%-*s", symbolWidth, name)),
+					helpStyle.Render("loading...")))
 				continue
 			}
 
 			if data.err != nil {
-				s.WriteString(fmt.Sprintf("%s %s\n",
-					symbolStyle.Render(normalizedSymbol),
-					errorStyle.Render(fmt.Sprintf("Error: %v", data.err))))
+				s.WriteString(fmt.Sprintf("%s  %s\n",
+					symbolStyle.Render(fmt.Sprintf("%-*s", symbolWidth, name)),
+					errorStyle.Render(fmt.Sprintf("Error: %s", describeError(data.err)))))
 				continue
 			}
 
@@ -188,18 +570,46 @@ func (m model) View() string {
 				indicator = "─"
 			}
 
-			s.WriteString(fmt.Sprintf("%s %s %s\n",
-				symbolStyle.Render(normalizedSymbol),
-				priceStyle.Render(fmt.Sprintf("$%.2f", data.price)),
+			s.WriteString(fmt.Sprintf("%s  %s %s",
+				symbolStyle.Render(fmt.Sprintf("%-*s", symbolWidth, name)),
+				priceStyle.Render(fmt.Sprintf("%*s", priceWidth, formatPrice(data.price))),
 				indicator))
+
+			for c := range shown {
+				text := fmt.Sprintf("%*s", widths[c], cells[c][i].text)
+				switch cells[c][i].trend {
+				case 1:
+					text = upStyle.Render(text)
+				case -1:
+					text = downStyle.Render(text)
+				}
+				s.WriteString("  " + text)
+			}
+
+			if sparkWidth > 0 {
+				history := m.history[name]
+				if len(history) > sparkWidth {
+					history = history[len(history)-sparkWidth:]
+				}
+				trend := upStyle
+				if len(history) > 0 && history[len(history)-1] < history[0] {
+					trend = downStyle
+				}
+				s.WriteString("  " + trend.Render(chart.Sparkline(history)))
+			}
+			s.WriteString("\n")
 		}
 	}
 
 	// Footer
 	s.WriteString("\n")
-	s.WriteString(strings.Repeat("═", 50))
+	s.WriteString(strings.Repeat("═", ruleWidth))
 	s.WriteString("\n")
-	s.WriteString(helpStyle.Render(fmt.Sprintf("Refreshing every %d seconds • Press 'q' to quit", refreshInterval)))
+	if m.updates != nil {
+		s.WriteString(helpStyle.Render("Streaming live prices • Press 'q' to quit"))
+	} else {
+		s.WriteString(helpStyle.Render(fmt.Sprintf("Refreshing every %d seconds • Press 'q' to quit", refreshInterval)))
+	}
 	s.WriteString("\n")
 
 	return s.String()
@@ -210,41 +620,100 @@ var watchCmd = &cobra.Command{
 	Short: "Watch real-time prices for cryptocurrency symbols",
 	Long: `Watch real-time cryptocurrency prices with auto-refresh.
 Prices are color-coded to show increases (green) and decreases (red).
+Exchanges with a websocket feed stream prices live; others are polled.
+
+--columns picks what is shown next to each price (default: watch.columns in
+the config file):
+  change      24h change in percent
+  high, low   24h high and low
+  volume      24h volume in the base asset
+  from-high   distance of the price below the 24h high, in percent
+  from-low    distance of the price above the 24h low, in percent
+  spread      best bid/ask spread in basis points (one order book request per symbol)
+  spark       sparkline of the last --spark prices
+
+Columns that do not fit the terminal are left out, rightmost first. The 24h
+statistics and the spread are refreshed every --interval seconds, also while
+prices are streamed.
 
 Examples:
   terminalcrypto watch BTC
   terminalcrypto watch BTC ETH SOL
   terminalcrypto watch BTC/USDT ETH/USDT --interval 3
-  terminalcrypto --exchange binance watch BTC`,
+  terminalcrypto watch BTC ETH --columns change,high,low,spark --spark 60
+  terminalcrypto --exchange binance watch BTC --columns spread,from-high`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Get credentials (may be empty for public access)
-		var apiKey, apiSecret string
-		creds, err := keyring.GetCredentials(exchangeName)
-		if err == nil {
-			apiKey = creds.APIKey
-			apiSecret = creds.APISecret
+		names := watchColumnList
+		if !cmd.Flags().Changed("columns") {
+			names = config.GetWatchColumns()
+		}
+		columns, spark, err := parseWatchColumns(names)
+		if err != nil {
+			return err
+		}
+		if sparkLength < 1 {
+			return fmt.Errorf("--spark must be at least 1")
+		}
+		if refreshInterval < 1 {
+			return fmt.Errorf("--interval must be positive")
 		}
 
 		// Create exchange client
-		client, err := exchange.Factory(exchangeName, apiKey, apiSecret)
+		client, err := newExchangeClient()
 		if err != nil {
 			return err
 		}
 
-		// Create the model
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Validate every symbol before starting the UI
+		symbols := make([]string, len(args))
+		for i, symbol := range args {
+			resolved, err := resolveSymbol(ctx, client, symbol)
+			if err != nil {
+				return err
+			}
+			symbols[i] = resolved
+		}
+
+		// Create the model; Init starts the first fetch
 		m := model{
-			client:  client,
-			symbols: args,
-			prices:  make(map[string]*priceData),
+			ctx:      ctx,
+			cancel:   cancel,
+			client:   client,
+			symbols:  symbols,
+			prices:   make(map[string]*priceData),
+			fetching: true,
+			columns:  columns,
+			spark:    spark,
+			history:  make(map[string][]float64),
+		}
+		for _, c := range columns {
+			m.needs.tickers = m.needs.tickers || c.ticker
+			m.needs.book = m.needs.book || c.book
+		}
+
+		// Prefer the websocket stream when the exchange supports it, otherwise poll
+		if streamer, ok := exchange.As[exchange.Streamer](client); ok && client.Capabilities().Streaming && !noStream {
+			if updates, err := streamer.SubscribeTickers(ctx, symbols); err == nil {
+				m.updates = updates
+			}
 		}
 
 		// Run the Bubble Tea program
 		p := tea.NewProgram(m)
-		if _, err := p.Run(); err != nil {
+		final, err := p.Run()
+		if err != nil {
 			return fmt.Errorf("error running watch: %w", err)
 		}
 
+		// A fatal error was already shown by the view
+		if fm, ok := final.(model); ok && fm.err != nil {
+			return &reportedError{err: fm.err}
+		}
+
 		return nil
 	},
 }
@@ -252,4 +721,7 @@ Examples:
 func init() {
 	rootCmd.AddCommand(watchCmd)
 	watchCmd.Flags().IntVarP(&refreshInterval, "interval", "i", 5, "refresh interval in seconds")
+	watchCmd.Flags().BoolVar(&noStream, "no-stream", false, "poll prices even if the exchange supports streaming")
+	watchCmd.Flags().StringSliceVarP(&watchColumnList, "columns", "c", nil, "columns to show: change, high, low, volume, from-high, from-low, spread, spark")
+	watchCmd.Flags().IntVar(&sparkLength, "spark", 30, "number of recent prices in the sparkline")
 }
@@ -0,0 +1,254 @@
+// Package alerts stores price alert rules and evaluates them against live prices.
+package alerts
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Condition is what a rule watches for
+type Condition string
+
+const (
+	// Above fires when the price is at or above Value
+	Above Condition = "above"
+
+	// Below fires when the price is at or below Value
+	Below Condition = "below"
+
+	// Move fires when the price moves by Value percent, either way, within Window
+	Move Condition = "move"
+
+	// CrossEMA fires when the price crosses the EMA of Value periods on Interval candles
+	CrossEMA Condition = "cross-ema"
+)
+
+// Conditions lists every condition a rule can use
+var Conditions = []Condition{Above, Below, Move, CrossEMA}
+
+// Duration is a time.Duration that is written to YAML and JSON as text, e.g. "15m"
+type Duration time.Duration
+
+// String formats the duration without zero trailing units, e.g. "1h" rather than "1h0m0s"
+func (d Duration) String() string {
+	s := time.Duration(d).String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return d.String(), nil
+}
+
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	v, err := time.ParseDuration(node.Value)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: %w", node.Value, err)
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// Rule is one alert
+type Rule struct {
+	ID        int       `yaml:"id" json:"id"`
+	Exchange  string    `yaml:"exchange" json:"exchange"`
+	Symbol    string    `yaml:"symbol" json:"symbol"`
+	Condition Condition `yaml:"condition" json:"condition"`
+
+	// Value is the price level, the percent move or the EMA period, by condition
+	Value float64 `yaml:"value" json:"value"`
+
+	// Window is the period a Move is measured over
+	Window Duration `yaml:"window,omitempty" json:"window,omitempty"`
+
+	// Interval is the candle interval of a CrossEMA
+	Interval string `yaml:"interval,omitempty" json:"interval,omitempty"`
+
+	// Cooldown is the least time between two firings of the rule
+	Cooldown Duration `yaml:"cooldown,omitempty" json:"cooldown,omitempty"`
+
+	Note      string    `yaml:"note,omitempty" json:"note,omitempty"`
+	CreatedAt time.Time `yaml:"created_at" json:"created_at"`
+}
+
+// Validate checks that the rule is complete for its condition
+func (r Rule) Validate() error {
+	if r.Exchange == "" || r.Symbol == "" {
+		return fmt.Errorf("alert needs an exchange and a symbol")
+	}
+	if r.Cooldown < 0 {
+		return fmt.Errorf("cooldown must not be negative")
+	}
+
+	switch r.Condition {
+	case Above, Below:
+		if r.Value <= 0 {
+			return fmt.Errorf("price level must be positive")
+		}
+	case Move:
+		if r.Value <= 0 {
+			return fmt.Errorf("percent move must be positive")
+		}
+		if r.Window <= 0 {
+			return fmt.Errorf("a move alert needs a window, e.g. 1h")
+		}
+	case CrossEMA:
+		if r.Value < 1 || r.Value != float64(int(r.Value)) {
+			return fmt.Errorf("EMA period must be a positive whole number")
+		}
+		if r.Interval == "" {
+			return fmt.Errorf("an EMA alert needs a candle interval, e.g. 1h")
+		}
+	default:
+		names := make([]string, len(Conditions))
+		for i, c := range Conditions {
+			names[i] = string(c)
+		}
+		return fmt.Errorf("unknown condition %q (use one of: %s)", r.Condition, strings.Join(names, ", "))
+	}
+
+	return nil
+}
+
+// String describes the rule, e.g. "BTC/USDT above 70000"
+func (r Rule) String() string {
+	value := strconv.FormatFloat(r.Value, 'f', -1, 64)
+	switch r.Condition {
+	case Move:
+		return fmt.Sprintf("%s moves %s%% within %s", r.Symbol, value, r.Window)
+	case CrossEMA:
+		return fmt.Sprintf("%s crosses EMA(%s) on %s", r.Symbol, value, r.Interval)
+	default:
+		return fmt.Sprintf("%s %s %s", r.Symbol, r.Condition, value)
+	}
+}
+
+// file is the layout of alerts.yaml
+type file struct {
+	Alerts []Rule `yaml:"alerts"`
+}
+
+// Path returns the location of the alerts file in dir
+func Path(dir string) string {
+	return filepath.Join(dir, "alerts.yaml")
+}
+
+// Load reads the rules stored at path, ordered by ID. A missing file holds no rules.
+func Load(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read alerts: %w", err)
+	}
+
+	var f file
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+
+	sort.Slice(f.Alerts, func(i, j int) bool { return f.Alerts[i].ID < f.Alerts[j].ID })
+	return f.Alerts, nil
+}
+
+// Save writes rules to path, replacing the file atomically
+func Save(path string, rules []Rule) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create alerts directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(file{Alerts: rules})
+	if err != nil {
+		return fmt.Errorf("failed to encode alerts: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write alerts: %w", err)
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// Add validates rule, gives it the next free ID and appends it to the file at path
+func Add(path string, rule Rule) (Rule, error) {
+	if err := rule.Validate(); err != nil {
+		return Rule{}, err
+	}
+
+	rules, err := Load(path)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	rule.ID = 1
+	for _, r := range rules {
+		rule.ID = max(rule.ID, r.ID+1)
+	}
+	if rule.CreatedAt.IsZero() {
+		rule.CreatedAt = time.Now().UTC()
+	}
+
+	if err := Save(path, append(rules, rule)); err != nil {
+		return Rule{}, err
+	}
+	return rule, nil
+}
+
+// Remove deletes the rules with the given IDs from the file at path and returns
+// the ones it removed. Unknown IDs are an error and leave the file unchanged.
+func Remove(path string, ids ...int) ([]Rule, error) {
+	rules, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	remove := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		remove[id] = true
+	}
+
+	var kept, removed []Rule
+	for _, r := range rules {
+		if remove[r.ID] {
+			removed = append(removed, r)
+			delete(remove, r.ID)
+		} else {
+			kept = append(kept, r)
+		}
+	}
+
+	if len(remove) > 0 {
+		var missing []string
+		for _, id := range ids {
+			if remove[id] {
+				missing = append(missing, strconv.Itoa(id))
+			}
+		}
+		return nil, fmt.Errorf("no alert with ID %s", strings.Join(missing, ", "))
+	}
+
+	if err := Save(path, kept); err != nil {
+		return nil, err
+	}
+	return removed, nil
+}
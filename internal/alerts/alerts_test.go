@@ -0,0 +1,107 @@
+package alerts
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRulesRoundTrip(t *testing.T) {
+	path := Path(filepath.Join(t.TempDir(), "config"))
+	created := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	rules := []Rule{
+		{Exchange: "binance", Symbol: "BTC/USDT", Condition: Above, Value: 70000, Cooldown: Duration(15 * time.Minute), Note: "breakout", CreatedAt: created},
+		{Exchange: "okx", Symbol: "ETH/USDT", Condition: Move, Value: 2.5, Window: Duration(time.Hour), CreatedAt: created},
+		{Exchange: "coinbase", Symbol: "SOL/USD", Condition: CrossEMA, Value: 50, Interval: "4h", CreatedAt: created},
+	}
+	for i, rule := range rules {
+		added, err := Add(path, rule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if added.ID != i+1 {
+			t.Errorf("rule %d got ID %d", i, added.ID)
+		}
+		rules[i].ID = added.ID
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, rules) {
+		t.Errorf("loaded %+v\nwant %+v", loaded, rules)
+	}
+
+	// Durations are stored as text
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"cooldown: 15m", "window: 1h"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("alerts.yaml lacks %q:\n%s", want, data)
+		}
+	}
+
+	removed, err := Remove(path, 2)
+	if err != nil || len(removed) != 1 || removed[0].ID != 2 {
+		t.Fatalf("Remove(2) = %v, %v", removed, err)
+	}
+	if _, err := Remove(path, 2, 3); err == nil || !strings.Contains(err.Error(), "no alert with ID 2") {
+		t.Errorf("removing a missing ID: err = %v", err)
+	}
+
+	// A new rule never reuses the highest ID
+	added, err := Add(path, rules[0])
+	if err != nil || added.ID != 4 {
+		t.Errorf("Add after remove = ID %d, %v; want 4", added.ID, err)
+	}
+
+	loaded, err = Load(path)
+	if err != nil || len(loaded) != 3 || loaded[0].ID != 1 || loaded[1].ID != 3 {
+		t.Errorf("after remove and add: %+v, %v", loaded, err)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	rules, err := Load(filepath.Join(t.TempDir(), "alerts.yaml"))
+	if err != nil || rules != nil {
+		t.Errorf("Load = %v, %v; want no rules", rules, err)
+	}
+}
+
+func TestRuleValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		rule Rule
+		want string
+	}{
+		{"above", Rule{Exchange: "binance", Symbol: "BTC", Condition: Above, Value: 1}, ""},
+		{"no symbol", Rule{Exchange: "binance", Condition: Above, Value: 1}, "needs an exchange and a symbol"},
+		{"zero level", Rule{Exchange: "binance", Symbol: "BTC", Condition: Below}, "price level must be positive"},
+		{"move without window", Rule{Exchange: "binance", Symbol: "BTC", Condition: Move, Value: 5}, "needs a window"},
+		{"fractional period", Rule{Exchange: "binance", Symbol: "BTC", Condition: CrossEMA, Value: 2.5, Interval: "1h"}, "whole number"},
+		{"ema without interval", Rule{Exchange: "binance", Symbol: "BTC", Condition: CrossEMA, Value: 20}, "needs a candle interval"},
+		{"unknown condition", Rule{Exchange: "binance", Symbol: "BTC", Condition: "sideways", Value: 1}, `unknown condition "sideways"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("err = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
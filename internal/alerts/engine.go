@@ -0,0 +1,263 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+	"github.com/Carpe-Wang/terminalCrypto/internal/indicators"
+)
+
+// Event is a rule firing
+type Event struct {
+	Rule  Rule      `json:"rule"`
+	Price float64   `json:"price"`
+	Time  time.Time `json:"time"`
+
+	// Reference is what the price was compared with: the level, the price at
+	// the start of the window or the EMA
+	Reference float64 `json:"reference"`
+	Message   string  `json:"message"`
+}
+
+// ClientFunc returns the client for an exchange by name
+type ClientFunc func(exchangeName string) (exchange.Exchange, error)
+
+// Engine evaluates rules against live prices
+type Engine struct {
+	rules     []Rule
+	clients   ClientFunc
+	notifiers []Notifier
+	state     map[int]*ruleState
+	now       func() time.Time
+}
+
+// ruleState is what the engine remembers about a rule between checks
+type ruleState struct {
+	// armed is false after a firing until the condition stops holding, so that
+	// a level that stays crossed is reported once
+	armed     bool
+	lastFired time.Time
+
+	// samples are the prices seen within the window of a Move
+	samples []sample
+
+	// side is +1 while the price is above the EMA of a CrossEMA, -1 below, 0 before the first check
+	side int
+}
+
+type sample struct {
+	time  time.Time
+	price float64
+}
+
+// NewEngine creates an engine for rules that sends events to notifiers
+func NewEngine(rules []Rule, clients ClientFunc, notifiers ...Notifier) *Engine {
+	e := &Engine{
+		rules:     rules,
+		clients:   clients,
+		notifiers: notifiers,
+		state:     make(map[int]*ruleState, len(rules)),
+		now:       time.Now,
+	}
+	for _, r := range rules {
+		e.state[r.ID] = &ruleState{armed: true}
+	}
+	return e
+}
+
+// RuleError is a failure to evaluate one rule
+type RuleError struct {
+	Rule Rule
+	Err  error
+}
+
+func (e *RuleError) Error() string {
+	return fmt.Sprintf("alert #%d (%s): %v", e.Rule.ID, e.Rule, e.Err)
+}
+
+func (e *RuleError) Unwrap() error {
+	return e.Err
+}
+
+// Check evaluates every rule once, fetching each price once however many rules
+// use it, and notifies about the rules that fire. It returns the events sent
+// and the rules that could not be evaluated or notified.
+func (e *Engine) Check(ctx context.Context) ([]Event, []error) {
+	type market struct{ exchange, symbol string }
+	prices := make(map[market]float64)
+	priceErrs := make(map[market]error)
+
+	var events []Event
+	var errs []error
+	for _, rule := range e.rules {
+		key := market{rule.Exchange, rule.Symbol}
+		if _, ok := prices[key]; !ok && priceErrs[key] == nil {
+			prices[key], priceErrs[key] = e.price(ctx, rule)
+		}
+		if err := priceErrs[key]; err != nil {
+			errs = append(errs, &RuleError{Rule: rule, Err: err})
+			continue
+		}
+
+		event, fired, err := e.evaluate(ctx, rule, prices[key])
+		if err != nil {
+			errs = append(errs, &RuleError{Rule: rule, Err: err})
+			continue
+		}
+		if !fired {
+			continue
+		}
+
+		events = append(events, event)
+		for _, n := range e.notifiers {
+			if err := n.Notify(ctx, event); err != nil {
+				errs = append(errs, &RuleError{Rule: rule, Err: fmt.Errorf("notify: %w", err)})
+			}
+		}
+	}
+
+	return events, errs
+}
+
+// Run checks the rules every interval until ctx is done, passing failures to onError
+func (e *Engine) Run(ctx context.Context, interval time.Duration, onError func(error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		_, errs := e.Check(ctx)
+		for _, err := range errs {
+			if ctx.Err() == nil && onError != nil {
+				onError(err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// price fetches the current price for a rule
+func (e *Engine) price(ctx context.Context, rule Rule) (float64, error) {
+	client, err := e.clients(rule.Exchange)
+	if err != nil {
+		return 0, err
+	}
+	return client.GetPrice(ctx, rule.Symbol)
+}
+
+// evaluate updates the state of rule with the latest price and reports whether it fires
+func (e *Engine) evaluate(ctx context.Context, rule Rule, price float64) (Event, bool, error) {
+	now := e.now()
+	st := e.state[rule.ID]
+	event := Event{Rule: rule, Price: price, Time: now}
+
+	var holds bool
+	switch rule.Condition {
+	case Above:
+		holds = price >= rule.Value
+		event.Reference = rule.Value
+		event.Message = fmt.Sprintf("%s is above %s at %s", rule.Symbol, formatNumber(rule.Value), formatNumber(price))
+
+	case Below:
+		holds = price <= rule.Value
+		event.Reference = rule.Value
+		event.Message = fmt.Sprintf("%s is below %s at %s", rule.Symbol, formatNumber(rule.Value), formatNumber(price))
+
+	case Move:
+		// Keep the samples within the window; the oldest is the starting price
+		cutoff := now.Add(-time.Duration(rule.Window))
+		kept := st.samples[:0]
+		for _, s := range st.samples {
+			if !s.time.Before(cutoff) {
+				kept = append(kept, s)
+			}
+		}
+		st.samples = append(kept, sample{now, price})
+
+		start := st.samples[0].price
+		change := (price - start) / start * 100
+		holds = math.Abs(change) >= rule.Value
+		event.Reference = start
+		event.Message = fmt.Sprintf("%s moved %+.2f%% within %s, from %s to %s",
+			rule.Symbol, change, rule.Window, formatNumber(start), formatNumber(price))
+
+	case CrossEMA:
+		ema, err := e.ema(ctx, rule)
+		if err != nil {
+			return Event{}, false, err
+		}
+
+		side := 1
+		if price < ema {
+			side = -1
+		}
+		holds = st.side != 0 && side != st.side
+		st.side = side
+
+		direction := "above"
+		if side < 0 {
+			direction = "below"
+		}
+		event.Reference = ema
+		event.Message = fmt.Sprintf("%s crossed %s EMA(%d) on %s at %s (EMA %s)",
+			rule.Symbol, direction, int(rule.Value), rule.Interval, formatNumber(price), formatNumber(ema))
+
+	default:
+		return Event{}, false, rule.Validate()
+	}
+
+	if !holds {
+		st.armed = true
+		return Event{}, false, nil
+	}
+	if !st.armed || (!st.lastFired.IsZero() && now.Sub(st.lastFired) < time.Duration(rule.Cooldown)) {
+		return Event{}, false, nil
+	}
+
+	st.armed = false
+	st.lastFired = now
+
+	// A move is measured afresh from the price that fired it
+	if rule.Condition == Move {
+		st.samples = []sample{{now, price}}
+		st.armed = true
+	}
+
+	return event, true, nil
+}
+
+// ema returns the latest EMA for a CrossEMA rule
+func (e *Engine) ema(ctx context.Context, rule Rule) (float64, error) {
+	client, err := e.clients(rule.Exchange)
+	if err != nil {
+		return 0, err
+	}
+
+	period := int(rule.Value)
+
+	// Fetch a few periods more than needed so the seed has worn off
+	limit := min(period*4, client.Capabilities().MaxCandleLimit)
+	candles, err := client.GetCandles(ctx, rule.Symbol, rule.Interval, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	values := indicators.Series(indicators.Closes(candles), indicators.NewEMA(period).Update)
+	if len(values) == 0 || math.IsNaN(values[len(values)-1]) {
+		return 0, fmt.Errorf("not enough %s candles for EMA(%d)", rule.Interval, period)
+	}
+	return values[len(values)-1], nil
+}
+
+// formatNumber formats a price without trailing zeros
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
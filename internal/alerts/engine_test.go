@@ -0,0 +1,247 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+)
+
+// stubClient serves a price and candles set by the test and counts price requests
+type stubClient struct {
+	price      float64
+	closes     []float64
+	priceCalls int
+}
+
+func (s *stubClient) GetPrice(ctx context.Context, symbol string) (float64, error) {
+	s.priceCalls++
+	return s.price, nil
+}
+
+func (s *stubClient) GetTicker(ctx context.Context, symbol string) (*models.Ticker, error) {
+	return &models.Ticker{Symbol: symbol, Price: s.price}, nil
+}
+
+func (s *stubClient) GetPrices(ctx context.Context, symbols []string) []exchange.Result[float64] {
+	return exchange.EachSymbol(ctx, symbols, s.GetPrice)
+}
+
+func (s *stubClient) GetTickers(ctx context.Context, symbols []string) []exchange.Result[*models.Ticker] {
+	return exchange.EachSymbol(ctx, symbols, s.GetTicker)
+}
+
+func (s *stubClient) GetCandles(ctx context.Context, symbol, interval string, limit int) ([]models.Candle, error) {
+	closes := s.closes[max(len(s.closes)-limit, 0):]
+	candles := make([]models.Candle, len(closes))
+	for i, c := range closes {
+		candles[i] = models.Candle{Open: c, High: c, Low: c, Close: c}
+	}
+	return candles, nil
+}
+
+func (s *stubClient) NormalizeSymbol(symbol string) string {
+	return symbol
+}
+
+func (s *stubClient) GetName() string {
+	return "stub"
+}
+
+func (s *stubClient) Capabilities() exchange.Capabilities {
+	return exchange.Capabilities{MaxCandleLimit: 1000}
+}
+
+// fakeClock is a time that the test moves forward
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.t
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.t = c.t.Add(d)
+}
+
+// newTestEngine returns an engine for rules on a stub client, with a fake clock
+func newTestEngine(rules ...Rule) (*Engine, *stubClient, *fakeClock) {
+	client := &stubClient{}
+	clock := &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	engine := NewEngine(rules, func(string) (exchange.Exchange, error) { return client, nil })
+	engine.now = clock.now
+	return engine, client, clock
+}
+
+// step sets the price, checks the rules and returns the IDs of the rules that fired
+func step(t *testing.T, engine *Engine, client *stubClient, price float64) []int {
+	t.Helper()
+
+	client.price = price
+	events, errs := engine.Check(context.Background())
+	for _, err := range errs {
+		t.Fatal(err)
+	}
+
+	ids := make([]int, len(events))
+	for i, e := range events {
+		ids[i] = e.Rule.ID
+	}
+	return ids
+}
+
+func TestEngineLevelFiresOncePerCrossing(t *testing.T) {
+	engine, client, clock := newTestEngine(
+		Rule{ID: 1, Exchange: "stub", Symbol: "BTC/USDT", Condition: Above, Value: 100},
+		Rule{ID: 2, Exchange: "stub", Symbol: "BTC/USDT", Condition: Below, Value: 90},
+	)
+
+	steps := []struct {
+		price float64
+		want  string
+	}{
+		{95, "[]"},
+		{100, "[1]"}, // at the level counts as above
+		{105, "[]"},  // still above: already reported
+		{99, "[]"},   // back under re-arms
+		{101, "[1]"}, // crosses again
+		{80, "[2]"},
+		{85, "[]"},
+	}
+
+	for _, s := range steps {
+		clock.advance(time.Minute)
+		if got := fmt.Sprint(step(t, engine, client, s.price)); got != s.want {
+			t.Errorf("at %v fired %s, want %s", s.price, got, s.want)
+		}
+	}
+
+	// Both rules watch the same market, so each check fetches its price once
+	if client.priceCalls != len(steps) {
+		t.Errorf("price fetched %d times in %d checks", client.priceCalls, len(steps))
+	}
+}
+
+func TestEngineCooldown(t *testing.T) {
+	engine, client, clock := newTestEngine(
+		Rule{ID: 1, Exchange: "stub", Symbol: "BTC/USDT", Condition: Above, Value: 100, Cooldown: Duration(time.Hour)},
+	)
+
+	if got := step(t, engine, client, 101); len(got) != 1 {
+		t.Fatalf("first crossing fired %v", got)
+	}
+
+	// A new crossing within the cooldown is held back
+	clock.advance(10 * time.Minute)
+	step(t, engine, client, 99)
+	clock.advance(10 * time.Minute)
+	if got := step(t, engine, client, 101); len(got) != 0 {
+		t.Errorf("crossing within cooldown fired %v", got)
+	}
+
+	// It fires once the cooldown has passed, as the level still holds
+	clock.advance(time.Hour)
+	if got := step(t, engine, client, 102); len(got) != 1 {
+		t.Errorf("crossing after cooldown fired %v", got)
+	}
+}
+
+func TestEngineMoveWindow(t *testing.T) {
+	engine, client, clock := newTestEngine(
+		Rule{ID: 1, Exchange: "stub", Symbol: "BTC/USDT", Condition: Move, Value: 5, Window: Duration(time.Hour)},
+	)
+
+	steps := []struct {
+		after time.Duration
+		price float64
+		want  int
+	}{
+		{0, 100, 0},
+		{20 * time.Minute, 103, 0},
+		{20 * time.Minute, 104, 0},
+		{30 * time.Minute, 108, 0},   // 100 has left the window; 103 -> 108 is under 5%
+		{10 * time.Minute, 109, 1},   // 103 -> 109
+		{10 * time.Minute, 112, 0},   // measured afresh from 109
+		{10 * time.Minute, 103.5, 1}, // 109 -> 103.5 is a fall of over 5%
+	}
+
+	for _, s := range steps {
+		clock.advance(s.after)
+		if got := step(t, engine, client, s.price); len(got) != s.want {
+			t.Errorf("at %v fired %v, want %d events", s.price, got, s.want)
+		}
+	}
+}
+
+func TestEngineCrossEMA(t *testing.T) {
+	engine, client, clock := newTestEngine(
+		Rule{ID: 1, Exchange: "stub", Symbol: "BTC/USDT", Condition: CrossEMA, Value: 3, Interval: "1h"},
+	)
+	client.closes = []float64{100, 100, 100, 100, 100, 100}
+
+	steps := []struct {
+		price float64
+		want  int
+	}{
+		{105, 0}, // the first check only learns the side
+		{106, 0},
+		{95, 1},
+		{94, 0},
+		{101, 1},
+	}
+
+	for _, s := range steps {
+		clock.advance(time.Hour)
+		client.price = s.price
+		events, errs := engine.Check(context.Background())
+		if len(errs) > 0 {
+			t.Fatal(errs)
+		}
+		if len(events) != s.want {
+			t.Errorf("at %v fired %d events, want %d", s.price, len(events), s.want)
+		}
+		for _, e := range events {
+			if e.Reference != 100 {
+				t.Errorf("EMA = %v, want 100", e.Reference)
+			}
+		}
+	}
+
+	// Too few candles for the period is an error, not a crossing
+	client.closes = client.closes[:2]
+	_, errs := engine.Check(context.Background())
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "not enough 1h candles") {
+		t.Errorf("errs = %v, want not enough candles", errs)
+	}
+}
+
+func TestEngineReportsFailures(t *testing.T) {
+	rule := Rule{ID: 7, Exchange: "nowhere", Symbol: "BTC/USDT", Condition: Above, Value: 1}
+	unavailable := errors.New("no such exchange")
+
+	notifyErr := errors.New("delivery failed")
+	engine := NewEngine([]Rule{rule}, func(string) (exchange.Exchange, error) { return nil, unavailable })
+	if _, errs := engine.Check(context.Background()); len(errs) != 1 || !errors.Is(errs[0], unavailable) {
+		t.Errorf("errs = %v, want the client error", errs)
+	}
+
+	client := &stubClient{price: 2}
+	engine = NewEngine([]Rule{rule}, func(string) (exchange.Exchange, error) { return client, nil },
+		NotifierFunc(func(context.Context, Event) error { return notifyErr }))
+	events, errs := engine.Check(context.Background())
+	if len(events) != 1 {
+		t.Errorf("events = %v, want the rule to fire", events)
+	}
+
+	var ruleErr *RuleError
+	if len(errs) != 1 || !errors.As(errs[0], &ruleErr) || ruleErr.Rule.ID != 7 || !errors.Is(errs[0], notifyErr) {
+		t.Errorf("errs = %v, want the notifier error for rule 7", errs)
+	}
+}
@@ -0,0 +1,125 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// Notifier delivers events, e.g. to the terminal, a desktop notification or a chat webhook
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+// NotifierFunc adapts a function to the Notifier interface
+type NotifierFunc func(ctx context.Context, event Event) error
+
+func (f NotifierFunc) Notify(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+// WriterNotifier writes each event to W as one line of text
+type WriterNotifier struct {
+	W io.Writer
+}
+
+func (n WriterNotifier) Notify(_ context.Context, event Event) error {
+	_, err := fmt.Fprintf(n.W, "%s  #%d  %s\n", event.Time.Format("2006-01-02 15:04:05"), event.Rule.ID, event.Message)
+	return err
+}
+
+// BellNotifier rings the terminal bell on W
+type BellNotifier struct {
+	W io.Writer
+}
+
+func (n BellNotifier) Notify(context.Context, Event) error {
+	_, err := io.WriteString(n.W, "\a")
+	return err
+}
+
+// CommandNotifier runs a shell command for each event. The event is passed as
+// JSON on stdin and as ALERT_ID, ALERT_SYMBOL, ALERT_EXCHANGE, ALERT_CONDITION,
+// ALERT_PRICE and ALERT_MESSAGE environment variables.
+type CommandNotifier struct {
+	Command string
+}
+
+// commandTimeout bounds how long a notification command may run
+const commandTimeout = 30 * time.Second
+
+func (n CommandNotifier) Notify(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", n.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", n.Command)
+	}
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"ALERT_ID="+strconv.Itoa(event.Rule.ID),
+		"ALERT_SYMBOL="+event.Rule.Symbol,
+		"ALERT_EXCHANGE="+event.Rule.Exchange,
+		"ALERT_CONDITION="+string(event.Rule.Condition),
+		"ALERT_PRICE="+formatNumber(event.Price),
+		"ALERT_MESSAGE="+event.Message,
+	)
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("command %q failed: %w", n.Command, err)
+	}
+	return nil
+}
+
+// WebhookNotifier posts each event as JSON to URL
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+func (n WebhookNotifier) Notify(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := n.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testEvent() Event {
+	return Event{
+		Rule:    Rule{ID: 3, Exchange: "binance", Symbol: "BTC/USDT", Condition: Above, Value: 70000},
+		Price:   70123.5,
+		Time:    time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		Message: "BTC/USDT is above 70000 at 70123.5",
+	}
+}
+
+func TestCommandNotifier(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	envFile := filepath.Join(dir, "env")
+	stdinFile := filepath.Join(dir, "stdin")
+
+	n := CommandNotifier{Command: `echo "$ALERT_ID $ALERT_EXCHANGE $ALERT_SYMBOL $ALERT_CONDITION $ALERT_PRICE" > ` + envFile + `; cat > ` + stdinFile}
+	if err := n.Notify(context.Background(), testEvent()); err != nil {
+		t.Fatal(err)
+	}
+
+	env, err := os.ReadFile(envFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.TrimSpace(string(env)), "3 binance BTC/USDT above 70123.5"; got != want {
+		t.Errorf("environment = %q, want %q", got, want)
+	}
+
+	stdin, err := os.ReadFile(stdinFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var event Event
+	if err := json.Unmarshal(stdin, &event); err != nil {
+		t.Fatalf("stdin is not an event: %v\n%s", err, stdin)
+	}
+	if event.Rule.ID != 3 || event.Price != 70123.5 || event.Message != testEvent().Message {
+		t.Errorf("stdin event = %+v", event)
+	}
+
+	failing := CommandNotifier{Command: "exit 3"}
+	if err := failing.Notify(context.Background(), testEvent()); err == nil || !strings.Contains(err.Error(), `command "exit 3" failed`) {
+		t.Errorf("failing command: err = %v", err)
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var body []byte
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		contentType = r.Header.Get("Content-Type")
+		body, _ = io.ReadAll(r.Body)
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	n := WebhookNotifier{URL: server.URL + "/hook", Client: server.Client()}
+	if err := n.Notify(context.Background(), testEvent()); err != nil {
+		t.Fatal(err)
+	}
+	if contentType != "application/json" {
+		t.Errorf("Content-Type = %q", contentType)
+	}
+
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		t.Fatalf("body is not an event: %v\n%s", err, body)
+	}
+	if event.Rule.Symbol != "BTC/USDT" || event.Price != 70123.5 || !event.Time.Equal(testEvent().Time) {
+		t.Errorf("posted event = %+v", event)
+	}
+
+	n.URL = server.URL + "/fail"
+	if err := n.Notify(context.Background(), testEvent()); err == nil || !strings.Contains(err.Error(), "HTTP 502") {
+		t.Errorf("failing webhook: err = %v", err)
+	}
+}
@@ -0,0 +1,259 @@
+// Package backtest replays historical candles through a trading strategy and
+// reports how a simulated account would have fared.
+package backtest
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+)
+
+// Intent is an order a strategy wants placed. It fills at the open of the next
+// candle, so a strategy never trades on a price it could not have known.
+type Intent struct {
+	Side models.Side
+
+	// Fraction is the share of the cash (buys) or of the position (sells) to trade, in (0, 1]
+	Fraction float64
+}
+
+// Account is the state of the simulated account
+type Account struct {
+	Cash     float64
+	Quantity float64
+}
+
+// Strategy decides what to trade as candles close
+type Strategy interface {
+	// Name identifies the strategy and its parameters, e.g. "sma-cross(20,50)"
+	Name() string
+
+	// OnCandle is called with each closed candle, oldest first, and returns the
+	// orders to place at the next open, if any
+	OnCandle(candle models.Candle, account Account) []Intent
+}
+
+// Config sets the account and the cost of trading
+type Config struct {
+	// InitialCash is what the account starts with, in the quote currency
+	InitialCash float64
+
+	// FeeRate is charged on the value of every fill, e.g. 0.001 for 0.1%
+	FeeRate float64
+
+	// Slippage moves every fill against the strategy by this fraction of the price
+	Slippage float64
+
+	// PeriodsPerYear annualizes the Sharpe ratio, e.g. 8760 for hourly candles
+	PeriodsPerYear float64
+}
+
+// Trade is one simulated fill
+type Trade struct {
+	Time     time.Time   `json:"time"`
+	Side     models.Side `json:"side"`
+	Price    float64     `json:"price"`
+	Quantity float64     `json:"quantity"`
+	Value    float64     `json:"value"`
+	Fee      float64     `json:"fee"`
+
+	// PnL is the profit of a sell against the average cost of the position, fees included
+	PnL float64 `json:"pnl"`
+}
+
+// EquityPoint is the account's value at a candle's close
+type EquityPoint struct {
+	Time   time.Time `json:"time"`
+	Price  float64   `json:"price"`
+	Equity float64   `json:"equity"`
+}
+
+// Result summarizes a backtest
+type Result struct {
+	Strategy    string
+	Start, End  time.Time
+	InitialCash float64
+	FinalEquity float64
+
+	// Return and BuyAndHold are the percentage changes of the account and of the price
+	Return     float64
+	BuyAndHold float64
+
+	// MaxDrawdown is the largest fall from a peak in equity, in percent
+	MaxDrawdown float64
+
+	// Sharpe is the annualized Sharpe ratio of per-candle returns, with no risk-free rate
+	Sharpe float64
+
+	Fees   float64
+	Trades []Trade
+	Equity []EquityPoint
+}
+
+// WinRate returns the percentage of sells that made a profit, or NaN without sells
+func (r *Result) WinRate() float64 {
+	sells, wins := 0, 0
+	for _, t := range r.Trades {
+		if t.Side == models.SideSell {
+			sells++
+			if t.PnL > 0 {
+				wins++
+			}
+		}
+	}
+	if sells == 0 {
+		return math.NaN()
+	}
+	return float64(wins) / float64(sells) * 100
+}
+
+// Run replays candles, oldest first, through strategy. Orders the strategy
+// returns for the last candle are not filled.
+func Run(candles []models.Candle, strategy Strategy, cfg Config) (*Result, error) {
+	if len(candles) < 2 {
+		return nil, fmt.Errorf("need at least 2 candles, got %d", len(candles))
+	}
+	if cfg.InitialCash <= 0 {
+		return nil, fmt.Errorf("initial cash must be positive")
+	}
+	if cfg.FeeRate < 0 || cfg.Slippage < 0 {
+		return nil, fmt.Errorf("fee rate and slippage must not be negative")
+	}
+
+	result := &Result{
+		Strategy:    strategy.Name(),
+		Start:       candles[0].Time,
+		End:         candles[len(candles)-1].CloseTime,
+		InitialCash: cfg.InitialCash,
+		Equity:      make([]EquityPoint, 0, len(candles)),
+	}
+
+	account := Account{Cash: cfg.InitialCash}
+	avgCost := 0.0
+	var pending []Intent
+
+	for _, c := range candles {
+		// Fill what the strategy asked for at the previous close
+		for _, intent := range pending {
+			fraction := math.Min(math.Max(intent.Fraction, 0), 1)
+
+			switch intent.Side {
+			case models.SideBuy:
+				price := c.Open * (1 + cfg.Slippage)
+				spend := account.Cash * fraction
+				if spend <= 0 || price <= 0 {
+					continue
+				}
+
+				// Spend includes the fee, so the cash never goes negative
+				fee := spend - spend/(1+cfg.FeeRate)
+				quantity := (spend - fee) / price
+				avgCost = (avgCost*account.Quantity + spend) / (account.Quantity + quantity)
+				account.Cash -= spend
+				account.Quantity += quantity
+
+				result.Fees += fee
+				result.Trades = append(result.Trades, Trade{
+					Time: c.Time, Side: models.SideBuy, Price: price, Quantity: quantity, Value: spend - fee, Fee: fee,
+				})
+
+			case models.SideSell:
+				price := c.Open * (1 - cfg.Slippage)
+				quantity := account.Quantity * fraction
+				if quantity <= 0 {
+					continue
+				}
+
+				value := quantity * price
+				fee := value * cfg.FeeRate
+				account.Cash += value - fee
+				account.Quantity -= quantity
+				if fraction == 1 {
+					account.Quantity = 0
+				}
+
+				result.Fees += fee
+				result.Trades = append(result.Trades, Trade{
+					Time: c.Time, Side: models.SideSell, Price: price, Quantity: quantity, Value: value, Fee: fee,
+					PnL: value - fee - quantity*avgCost,
+				})
+			}
+		}
+
+		result.Equity = append(result.Equity, EquityPoint{
+			Time:   c.CloseTime,
+			Price:  c.Close,
+			Equity: account.Cash + account.Quantity*c.Close,
+		})
+		pending = strategy.OnCandle(c, account)
+	}
+
+	first, last := candles[0], candles[len(candles)-1]
+	result.FinalEquity = result.Equity[len(result.Equity)-1].Equity
+	result.Return = (result.FinalEquity/cfg.InitialCash - 1) * 100
+	if first.Open > 0 {
+		result.BuyAndHold = (last.Close/first.Open - 1) * 100
+	}
+	result.MaxDrawdown = maxDrawdown(result.Equity)
+	result.Sharpe = sharpe(result.Equity, cfg.InitialCash, cfg.PeriodsPerYear)
+
+	return result, nil
+}
+
+// maxDrawdown returns the largest fall from a peak in equity, in percent
+func maxDrawdown(equity []EquityPoint) float64 {
+	peak, worst := 0.0, 0.0
+	for _, p := range equity {
+		peak = math.Max(peak, p.Equity)
+		if peak > 0 {
+			worst = math.Max(worst, (peak-p.Equity)/peak*100)
+		}
+	}
+	return worst
+}
+
+// sharpe returns the mean over the standard deviation of per-period returns,
+// scaled by the square root of periodsPerYear (left unscaled when it is zero)
+func sharpe(equity []EquityPoint, initial float64, periodsPerYear float64) float64 {
+	if len(equity) == 0 {
+		return 0
+	}
+
+	returns := make([]float64, len(equity))
+	prev := initial
+	for i, p := range equity {
+		if prev > 0 {
+			returns[i] = p.Equity/prev - 1
+		}
+		prev = p.Equity
+	}
+
+	mean := 0.0
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+
+	variance := 0.0
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	if len(returns) > 1 {
+		variance /= float64(len(returns) - 1)
+	}
+
+	// Constant returns leave only rounding noise in the deviation, which would
+	// otherwise blow the ratio up to a meaningless huge number
+	std := math.Sqrt(variance)
+	if std < 1e-12 {
+		return 0
+	}
+
+	ratio := mean / std
+	if periodsPerYear > 0 {
+		ratio *= math.Sqrt(periodsPerYear)
+	}
+	return ratio
+}
@@ -0,0 +1,233 @@
+package backtest
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+)
+
+const tolerance = 1e-9
+
+// scripted is a strategy that places fixed orders after given candles
+type scripted struct {
+	orders map[int][]Intent
+	seen   int
+}
+
+func (s *scripted) Name() string {
+	return "scripted"
+}
+
+func (s *scripted) OnCandle(c models.Candle, account Account) []Intent {
+	intents := s.orders[s.seen]
+	s.seen++
+	return intents
+}
+
+// hourly returns hourly candles with the given opens and closes
+func hourly(opens, closes []float64) []models.Candle {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	candles := make([]models.Candle, len(opens))
+	for i := range opens {
+		open, close := opens[i], closes[i]
+		candles[i] = models.Candle{
+			Time:      start.Add(time.Duration(i) * time.Hour),
+			CloseTime: start.Add(time.Duration(i+1)*time.Hour - time.Millisecond),
+			Open:      open,
+			High:      math.Max(open, close),
+			Low:       math.Min(open, close),
+			Close:     close,
+		}
+	}
+	return candles
+}
+
+func TestRunFillsAtNextOpen(t *testing.T) {
+	candles := hourly(
+		[]float64{100, 110, 120, 130},
+		[]float64{105, 115, 125, 135},
+	)
+	strategy := &scripted{orders: map[int][]Intent{
+		0: {{Side: models.SideBuy, Fraction: 1}},
+		1: {{Side: models.SideSell, Fraction: 1}},
+	}}
+
+	result, err := Run(candles, strategy, Config{InitialCash: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Trades) != 2 {
+		t.Fatalf("got %d trades, want 2", len(result.Trades))
+	}
+
+	buy, sell := result.Trades[0], result.Trades[1]
+	if buy.Price != 110 || !buy.Time.Equal(candles[1].Time) {
+		t.Errorf("buy at %v on %v, want the open of candle 1", buy.Price, buy.Time)
+	}
+	if sell.Price != 120 || !sell.Time.Equal(candles[2].Time) {
+		t.Errorf("sell at %v on %v, want the open of candle 2", sell.Price, sell.Time)
+	}
+
+	want := 1000.0 / 110 * 120
+	if math.Abs(result.FinalEquity-want) > tolerance {
+		t.Errorf("final equity = %v, want %v", result.FinalEquity, want)
+	}
+	if math.Abs(sell.PnL-(want-1000)) > tolerance {
+		t.Errorf("pnl = %v, want %v", sell.PnL, want-1000)
+	}
+}
+
+func TestRunIgnoresOrdersOnLastCandle(t *testing.T) {
+	candles := hourly([]float64{100, 110}, []float64{105, 115})
+	strategy := &scripted{orders: map[int][]Intent{
+		1: {{Side: models.SideBuy, Fraction: 1}},
+	}}
+
+	result, err := Run(candles, strategy, Config{InitialCash: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Trades) != 0 || result.FinalEquity != 1000 {
+		t.Errorf("trades = %v, final equity = %v, want no fills", result.Trades, result.FinalEquity)
+	}
+}
+
+func TestRunFeesAndSlippage(t *testing.T) {
+	candles := hourly(
+		[]float64{100, 100, 100},
+		[]float64{100, 100, 100},
+	)
+	strategy := &scripted{orders: map[int][]Intent{
+		0: {{Side: models.SideBuy, Fraction: 1}},
+		1: {{Side: models.SideSell, Fraction: 1}},
+	}}
+
+	cfg := Config{InitialCash: 1000, FeeRate: 0.01, Slippage: 0.02}
+	result, err := Run(candles, strategy, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buy, sell := result.Trades[0], result.Trades[1]
+
+	// The buy pays up by the slippage, and its fee comes out of the cash spent
+	buyFee := 1000 - 1000/1.01
+	quantity := (1000 - buyFee) / 102
+	if buy.Price != 102 || math.Abs(buy.Fee-buyFee) > tolerance || math.Abs(buy.Quantity-quantity) > tolerance {
+		t.Errorf("buy = %+v, want price 102, fee %v, quantity %v", buy, buyFee, quantity)
+	}
+
+	// The sell gives up the slippage, and its fee comes out of the proceeds
+	value := quantity * 98
+	sellFee := value * 0.01
+	if sell.Price != 98 || math.Abs(sell.Fee-sellFee) > tolerance || math.Abs(sell.Value-value) > tolerance {
+		t.Errorf("sell = %+v, want price 98, value %v, fee %v", sell, value, sellFee)
+	}
+
+	if math.Abs(result.Fees-(buyFee+sellFee)) > tolerance {
+		t.Errorf("fees = %v, want %v", result.Fees, buyFee+sellFee)
+	}
+	if want := value - sellFee; math.Abs(result.FinalEquity-want) > tolerance {
+		t.Errorf("final equity = %v, want %v", result.FinalEquity, want)
+	}
+	if sell.PnL >= 0 {
+		t.Errorf("pnl = %v, want a loss from trading costs on a flat price", sell.PnL)
+	}
+}
+
+func TestRunRejectsShortInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		candles []models.Candle
+	}{
+		{"empty", nil},
+		{"one candle", hourly([]float64{100}, []float64{100})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Run(tt.candles, &scripted{}, Config{InitialCash: 1000}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestMaxDrawdown(t *testing.T) {
+	tests := []struct {
+		name   string
+		equity []float64
+		want   float64
+	}{
+		// The 150 -> 90 fall is deeper than the earlier 100 -> 80 one
+		{"deepest fall", []float64{100, 80, 150, 120, 90, 200}, 40},
+		{"only rising", []float64{100, 110, 120}, 0},
+		{"empty", nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := maxDrawdown(equityCurve(tt.equity)); math.Abs(got-tt.want) > tolerance {
+				t.Errorf("maxDrawdown = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSharpe(t *testing.T) {
+	tests := []struct {
+		name           string
+		equity         []float64
+		periodsPerYear float64
+		want           float64
+	}{
+		// Returns of exactly 10% every period have no deviation to divide by
+		{"constant returns", []float64{110, 121, 133.1, 146.41}, 8760, 0},
+		{"flat", []float64{100, 100, 100}, 8760, 0},
+		{"empty", nil, 8760, 0},
+		// Returns +10% and -10%: mean 0
+		{"alternating", []float64{110, 99}, 0, 0},
+		// Returns +10%, 0%, +10%: mean 1/15, sample deviation sqrt(1/300)
+		{"unscaled", []float64{110, 110, 121}, 0, (1.0 / 15) / math.Sqrt(1.0/300)},
+		{"annualized", []float64{110, 110, 121}, 4, (1.0 / 15) / math.Sqrt(1.0/300) * 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sharpe(equityCurve(tt.equity), 100, tt.periodsPerYear)
+			if math.IsNaN(got) || math.IsInf(got, 0) {
+				t.Fatalf("sharpe = %v, want a finite number", got)
+			}
+			if math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("sharpe = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunWithoutTrades(t *testing.T) {
+	candles := hourly([]float64{100, 100, 100}, []float64{100, 100, 100})
+
+	result, err := Run(candles, &scripted{}, Config{InitialCash: 1000, PeriodsPerYear: 8760})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Return != 0 || result.MaxDrawdown != 0 || result.Sharpe != 0 {
+		t.Errorf("return %v, drawdown %v, sharpe %v, want all 0", result.Return, result.MaxDrawdown, result.Sharpe)
+	}
+	if !math.IsNaN(result.WinRate()) {
+		t.Errorf("win rate = %v, want NaN without sells", result.WinRate())
+	}
+}
+
+// equityCurve turns equity values into equity points
+func equityCurve(values []float64) []EquityPoint {
+	points := make([]EquityPoint, len(values))
+	for i, v := range values {
+		points[i] = EquityPoint{Equity: v}
+	}
+	return points
+}
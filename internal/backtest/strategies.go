@@ -0,0 +1,92 @@
+package backtest
+
+import (
+	"fmt"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/indicators"
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+)
+
+// SMACross goes all in when the fast SMA crosses above the slow one and all out
+// when it crosses back below
+type SMACross struct {
+	fast, slow       *indicators.SMA
+	fastLen, slowLen int
+
+	// above is +1 while the fast average is above the slow one, -1 below, 0 before both are ready
+	above int
+}
+
+// NewSMACross returns an SMA crossover strategy
+func NewSMACross(fast, slow int) (*SMACross, error) {
+	if fast <= 0 || slow <= 0 || fast >= slow {
+		return nil, fmt.Errorf("sma-cross needs 0 < fast < slow, got %d and %d", fast, slow)
+	}
+	return &SMACross{fast: indicators.NewSMA(fast), slow: indicators.NewSMA(slow), fastLen: fast, slowLen: slow}, nil
+}
+
+func (s *SMACross) Name() string {
+	return fmt.Sprintf("sma-cross(%d,%d)", s.fastLen, s.slowLen)
+}
+
+func (s *SMACross) OnCandle(c models.Candle, account Account) []Intent {
+	fast, fastOK := s.fast.Update(c.Close)
+	slow, slowOK := s.slow.Update(c.Close)
+	if !fastOK || !slowOK {
+		return nil
+	}
+
+	side := -1
+	if fast > slow {
+		side = 1
+	}
+
+	crossed := s.above != 0 && side != s.above
+	s.above = side
+	if !crossed {
+		return nil
+	}
+
+	if side > 0 && account.Cash > 0 {
+		return []Intent{{Side: models.SideBuy, Fraction: 1}}
+	}
+	if side < 0 && account.Quantity > 0 {
+		return []Intent{{Side: models.SideSell, Fraction: 1}}
+	}
+	return nil
+}
+
+// RSIReversion goes all in when the RSI falls below oversold and all out when
+// it rises above overbought
+type RSIReversion struct {
+	rsi                  *indicators.RSI
+	period               int
+	oversold, overbought float64
+}
+
+// NewRSIReversion returns an RSI mean-reversion strategy
+func NewRSIReversion(period int, oversold, overbought float64) (*RSIReversion, error) {
+	if period <= 0 || oversold <= 0 || overbought >= 100 || oversold >= overbought {
+		return nil, fmt.Errorf("rsi needs a positive period and 0 < oversold < overbought < 100")
+	}
+	return &RSIReversion{rsi: indicators.NewRSI(period), period: period, oversold: oversold, overbought: overbought}, nil
+}
+
+func (s *RSIReversion) Name() string {
+	return fmt.Sprintf("rsi(%d,%g,%g)", s.period, s.oversold, s.overbought)
+}
+
+func (s *RSIReversion) OnCandle(c models.Candle, account Account) []Intent {
+	rsi, ok := s.rsi.Update(c.Close)
+	if !ok {
+		return nil
+	}
+
+	if rsi < s.oversold && account.Cash > 0 && account.Quantity == 0 {
+		return []Intent{{Side: models.SideBuy, Fraction: 1}}
+	}
+	if rsi > s.overbought && account.Quantity > 0 {
+		return []Intent{{Side: models.SideSell, Fraction: 1}}
+	}
+	return nil
+}
@@ -0,0 +1,585 @@
+// Package chart draws candlestick charts with price, volume and time axes as terminal text
+package chart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// axisWidth is the number of columns reserved for the price axis on the right
+const axisWidth = 13
+
+// MaxSlotWidth is the widest column slot a candle can be given
+const MaxSlotWidth = 6
+
+// Options controls how a chart is drawn
+type Options struct {
+	// Width is the total width in columns, including the price axis
+	Width int
+
+	// PriceHeight and VolumeHeight are the rows given to candles and volume bars
+	PriceHeight  int
+	VolumeHeight int
+
+	// SlotWidth is the number of columns each candle occupies (1 to MaxSlotWidth)
+	SlotWidth int
+
+	// Cursor is the index of the candle under the crosshair, or -1 for none
+	Cursor int
+
+	// TimeFormat is the layout of the time axis labels
+	TimeFormat string
+
+	// Overlays are lines drawn on the price scale, such as moving averages
+	Overlays []Series
+
+	// Panel is an optional indicator pane drawn below the volume bars, such as RSI
+	Panel *Panel
+}
+
+// Series is a line of indicator values aligned with the candles; NaN marks
+// candles without a value
+type Series struct {
+	Label  string
+	Values []float64
+	Color  string
+
+	// Bars draws the values as bars from zero instead of a line, e.g. a MACD histogram
+	Bars bool
+}
+
+// Panel is an indicator pane with its own vertical scale
+type Panel struct {
+	Label  string
+	Series []Series
+	Height int
+
+	// Min and Max fix the scale, e.g. 0 and 100 for RSI; if equal the scale fits the data
+	Min, Max float64
+
+	// Levels are reference lines, e.g. 30 and 70 for RSI
+	Levels []float64
+}
+
+// cell styles; series get styleSeries plus their index
+const (
+	styleNone = iota
+	styleUp
+	styleDown
+	styleAxis
+	styleCross
+	styleCrossLabel
+	styleSeries
+)
+
+// newStyles returns the fixed cell styles
+func newStyles() map[int]lipgloss.Style {
+	return map[int]lipgloss.Style{
+		styleUp:         lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF87")),
+		styleDown:       lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0087")),
+		styleAxis:       lipgloss.NewStyle().Foreground(lipgloss.Color("#888888")),
+		styleCross:      lipgloss.NewStyle().Foreground(lipgloss.Color("#666666")),
+		styleCrossLabel: lipgloss.NewStyle().Foreground(lipgloss.Color("#000000")).Background(lipgloss.Color("#FFFF00")),
+	}
+}
+
+// cell is one character of the chart
+type cell struct {
+	r     rune
+	style int
+}
+
+// canvas is a grid of cells
+type canvas [][]cell
+
+func newCanvas(width, height int) canvas {
+	c := make(canvas, height)
+	for y := range c {
+		c[y] = make([]cell, width)
+		for x := range c[y] {
+			c[y][x] = cell{r: ' '}
+		}
+	}
+	return c
+}
+
+// set writes a rune if (x, y) is on the canvas
+func (c canvas) set(x, y int, r rune, style int) {
+	if y >= 0 && y < len(c) && x >= 0 && x < len(c[y]) {
+		c[y][x] = cell{r: r, style: style}
+	}
+}
+
+// text writes s starting at (x, y), clipped to the canvas
+func (c canvas) text(x, y int, s string, style int) {
+	for _, r := range s {
+		c.set(x, y, r, style)
+		x++
+	}
+}
+
+// render turns the canvas into text, styling runs of equally styled cells at once
+func (c canvas) render(styles map[int]lipgloss.Style) string {
+	var sb strings.Builder
+	for y, row := range c {
+		if y > 0 {
+			sb.WriteByte('\n')
+		}
+
+		var run []rune
+		runStyle := styleNone
+		flush := func() {
+			if len(run) == 0 {
+				return
+			}
+			if style, ok := styles[runStyle]; ok {
+				sb.WriteString(style.Render(string(run)))
+			} else {
+				sb.WriteString(string(run))
+			}
+			run = run[:0]
+		}
+
+		for _, cl := range row {
+			if cl.style != runStyle {
+				flush()
+				runStyle = cl.style
+			}
+			run = append(run, cl.r)
+		}
+		flush()
+	}
+	return sb.String()
+}
+
+// PlotWidth returns the number of columns left for candles in a chart of the given width
+func PlotWidth(width int) int {
+	return max(width-axisWidth, 0)
+}
+
+// Capacity returns how many candles fit in a chart of the given width
+func Capacity(width, slotWidth int) int {
+	return PlotWidth(width) / max(slotWidth, 1)
+}
+
+// Render draws the candles, oldest first, as a candlestick chart with volume bars,
+// an optional indicator panel and a time axis below it. Candles that do not fit
+// are dropped from the left, along with their overlay and panel values.
+func Render(candles []models.Candle, opts Options) string {
+	slot := min(max(opts.SlotWidth, 1), MaxSlotWidth)
+	plotWidth := PlotWidth(opts.Width)
+	overlays := opts.Overlays
+	var panel *Panel
+	if opts.Panel != nil {
+		p := *opts.Panel
+		panel = &p
+	}
+	if n := Capacity(opts.Width, slot); len(candles) > n {
+		drop := len(candles) - n
+		opts.Cursor -= drop
+		candles = candles[drop:]
+		overlays = trimSeries(overlays, drop)
+		if panel != nil {
+			panel.Series = trimSeries(panel.Series, drop)
+		}
+	}
+
+	priceHeight := max(opts.PriceHeight, 1)
+	volumeHeight := max(opts.VolumeHeight, 0)
+	panelHeight := 0
+	if panel != nil {
+		panelHeight = max(panel.Height, 2)
+	}
+	c := newCanvas(opts.Width, priceHeight+volumeHeight+panelHeight+1)
+
+	styles := newStyles()
+	seriesStyle := func(i int, s Series) int {
+		styles[styleSeries+i] = lipgloss.NewStyle().Foreground(lipgloss.Color(s.Color))
+		return styleSeries + i
+	}
+
+	if len(candles) == 0 || plotWidth == 0 {
+		return c.render(styles)
+	}
+
+	low, high := priceRange(candles, overlays)
+	scale := newScale(low, high, priceHeight)
+
+	drawCrosshair(c, candles, opts.Cursor, slot, scale)
+	for i, s := range overlays {
+		drawLine(c, s.Values, 0, slot, scale, seriesStyle(i, s))
+	}
+	for i, candle := range candles {
+		drawCandle(c, candle, column(i, slot), scale)
+	}
+	drawPriceAxis(c, plotWidth, scale)
+	if opts.Cursor >= 0 && opts.Cursor < len(candles) {
+		row := scale.row(candles[opts.Cursor].Close)
+		c.text(plotWidth+2, row, padRight(formatAxisPrice(candles[opts.Cursor].Close, scale), axisWidth-2), styleCrossLabel)
+	}
+
+	if volumeHeight > 0 {
+		drawVolume(c, candles, priceHeight, volumeHeight, slot, plotWidth)
+	}
+	if panel != nil {
+		top := priceHeight + volumeHeight
+		for i := range panel.Series {
+			seriesStyle(len(overlays)+i, panel.Series[i])
+		}
+		drawPanel(c, panel, top, panelHeight, slot, plotWidth, styleSeries+len(overlays))
+	}
+	drawTimeAxis(c, candles, priceHeight+volumeHeight+panelHeight, slot, plotWidth, opts.TimeFormat)
+
+	return c.render(styles)
+}
+
+// trimSeries drops the first n values of every series
+func trimSeries(series []Series, n int) []Series {
+	trimmed := make([]Series, len(series))
+	for i, s := range series {
+		trimmed[i] = s
+		trimmed[i].Values = s.Values[min(n, len(s.Values)):]
+	}
+	return trimmed
+}
+
+// priceRange returns the lowest and highest price among the candles and overlays
+func priceRange(candles []models.Candle, overlays []Series) (float64, float64) {
+	low, high := candles[0].Low, candles[0].High
+	for _, candle := range candles[1:] {
+		low = math.Min(low, candle.Low)
+		high = math.Max(high, candle.High)
+	}
+	for _, s := range overlays {
+		for i := 0; i < len(s.Values) && i < len(candles); i++ {
+			if !math.IsNaN(s.Values[i]) {
+				low = math.Min(low, s.Values[i])
+				high = math.Max(high, s.Values[i])
+			}
+		}
+	}
+	return low, high
+}
+
+// column returns the x position of the i-th candle, centred in its slot
+func column(i, slot int) int {
+	return i*slot + (slot-1)/2
+}
+
+// scale maps prices onto chart rows. Every row is split into a lower and an
+// upper half, which doubles the vertical resolution of wicks and bodies.
+type scale struct {
+	low, high float64
+	rows      int
+}
+
+func newScale(low, high float64, rows int) scale {
+	if high <= low {
+		// A flat range still needs some height to be drawn
+		pad := math.Max(math.Abs(low)*0.001, 1e-8)
+		low -= pad
+		high += pad
+	}
+	return scale{low: low, high: high, rows: rows}
+}
+
+// pos returns the height of price above the bottom of the chart, in rows
+func (s scale) pos(price float64) float64 {
+	return (price - s.low) / (s.high - s.low) * float64(s.rows)
+}
+
+// row returns the canvas row (0 at the top) that holds price
+func (s scale) row(price float64) int {
+	fromBottom := min(max(int(s.pos(price)), 0), s.rows-1)
+	return s.rows - 1 - fromBottom
+}
+
+// price returns the price at the centre of a canvas row
+func (s scale) price(row int) float64 {
+	fromBottom := float64(s.rows - 1 - row)
+	return s.low + (fromBottom+0.5)/float64(s.rows)*(s.high-s.low)
+}
+
+// half-row states of a candle
+const (
+	halfEmpty = iota
+	halfWick
+	halfBody
+)
+
+// candleGlyphs maps the (upper, lower) half-row states of a cell to a glyph
+var candleGlyphs = map[[2]int]rune{
+	{halfBody, halfBody}:   '┃',
+	{halfWick, halfWick}:   '│',
+	{halfBody, halfWick}:   '╿',
+	{halfWick, halfBody}:   '╽',
+	{halfBody, halfEmpty}:  '╹',
+	{halfEmpty, halfBody}:  '╻',
+	{halfWick, halfEmpty}:  '╵',
+	{halfEmpty, halfWick}:  '╷',
+	{halfEmpty, halfEmpty}: ' ',
+}
+
+// drawCandle draws one candle's wick and body in column x
+func drawCandle(c canvas, candle models.Candle, x int, s scale) {
+	style := styleUp
+	if candle.Close < candle.Open {
+		style = styleDown
+	}
+
+	bodyLow := s.pos(math.Min(candle.Open, candle.Close))
+	bodyHigh := s.pos(math.Max(candle.Open, candle.Close))
+	wickLow := s.pos(candle.Low)
+	wickHigh := s.pos(candle.High)
+
+	// A half is filled when its centre lies within the wick or body
+	halves := make([]int, 2*s.rows)
+	bodyDrawn := false
+	for h := range halves {
+		centre := (float64(h) + 0.5) / 2
+		switch {
+		case centre >= bodyLow && centre <= bodyHigh:
+			halves[h] = halfBody
+			bodyDrawn = true
+		case centre >= wickLow && centre <= wickHigh:
+			halves[h] = halfWick
+		}
+	}
+
+	// Bodies thinner than half a row are still shown
+	if !bodyDrawn {
+		mid := (bodyLow + bodyHigh) / 2
+		h := min(max(int(mid*2), 0), len(halves)-1)
+		halves[h] = halfBody
+	}
+
+	for fromBottom := 0; fromBottom < s.rows; fromBottom++ {
+		glyph := candleGlyphs[[2]int{halves[2*fromBottom+1], halves[2*fromBottom]}]
+		if glyph != ' ' {
+			c.set(x, s.rows-1-fromBottom, glyph, style)
+		}
+	}
+}
+
+// drawCrosshair draws a vertical line through the selected candle and a
+// horizontal line at its close
+func drawCrosshair(c canvas, candles []models.Candle, cursor, slot int, s scale) {
+	if cursor < 0 || cursor >= len(candles) {
+		return
+	}
+
+	x := column(cursor, slot)
+	for y := range len(c) - 1 {
+		c.set(x, y, '┊', styleCross)
+	}
+
+	y := s.row(candles[cursor].Close)
+	for x := range PlotWidth(len(c[0])) {
+		c.set(x, y, '┈', styleCross)
+	}
+}
+
+// drawPriceAxis draws the axis line and a price label every few rows
+func drawPriceAxis(c canvas, plotWidth int, s scale) {
+	const labelEvery = 4
+
+	for y := 0; y < s.rows; y++ {
+		// The bottom row is labelled too, unless the previous label is right above it
+		if y%labelEvery == 0 || (y == s.rows-1 && y%labelEvery >= 2) {
+			c.set(plotWidth, y, '┤', styleAxis)
+			c.text(plotWidth+2, y, formatAxisPrice(s.price(y), s), styleAxis)
+		} else {
+			c.set(plotWidth, y, '│', styleAxis)
+		}
+	}
+}
+
+// drawLine plots a series as dots, interpolating across the gaps between candle
+// columns. Candles and other lines already drawn are not overwritten.
+func drawLine(c canvas, values []float64, top, slot int, s scale, style int) {
+	plot := func(x int, v float64, glyph rune) {
+		y := top + s.row(v)
+		if y < len(c) && x < len(c[y]) && (c[y][x].r == ' ' || c[y][x].style == styleCross) {
+			c.set(x, y, glyph, style)
+		}
+	}
+
+	for i, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		x := column(i, slot)
+		plot(x, v, '•')
+
+		if i+1 < len(values) && !math.IsNaN(values[i+1]) {
+			next := values[i+1]
+			for dx := 1; dx < slot; dx++ {
+				plot(x+dx, v+(next-v)*float64(dx)/float64(slot), '·')
+			}
+		}
+	}
+}
+
+// drawPanel draws an indicator pane with its own scale, reference levels and labels
+func drawPanel(c canvas, p *Panel, top, height, slot, plotWidth, firstStyle int) {
+	low, high := p.Min, p.Max
+	if low == high {
+		low, high = math.Inf(1), math.Inf(-1)
+		for _, s := range p.Series {
+			for _, v := range s.Values {
+				if !math.IsNaN(v) {
+					low = math.Min(low, v)
+					high = math.Max(high, v)
+				}
+			}
+			if s.Bars {
+				// Bars grow from zero, so zero must be on the scale
+				low = math.Min(low, 0)
+				high = math.Max(high, 0)
+			}
+		}
+		if math.IsInf(low, 0) {
+			low, high = 0, 1
+		}
+	}
+	s := newScale(low, high, height)
+
+	for _, level := range p.Levels {
+		y := top + s.row(level)
+		for x := range plotWidth {
+			if c[y][x].r == ' ' || c[y][x].style == styleCross {
+				c.set(x, y, '┈', styleAxis)
+			}
+		}
+	}
+
+	for i, series := range p.Series {
+		style := firstStyle + i
+		if !series.Bars {
+			drawLine(c, series.Values, top, slot, s, style)
+			continue
+		}
+
+		zero := top + s.row(0)
+		for j, v := range series.Values {
+			if math.IsNaN(v) {
+				continue
+			}
+			barStyle := styleUp
+			if v < 0 {
+				barStyle = styleDown
+			}
+			x := column(j, slot)
+			y := top + s.row(v)
+			for row := min(y, zero); row <= max(y, zero); row++ {
+				c.set(x, row, '│', barStyle)
+			}
+		}
+	}
+
+	for y := top; y < top+height; y++ {
+		c.set(plotWidth, y, '│', styleAxis)
+	}
+	c.set(plotWidth, top, '┤', styleAxis)
+	c.text(plotWidth+2, top, formatAxisPrice(high, s), styleAxis)
+	c.set(plotWidth, top+height-1, '┤', styleAxis)
+	c.text(plotWidth+2, top+height-1, formatAxisPrice(low, s), styleAxis)
+	c.text(0, top, p.Label, styleAxis)
+}
+
+// volumeGlyphs are the bar heights in eighths of a row
+var volumeGlyphs = []rune{' ', '▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+
+// drawVolume draws volume bars scaled to the largest visible volume
+func drawVolume(c canvas, candles []models.Candle, top, height, slot, plotWidth int) {
+	maxVolume := 0.0
+	for _, candle := range candles {
+		maxVolume = math.Max(maxVolume, candle.Volume)
+	}
+	if maxVolume == 0 {
+		return
+	}
+
+	for i, candle := range candles {
+		style := styleUp
+		if candle.Close < candle.Open {
+			style = styleDown
+		}
+
+		eighths := int(math.Round(candle.Volume / maxVolume * float64(height*8)))
+		if eighths == 0 && candle.Volume > 0 {
+			eighths = 1
+		}
+
+		x := column(i, slot)
+		for fromBottom := 0; fromBottom < height; fromBottom++ {
+			fill := min(max(eighths-fromBottom*8, 0), 8)
+			if fill > 0 {
+				c.set(x, top+height-1-fromBottom, volumeGlyphs[fill], style)
+			}
+		}
+	}
+
+	for y := top; y < top+height; y++ {
+		c.set(plotWidth, y, '│', styleAxis)
+	}
+	c.set(plotWidth, top, '┤', styleAxis)
+	c.text(plotWidth+2, top, "Vol "+formatVolume(maxVolume), styleAxis)
+}
+
+// drawTimeAxis labels candles along the bottom row, as often as the labels fit
+func drawTimeAxis(c canvas, candles []models.Candle, y, slot, plotWidth int, layout string) {
+	if layout == "" {
+		layout = "15:04"
+	}
+
+	labelWidth := len(candles[0].Time.Format(layout))
+	every := max((labelWidth+2+slot-1)/slot, 1)
+
+	for i := 0; i < len(candles); i += every {
+		x := column(i, slot)
+		if x+labelWidth > plotWidth {
+			break
+		}
+		c.text(x, y, candles[i].Time.Format(layout), styleAxis)
+	}
+}
+
+// formatAxisPrice formats a price with enough decimals to tell rows apart
+func formatAxisPrice(price float64, s scale) string {
+	step := (s.high - s.low) / float64(s.rows)
+	decimals := 2
+	if step > 0 {
+		decimals = min(max(int(math.Ceil(-math.Log10(step)))+1, 0), 8)
+	}
+	if price >= 1 && decimals < 2 {
+		decimals = 2
+	}
+	return fmt.Sprintf("%.*f", decimals, price)
+}
+
+// formatVolume formats a volume compactly, e.g. 1.25K or 3.40M
+func formatVolume(volume float64) string {
+	switch {
+	case volume >= 1e9:
+		return fmt.Sprintf("%.2fB", volume/1e9)
+	case volume >= 1e6:
+		return fmt.Sprintf("%.2fM", volume/1e6)
+	case volume >= 1e3:
+		return fmt.Sprintf("%.2fK", volume/1e3)
+	default:
+		return fmt.Sprintf("%.2f", volume)
+	}
+}
+
+// padRight pads s with spaces to width
+func padRight(s string, width int) string {
+	if len(s) >= width {
+		return s
+	}
+	return s + strings.Repeat(" ", width-len(s))
+}
@@ -0,0 +1,30 @@
+package chart
+
+import "strings"
+
+// Sparkline draws values as a single row, one glyph per value, scaled between
+// the lowest and the highest. A flat series is drawn at mid height.
+func Sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	// The blank volume glyph is left out so that every value shows
+	glyphs := volumeGlyphs[1:]
+
+	low, high := values[0], values[0]
+	for _, v := range values {
+		low = min(low, v)
+		high = max(high, v)
+	}
+
+	var s strings.Builder
+	for _, v := range values {
+		level := len(glyphs) / 2
+		if high > low {
+			level = int((v - low) / (high - low) * float64(len(glyphs)-1))
+		}
+		s.WriteRune(glyphs[level])
+	}
+	return s.String()
+}
@@ -4,15 +4,21 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
+// dirName is the directory under the user's home that holds config and local data
+const dirName = ".terminalcrypto"
+
 type Config struct {
 	Exchange        string          `mapstructure:"exchange"`
 	Exchanges       map[string]bool `mapstructure:"exchanges"`
 	RefreshInterval int             `mapstructure:"refresh_interval"`
 	Display         DisplayConfig   `mapstructure:"display"`
+	Trades          TradesConfig    `mapstructure:"trades"`
+	Markets         MarketsConfig   `mapstructure:"markets"`
 }
 
 type DisplayConfig struct {
@@ -20,6 +26,14 @@ type DisplayConfig struct {
 	DecimalPlaces int    `mapstructure:"decimal_places"`
 }
 
+type TradesConfig struct {
+	LargeNotional float64 `mapstructure:"large_notional"`
+}
+
+type MarketsConfig struct {
+	CacheTTL time.Duration `mapstructure:"cache_ttl"`
+}
+
 // InitConfig initializes the configuration
 func InitConfig() error {
 	home, err := os.UserHomeDir()
@@ -27,7 +41,7 @@ func InitConfig() error {
 		return fmt.Errorf("failed to get home directory: %w", err)
 	}
 
-	configDir := filepath.Join(home, ".terminalcrypto")
+	configDir := filepath.Join(home, dirName)
 	configPath := filepath.Join(configDir, "config.yaml")
 
 	// Create config directory if it doesn't exist
@@ -53,6 +67,17 @@ func InitConfig() error {
 	viper.SetDefault("refresh_interval", 5)
 	viper.SetDefault("display.currency", "USDT")
 	viper.SetDefault("display.decimal_places", 2)
+	viper.SetDefault("trades.large_notional", 100000)
+	viper.SetDefault("markets.cache_ttl", "24h")
+	viper.SetDefault("store.enabled", true)
+	viper.SetDefault("store.retention", "720h")
+	viper.SetDefault("paper.fee_rate", 0.001)
+	viper.SetDefault("paper.starting_balance", 10000)
+	viper.SetDefault("composite.exchanges", []string{"binance", "coinbase", "okx"})
+	viper.SetDefault("composite.method", "median")
+	viper.SetDefault("composite.max_deviation", 0.02)
+	viper.SetDefault("composite.max_age", "30s")
+	viper.SetDefault("watch.columns", []string{"spark"})
 
 	// Try to read existing config
 	if err := viper.ReadInConfig(); err != nil {
@@ -99,3 +124,72 @@ func GetExchange() string {
 func IsExchangeEnabled(exchange string) bool {
 	return viper.GetBool("exchanges." + exchange)
 }
+
+// GetLargeTradeNotional returns the quote value above which trades are highlighted
+func GetLargeTradeNotional() float64 {
+	return viper.GetFloat64("trades.large_notional")
+}
+
+// Dir returns the directory that holds the config file and local data (~/.terminalcrypto)
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, dirName), nil
+}
+
+// GetMarketsCacheTTL returns how long a cached market catalog stays fresh
+func GetMarketsCacheTTL() time.Duration {
+	return viper.GetDuration("markets.cache_ttl")
+}
+
+// GetDisplayCurrency returns the currency that values are shown in, e.g. USDT
+func GetDisplayCurrency() string {
+	return viper.GetString("display.currency")
+}
+
+// GetPaperFeeRate returns the fee charged on paper fills, as a fraction of their value
+func GetPaperFeeRate() float64 {
+	return viper.GetFloat64("paper.fee_rate")
+}
+
+// GetPaperStartingBalance returns the amount of quote currency a new paper account starts with
+func GetPaperStartingBalance() float64 {
+	return viper.GetFloat64("paper.starting_balance")
+}
+
+// GetStoreEnabled reports whether market data is kept in the local store
+func GetStoreEnabled() bool {
+	return viper.GetBool("store.enabled")
+}
+
+// GetStoreRetention returns how long stored tickers and prices are kept; zero keeps them forever
+func GetStoreRetention() time.Duration {
+	return viper.GetDuration("store.retention")
+}
+
+// GetCompositeExchanges returns the exchanges the composite exchange combines prices from
+func GetCompositeExchanges() []string {
+	return viper.GetStringSlice("composite.exchanges")
+}
+
+// GetCompositeMethod returns how the composite exchange combines prices: median or vwap
+func GetCompositeMethod() string {
+	return viper.GetString("composite.method")
+}
+
+// GetCompositeMaxDeviation returns the fraction from the median beyond which a composite source is dropped
+func GetCompositeMaxDeviation() float64 {
+	return viper.GetFloat64("composite.max_deviation")
+}
+
+// GetCompositeMaxAge returns how old or slow a composite source may be before it is dropped
+func GetCompositeMaxAge() time.Duration {
+	return viper.GetDuration("composite.max_age")
+}
+
+// GetWatchColumns returns the columns the watch table shows next to each price
+func GetWatchColumns() []string {
+	return viper.GetStringSlice("watch.columns")
+}
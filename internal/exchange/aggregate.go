@@ -0,0 +1,451 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+)
+
+// AggregateMethod is how an AggregateExchange combines its sources' prices
+type AggregateMethod string
+
+const (
+	// AggregateMedian takes the median of the sources' prices
+	AggregateMedian AggregateMethod = "median"
+
+	// AggregateVWAP weights each source's price by its 24h volume
+	AggregateVWAP AggregateMethod = "vwap"
+)
+
+// usdStablecoins are quote currencies pegged to the US dollar
+var usdStablecoins = []string{"USDT", "USDC", "FDUSD", "TUSD", "BUSD", "DAI"}
+
+// IsUSDStablecoin reports whether currency is a stablecoin pegged to the US dollar
+func IsUSDStablecoin(currency string) bool {
+	for _, c := range usdStablecoins {
+		if strings.EqualFold(c, currency) {
+			return true
+		}
+	}
+	return false
+}
+
+// AggregateOptions configures an AggregateExchange
+type AggregateOptions struct {
+	Method AggregateMethod
+
+	// MaxDeviation drops a source whose price is further than this fraction
+	// from the median, e.g. 0.02 for 2%; zero keeps every source
+	MaxDeviation float64
+
+	// MaxAge drops a source whose ticker is older than this or that does not
+	// answer a request within it; zero waits for every source
+	MaxAge time.Duration
+}
+
+// SourcePrice is one source's part in an aggregate price
+type SourcePrice struct {
+	Exchange string    `json:"exchange"`
+	Symbol   string    `json:"symbol"`
+	Price    float64   `json:"price,omitzero"`
+	Volume   float64   `json:"volume,omitzero"`
+	Time     time.Time `json:"time,omitzero"`
+
+	// Dropped says why the source was left out, and is empty when it contributed
+	Dropped string `json:"dropped,omitempty"`
+}
+
+// Aggregate is a price combined from several sources
+type Aggregate struct {
+	Symbol  string
+	Price   float64
+	Method  AggregateMethod
+	Sources []SourcePrice
+	Time    time.Time
+}
+
+// Contributors returns the names of the sources the price was combined from
+func (a *Aggregate) Contributors() []string {
+	var names []string
+	for _, s := range a.Sources {
+		if s.Dropped == "" {
+			names = append(names, s.Exchange)
+		}
+	}
+	return names
+}
+
+// AggregateExchange is a composite "index" exchange: it asks every source for
+// its ticker concurrently and combines the prices by median or by volume,
+// after dropping sources that failed, are stale or stray too far from the
+// median. Symbols are priced in USD, taking USD stablecoin quotes at par, so
+// "BTC" is BTC/USDT on Binance and BTC-USD on Coinbase. Candles come from the
+// first source that serves them rather than being combined.
+type AggregateExchange struct {
+	sources []Exchange
+	opts    AggregateOptions
+
+	mu   sync.Mutex
+	last map[string]*Aggregate
+}
+
+// NewAggregateExchange returns an exchange that combines the prices of sources
+func NewAggregateExchange(sources []Exchange, opts AggregateOptions) (*AggregateExchange, error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("composite exchange needs at least one source")
+	}
+	if opts.Method != AggregateMedian && opts.Method != AggregateVWAP {
+		return nil, fmt.Errorf("unknown aggregation method %q (available: %s, %s)", opts.Method, AggregateMedian, AggregateVWAP)
+	}
+	if opts.MaxDeviation < 0 || opts.MaxAge < 0 {
+		return nil, fmt.Errorf("composite max deviation and max age must not be negative")
+	}
+
+	return &AggregateExchange{sources: sources, opts: opts, last: make(map[string]*Aggregate)}, nil
+}
+
+// GetName returns "composite"
+func (a *AggregateExchange) GetName() string {
+	return "composite"
+}
+
+// Sources returns the exchanges prices are combined from
+func (a *AggregateExchange) Sources() []Exchange {
+	return a.sources
+}
+
+// Capabilities returns the candle intervals of the first source; prices and
+// tickers are all a composite exchange provides itself, in batches when every
+// source has a batch endpoint
+func (a *AggregateExchange) Capabilities() Capabilities {
+	caps := a.sources[0].Capabilities()
+	batch := true
+	for _, source := range a.sources {
+		batch = batch && source.Capabilities().Batch
+	}
+
+	return Capabilities{
+		Intervals:      caps.Intervals,
+		MaxCandleLimit: caps.MaxCandleLimit,
+		Batch:          batch,
+	}
+}
+
+// NormalizeSymbol converts a symbol like "BTC", "BTC-USDT" or "BTCUSDT" to "BTC/USD"
+func (a *AggregateExchange) NormalizeSymbol(symbol string) string {
+	normalized := strings.ToUpper(symbol)
+	normalized = strings.ReplaceAll(normalized, "-", "/")
+	normalized = strings.ReplaceAll(normalized, "_", "/")
+
+	base, quote, ok := strings.Cut(normalized, "/")
+	if !ok {
+		// Split concatenated USD pairs such as "BTCUSDT", but leave a stablecoin
+		// on its own alone, so that "BUSD" is BUSD/USD rather than B/USD
+		base, quote = normalized, "USD"
+		if !IsUSDStablecoin(normalized) {
+			for _, q := range append([]string{"USD"}, usdStablecoins...) {
+				if len(normalized) > len(q) && strings.HasSuffix(normalized, q) {
+					base = strings.TrimSuffix(normalized, q)
+				}
+			}
+		}
+	}
+
+	if IsUSDStablecoin(quote) {
+		quote = "USD"
+	}
+	return base + "/" + quote
+}
+
+// sourceSymbol returns the symbol to ask the sources for. USD pairs are asked
+// for by their base alone, which every source quotes in its own USD currency.
+func (a *AggregateExchange) sourceSymbol(symbol string) string {
+	normalized := a.NormalizeSymbol(symbol)
+	if base, ok := strings.CutSuffix(normalized, "/USD"); ok {
+		return base
+	}
+	return normalized
+}
+
+// GetPrice returns the aggregate price for a symbol
+func (a *AggregateExchange) GetPrice(ctx context.Context, symbol string) (float64, error) {
+	agg, _, err := a.aggregate(ctx, symbol)
+	if err != nil {
+		return 0, err
+	}
+	return agg.Price, nil
+}
+
+// GetAggregate returns the aggregate price for a symbol with every source's part in it
+func (a *AggregateExchange) GetAggregate(ctx context.Context, symbol string) (*Aggregate, error) {
+	agg, _, err := a.aggregate(ctx, symbol)
+	return agg, err
+}
+
+// LastAggregate returns the most recent aggregate price computed for a symbol
+func (a *AggregateExchange) LastAggregate(symbol string) (*Aggregate, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	agg, ok := a.last[a.NormalizeSymbol(symbol)]
+	return agg, ok
+}
+
+// GetTicker returns a ticker combined from the contributing sources: the
+// aggregate price, the median change, high and low, and the total volume
+func (a *AggregateExchange) GetTicker(ctx context.Context, symbol string) (*models.Ticker, error) {
+	agg, tickers, err := a.aggregate(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	return combineTickers(agg, tickers), nil
+}
+
+// GetPrices returns aggregate prices for several symbols, asking each source
+// for all of them at once
+func (a *AggregateExchange) GetPrices(ctx context.Context, symbols []string) []Result[float64] {
+	results := make([]Result[float64], len(symbols))
+	for i, r := range a.aggregateAll(ctx, symbols) {
+		if results[i].Err = r.err; r.err == nil {
+			results[i].Value = r.agg.Price
+		}
+	}
+	return results
+}
+
+// GetTickers returns combined tickers for several symbols, asking each source
+// for all of them at once
+func (a *AggregateExchange) GetTickers(ctx context.Context, symbols []string) []Result[*models.Ticker] {
+	results := make([]Result[*models.Ticker], len(symbols))
+	for i, r := range a.aggregateAll(ctx, symbols) {
+		if results[i].Err = r.err; r.err == nil {
+			results[i].Value = combineTickers(r.agg, r.tickers)
+		}
+	}
+	return results
+}
+
+// combineTickers combines the tickers of the sources that contributed to agg
+func combineTickers(agg *Aggregate, tickers []*models.Ticker) *models.Ticker {
+	var changes, highs, lows []float64
+	ticker := &models.Ticker{Symbol: agg.Symbol, Price: agg.Price, LastUpdated: agg.Time}
+	for i, s := range agg.Sources {
+		if s.Dropped != "" {
+			continue
+		}
+		changes = append(changes, tickers[i].Change24h)
+		highs = append(highs, tickers[i].High24h)
+		lows = append(lows, tickers[i].Low24h)
+		ticker.Volume24h += tickers[i].Volume24h
+	}
+
+	ticker.Change24h = median(changes)
+	ticker.High24h = median(highs)
+	ticker.Low24h = median(lows)
+	return ticker
+}
+
+// GetCandles returns the candles of the first source that serves them
+func (a *AggregateExchange) GetCandles(ctx context.Context, symbol, interval string, limit int) ([]models.Candle, error) {
+	var firstErr error
+	for _, source := range a.sources {
+		candles, err := source.GetCandles(ctx, a.sourceSymbol(symbol), interval, limit)
+		if err == nil {
+			return candles, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return nil, firstErr
+}
+
+// aggregation is an aggregate price with the source tickers it was combined
+// from, in the order of the sources and nil for a source that failed
+type aggregation struct {
+	agg     *Aggregate
+	tickers []*models.Ticker
+	err     error
+}
+
+// aggregate fetches every source's ticker for a symbol and combines the prices
+func (a *AggregateExchange) aggregate(ctx context.Context, symbol string) (*Aggregate, []*models.Ticker, error) {
+	r := a.aggregateAll(ctx, []string{symbol})[0]
+	return r.agg, r.tickers, r.err
+}
+
+// aggregateAll asks every source for the tickers of all symbols concurrently
+// and combines each symbol's prices
+func (a *AggregateExchange) aggregateAll(ctx context.Context, symbols []string) []aggregation {
+	queries := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		queries[i] = a.sourceSymbol(symbol)
+	}
+
+	start := time.Now()
+	bySource := make([][]Result[*models.Ticker], len(a.sources))
+	var wg sync.WaitGroup
+	for i, source := range a.sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bySource[i] = a.fetchTickers(ctx, source, queries)
+		}()
+	}
+	wg.Wait()
+
+	results := make([]aggregation, len(symbols))
+	for j, symbol := range symbols {
+		tickers := make([]*models.Ticker, len(a.sources))
+		errs := make([]error, len(a.sources))
+		for i := range a.sources {
+			tickers[i], errs[i] = bySource[i][j].Value, bySource[i][j].Err
+		}
+
+		agg, err := a.combine(ctx, symbol, queries[j], start, tickers, errs)
+		if err != nil {
+			results[j] = aggregation{err: err}
+		} else {
+			results[j] = aggregation{agg: agg, tickers: tickers}
+		}
+	}
+	return results
+}
+
+// fetchTickers asks a source for the tickers of queries. MaxAge bounds each
+// request rather than the whole fetch: a source with a batch endpoint answers in
+// one request, while any other is asked symbol by symbol with a deadline each,
+// so that a long watchlist is not mistaken for a source that does not answer.
+func (a *AggregateExchange) fetchTickers(ctx context.Context, source Exchange, queries []string) []Result[*models.Ticker] {
+	if a.opts.MaxAge <= 0 {
+		return source.GetTickers(ctx, queries)
+	}
+
+	if source.Capabilities().Batch {
+		ctx, cancel := context.WithTimeout(ctx, a.opts.MaxAge)
+		defer cancel()
+		return source.GetTickers(ctx, queries)
+	}
+
+	return EachSymbol(ctx, queries, func(ctx context.Context, symbol string) (*models.Ticker, error) {
+		ctx, cancel := context.WithTimeout(ctx, a.opts.MaxAge)
+		defer cancel()
+		return source.GetTicker(ctx, symbol)
+	})
+}
+
+// combine drops the sources that failed, are stale or stray from the median and
+// combines the prices of the rest. start is when the tickers were asked for.
+func (a *AggregateExchange) combine(ctx context.Context, symbol, query string, start time.Time, tickers []*models.Ticker, errs []error) (*Aggregate, error) {
+	agg := &Aggregate{Symbol: a.NormalizeSymbol(symbol), Method: a.opts.Method, Sources: make([]SourcePrice, len(a.sources))}
+
+	var fresh []float64
+	for i, source := range a.sources {
+		s := SourcePrice{Exchange: source.GetName(), Symbol: source.NormalizeSymbol(query)}
+
+		switch t := tickers[i]; {
+		case errs[i] != nil && errors.Is(errs[i], context.DeadlineExceeded) && ctx.Err() == nil:
+			s.Dropped = fmt.Sprintf("no answer within %s", a.opts.MaxAge)
+		case errs[i] != nil:
+			s.Dropped = errs[i].Error()
+		case t.Price <= 0:
+			s.Dropped = "no price"
+		case a.opts.MaxAge > 0 && start.Sub(t.LastUpdated) > a.opts.MaxAge:
+			s.Price, s.Volume, s.Time = t.Price, t.Volume24h, t.LastUpdated
+			s.Dropped = fmt.Sprintf("stale, last updated %s ago", start.Sub(t.LastUpdated).Round(time.Second))
+		default:
+			s.Price, s.Volume, s.Time = t.Price, t.Volume24h, t.LastUpdated
+			fresh = append(fresh, t.Price)
+		}
+		agg.Sources[i] = s
+	}
+
+	// With fewer than three prices there is no telling which one is off
+	if mid := median(fresh); a.opts.MaxDeviation > 0 && len(fresh) >= 3 && mid > 0 {
+		for i := range agg.Sources {
+			s := &agg.Sources[i]
+			if deviation := (s.Price - mid) / mid; s.Dropped == "" && math.Abs(deviation) > a.opts.MaxDeviation {
+				s.Dropped = fmt.Sprintf("outlier, %+.2f%% from the median", deviation*100)
+			}
+		}
+	}
+
+	var prices, volumes []float64
+	for _, s := range agg.Sources {
+		if s.Dropped == "" {
+			prices = append(prices, s.Price)
+			volumes = append(volumes, s.Volume)
+			if s.Time.After(agg.Time) {
+				agg.Time = s.Time
+			}
+		}
+	}
+
+	if len(prices) == 0 {
+		return nil, a.noPrice(agg, errs)
+	}
+
+	agg.Price = median(prices)
+	if a.opts.Method == AggregateVWAP {
+		if vwap, ok := weightedMean(prices, volumes); ok {
+			agg.Price = vwap
+		}
+	}
+
+	a.mu.Lock()
+	a.last[agg.Symbol] = agg
+	a.mu.Unlock()
+
+	return agg, nil
+}
+
+// noPrice explains why no source contributed. It is an unknown symbol when
+// every source said so, and the exchange is unavailable otherwise.
+func (a *AggregateExchange) noPrice(agg *Aggregate, errs []error) error {
+	kind := ErrUnknownSymbol
+	reasons := make([]string, len(agg.Sources))
+	for i, s := range agg.Sources {
+		reasons[i] = s.Exchange + ": " + s.Dropped
+		if errs[i] == nil || !errors.Is(errs[i], ErrUnknownSymbol) {
+			kind = ErrUnavailable
+		}
+	}
+	return newError(a.GetName(), kind, fmt.Errorf("no source priced %s (%s)", agg.Symbol, strings.Join(reasons, "; ")))
+}
+
+// median returns the median of values, or zero for none
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// weightedMean returns the mean of values weighted by weights, and false when
+// the weights add up to nothing
+func weightedMean(values, weights []float64) (float64, bool) {
+	sum, total := 0.0, 0.0
+	for i, v := range values {
+		sum += v * weights[i]
+		total += weights[i]
+	}
+	if total <= 0 {
+		return 0, false
+	}
+	return sum / total, true
+}
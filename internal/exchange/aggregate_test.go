@@ -0,0 +1,158 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAggregateNormalizeSymbol(t *testing.T) {
+	agg, err := NewAggregateExchange([]Exchange{&OKXClient{}}, AggregateOptions{Method: AggregateMedian})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		in, want string
+	}{
+		{"BTC", "BTC/USD"},
+		{"btcusdt", "BTC/USD"},
+		{"BTC-USDC", "BTC/USD"},
+		{"BTCFDUSD", "BTC/USD"},
+		{"BTCTUSD", "BTC/USD"},
+		{"ETH/BTC", "ETH/BTC"},
+		{"BUSD", "BUSD/USD"},
+		{"USDC", "USDC/USD"},
+		{"USDCUSDT", "USDC/USD"},
+	}
+
+	for _, tt := range tests {
+		if got := agg.NormalizeSymbol(tt.in); got != tt.want {
+			t.Errorf("NormalizeSymbol(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// tickerSources returns Binance, Coinbase and OKX test clients that quote BTC
+// at the given prices, last updated at the given times
+func tickerSources(t *testing.T, prices [3]float64, times [3]time.Time) []Exchange {
+	t.Helper()
+
+	binance := newBinanceTestClient(t, "", "", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"symbol":"BTCUSDT","lastPrice":"%g","priceChange":"0","volume":"1","highPrice":"%g","lowPrice":"%g","closeTime":%d}`,
+			prices[0], prices[0], prices[0], times[0].UnixMilli())
+	})
+
+	coinbase := newCoinbaseTestClient(t, "", "", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/ticker"):
+			fmt.Fprintf(w, `{"price":"%g","time":%q}`, prices[1], times[1].Format(time.RFC3339Nano))
+		case strings.HasSuffix(r.URL.Path, "/stats"):
+			fmt.Fprintf(w, `{"open":"%g","high":"%g","low":"%g","last":"%g","volume":"1"}`, prices[1], prices[1], prices[1], prices[1])
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	okx := newOKXTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		okxData(w, fmt.Sprintf(`[{"instId":"BTC-USDT","last":"%g","open24h":"%g","high24h":"%g","low24h":"%g","vol24h":"1","ts":"%d"}]`,
+			prices[2], prices[2], prices[2], prices[2], times[2].UnixMilli()))
+	})
+
+	return []Exchange{binance, coinbase, okx}
+}
+
+func TestAggregateDropsStaleSources(t *testing.T) {
+	now := time.Now()
+	stale := now.Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		times     [3]time.Time
+		wantStale string
+		wantPrice float64
+	}{
+		{"binance close time", [3]time.Time{stale, now, now}, "binance", 101.5},
+		{"coinbase last trade", [3]time.Time{now, stale, now}, "coinbase", 101},
+		{"okx ticker time", [3]time.Time{now, now, stale}, "okx", 100.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sources := tickerSources(t, [3]float64{100, 101, 102}, tt.times)
+			composite, err := NewAggregateExchange(sources, AggregateOptions{Method: AggregateMedian, MaxAge: 30 * time.Second})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			agg, err := composite.GetAggregate(context.Background(), "BTC")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, s := range agg.Sources {
+				isStale := strings.HasPrefix(s.Dropped, "stale")
+				if isStale != (s.Exchange == tt.wantStale) {
+					t.Errorf("%s dropped = %q, want only %s stale", s.Exchange, s.Dropped, tt.wantStale)
+				}
+				if s.Exchange != tt.wantStale && s.Dropped != "" {
+					t.Errorf("%s dropped: %s", s.Exchange, s.Dropped)
+				}
+			}
+			if agg.Price != tt.wantPrice {
+				t.Errorf("price = %v, want %v", agg.Price, tt.wantPrice)
+			}
+		})
+	}
+}
+
+func TestAggregateSlowPerSymbolSource(t *testing.T) {
+	// Five symbols take ten requests, which fit in the client's rate limit burst
+	symbols := []string{"BTC", "ETH", "SOL", "ADA", "DOT"}
+
+	// slowCoinbase answers every request after delay; a ticker takes two requests
+	slowCoinbase := func(delay time.Duration) Exchange {
+		return newCoinbaseTestClient(t, "", "", func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(delay)
+			switch {
+			case strings.HasSuffix(r.URL.Path, "/ticker"):
+				fmt.Fprintf(w, `{"price":"100","time":%q}`, time.Now().Format(time.RFC3339Nano))
+			case strings.HasSuffix(r.URL.Path, "/stats"):
+				fmt.Fprint(w, `{"open":"100","high":"100","low":"100","last":"100","volume":"1"}`)
+			default:
+				http.NotFound(w, r)
+			}
+		})
+	}
+
+	t.Run("whole watchlist slower than max age", func(t *testing.T) {
+		// Each symbol takes about 120ms, the whole list more than max age
+		composite, err := NewAggregateExchange([]Exchange{slowCoinbase(60 * time.Millisecond)},
+			AggregateOptions{Method: AggregateMedian, MaxAge: 200 * time.Millisecond})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, r := range composite.GetPrices(context.Background(), symbols) {
+			if r.Err != nil || r.Value != 100 {
+				t.Errorf("%s = %v, %v; want 100", symbols[i], r.Value, r.Err)
+			}
+		}
+	})
+
+	t.Run("symbol slower than max age", func(t *testing.T) {
+		composite, err := NewAggregateExchange([]Exchange{slowCoinbase(250 * time.Millisecond)},
+			AggregateOptions{Method: AggregateMedian, MaxAge: 200 * time.Millisecond})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = composite.GetAggregate(context.Background(), "BTC")
+		if err == nil || !strings.Contains(err.Error(), "no answer within 200ms") {
+			t.Errorf("err = %v, want the source dropped for not answering", err)
+		}
+	})
+}
@@ -2,6 +2,8 @@ package exchange
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -14,9 +16,27 @@ import (
 
 // BinanceClient implements the Exchange interface for Binance
 type BinanceClient struct {
-	client  *binance.Client
-	limiter *rate.Limiter
-	name    string
+	client        *binance.Client
+	name          string
+	wsURL         string
+	authenticated bool
+	markets       listedMarkets
+}
+
+// binanceMiniTicker is the payload of a <symbol>@miniTicker stream event
+type binanceMiniTicker struct {
+	// EventType ("e") must be declared: encoding/json matches keys case-insensitively,
+	// so an undeclared "e" would be decoded into EventTime and fail every frame
+	EventType string `json:"e"`
+	EventTime int64  `json:"E"`
+	Symbol    string `json:"s"`
+	Close     string `json:"c"`
+}
+
+// binanceCombinedEvent wraps every event delivered on a combined stream
+type binanceCombinedEvent struct {
+	Stream string          `json:"stream"`
+	Data   json.RawMessage `json:"data"`
 }
 
 // NewBinanceClient creates a new Binance client
@@ -24,53 +44,129 @@ func NewBinanceClient(apiKey, apiSecret string) (*BinanceClient, error) {
 	// For public endpoints, we can use empty credentials
 	client := binance.NewClient(apiKey, apiSecret)
 
-	// Rate limit: 10 requests per second (stay well below Binance's 6000 weight/minute)
+	// Rate limit: 10 requests per second (stay well below Binance's 6000 weight/minute),
+	// pausing if the used weight reported by Binance gets close to that budget anyway
 	limiter := rate.NewLimiter(rate.Limit(10), 10)
+	client.HTTPClient = newHTTPClient("binance", limiter,
+		withWeightBudget("X-Mbx-Used-Weight-1m", 6000, time.Minute))
 
 	return &BinanceClient{
-		client:  client,
-		limiter: limiter,
-		name:    "binance",
+		client:        client,
+		name:          "binance",
+		wsURL:         "wss://stream.binance.com:9443/stream",
+		authenticated: apiKey != "" && apiSecret != "",
 	}, nil
 }
 
+// binanceTestnetURL is the REST endpoint of the Binance spot testnet
+const binanceTestnetURL = "https://testnet.binance.vision"
+
+// NewBinanceTestnetClient creates a client for the Binance spot testnet, which
+// trades with play money and has API keys of its own
+func NewBinanceTestnetClient(apiKey, apiSecret string) (*BinanceClient, error) {
+	b, err := NewBinanceClient(apiKey, apiSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	b.name = "binance-testnet"
+	b.client.BaseURL = binanceTestnetURL
+	b.wsURL = "wss://stream.testnet.binance.vision/stream"
+	return b, nil
+}
+
+// SetBaseURL overrides the REST endpoint, e.g. to point the client at a test server
+func (b *BinanceClient) SetBaseURL(baseURL string) {
+	b.client.BaseURL = strings.TrimRight(baseURL, "/")
+}
+
 // GetName returns the exchange name
 func (b *BinanceClient) GetName() string {
 	return b.name
 }
 
-// NormalizeSymbol converts a symbol like "BTC/USDT" to "BTCUSDT" for Binance
+// Capabilities describes what the client supports
+func (b *BinanceClient) Capabilities() Capabilities {
+	return Capabilities{
+		Intervals:      binanceIntervals,
+		MaxCandleLimit: binanceMaxCandles,
+		Batch:          true,
+		Streaming:      true,
+		OrderBook:      true,
+		Trades:         true,
+		CandleHistory:  true,
+		Markets:        true,
+		Authenticated:  b.authenticated,
+		Trading:        b.authenticated,
+	}
+}
+
+// binanceIntervals are the kline intervals supported by Binance
+var binanceIntervals = []string{"1s", "1m", "3m", "5m", "15m", "30m", "1h", "2h", "4h", "6h", "8h", "12h", "1d", "3d", "1w", "1M"}
+
+// binanceMaxCandles is the maximum number of klines returned by a single request
+const binanceMaxCandles = 1000
+
+// binanceQuoteAssets are quote currencies recognised at the end of a concatenated
+// symbol such as "ETHBTC", which is then left as it is.
+var binanceQuoteAssets = []string{"USDT", "USDC", "FDUSD", "TUSD", "BUSD", "BTC", "ETH", "BNB", "EUR"}
+
+// binanceCryptoQuotes are the quotes that wrapped tokens are named after; a
+// single letter before one ("WBTC", "WETH") is read as an asset, not W/BTC.
+// Longer names such as WBETH need the listed markets to be read right.
+var binanceCryptoQuotes = map[string]bool{"BTC": true, "ETH": true, "BNB": true}
+
+// NormalizeSymbol converts a symbol like "BTC/USDT" to "BTCUSDT" for Binance.
+// A bare symbol is looked up in the listed markets once SetMarkets has been
+// called, and otherwise read as a pair if it ends in a quote currency.
 func (b *BinanceClient) NormalizeSymbol(symbol string) string {
 	// Remove common separators and convert to uppercase
 	normalized := strings.ToUpper(symbol)
+	hasSeparator := strings.ContainsAny(normalized, "/-_")
 	normalized = strings.ReplaceAll(normalized, "/", "")
 	normalized = strings.ReplaceAll(normalized, "-", "")
 	normalized = strings.ReplaceAll(normalized, "_", "")
 
-	// If no quote currency specified, default to USDT
-	if len(normalized) <= 4 && !strings.HasSuffix(normalized, "USDT") {
-		normalized = normalized + "USDT"
+	// An explicit pair such as "ETH/BTC" already names its quote currency
+	if hasSeparator {
+		return normalized
+	}
+
+	if base, quote, ok := b.markets.split(normalized, "USDT"); ok {
+		return base + quote
+	}
+
+	// A symbol that already ends in a quote currency is a pair
+	for _, quote := range binanceQuoteAssets {
+		minBase := 1
+		if binanceCryptoQuotes[quote] {
+			minBase = 2
+		}
+		if len(normalized) >= len(quote)+minBase && strings.HasSuffix(normalized, quote) {
+			return normalized
+		}
 	}
 
-	return normalized
+	// If no quote currency specified, default to USDT
+	return normalized + "USDT"
+}
+
+// SetMarkets lets NormalizeSymbol look bare symbols up in the listed markets
+func (b *BinanceClient) SetMarkets(pair func(symbol string) (models.Market, bool)) {
+	b.markets.set(pair)
 }
 
 // GetPrice returns the current price for a symbol
 func (b *BinanceClient) GetPrice(ctx context.Context, symbol string) (float64, error) {
-	// Rate limiting
-	if err := b.limiter.Wait(ctx); err != nil {
-		return 0, fmt.Errorf("rate limit error: %w", err)
-	}
-
 	normalizedSymbol := b.NormalizeSymbol(symbol)
 
 	prices, err := b.client.NewListPricesService().Symbol(normalizedSymbol).Do(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get price from Binance: %w", err)
+		return 0, fmt.Errorf("failed to get price from Binance: %w", binanceError(b.name, err))
 	}
 
 	if len(prices) == 0 {
-		return 0, fmt.Errorf("no price data returned for symbol: %s", normalizedSymbol)
+		return 0, newError(b.name, ErrUnknownSymbol, fmt.Errorf("no price data returned for symbol: %s", normalizedSymbol))
 	}
 
 	price, err := strconv.ParseFloat(prices[0].Price, 64)
@@ -83,60 +179,166 @@ func (b *BinanceClient) GetPrice(ctx context.Context, symbol string) (float64, e
 
 // GetTicker returns detailed market data for a symbol
 func (b *BinanceClient) GetTicker(ctx context.Context, symbol string) (*models.Ticker, error) {
-	// Rate limiting
-	if err := b.limiter.Wait(ctx); err != nil {
-		return nil, fmt.Errorf("rate limit error: %w", err)
-	}
-
 	normalizedSymbol := b.NormalizeSymbol(symbol)
 
 	ticker, err := b.client.NewListPriceChangeStatsService().Symbol(normalizedSymbol).Do(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get ticker from Binance: %w", err)
+		return nil, fmt.Errorf("failed to get ticker from Binance: %w", binanceError(b.name, err))
 	}
 
 	if len(ticker) == 0 {
-		return nil, fmt.Errorf("no ticker data returned for symbol: %s", normalizedSymbol)
+		return nil, newError(b.name, ErrUnknownSymbol, fmt.Errorf("no ticker data returned for symbol: %s", normalizedSymbol))
 	}
 
-	t := ticker[0]
+	return binanceTicker(ticker[0]), nil
+}
 
+// binanceTicker converts Binance 24h statistics to a ticker
+func binanceTicker(t *binance.PriceChangeStats) *models.Ticker {
 	price, _ := strconv.ParseFloat(t.LastPrice, 64)
 	priceChange, _ := strconv.ParseFloat(t.PriceChange, 64)
 	volume, _ := strconv.ParseFloat(t.Volume, 64)
 	high, _ := strconv.ParseFloat(t.HighPrice, 64)
 	low, _ := strconv.ParseFloat(t.LowPrice, 64)
+	bid, _ := strconv.ParseFloat(t.BidPrice, 64)
+	ask, _ := strconv.ParseFloat(t.AskPrice, 64)
 
+	// The statistics window closes at the time the exchange computed them
 	return &models.Ticker{
-		Symbol:      normalizedSymbol,
+		Symbol:      t.Symbol,
 		Price:       price,
 		Change24h:   priceChange,
 		Volume24h:   volume,
 		High24h:     high,
 		Low24h:      low,
-		LastUpdated: time.Now(),
-	}, nil
+		LastUpdated: time.UnixMilli(t.CloseTime),
+		Bid:         bid,
+		Ask:         ask,
+	}
+}
+
+// GetPrices returns the current prices for several symbols with a single request
+func (b *BinanceClient) GetPrices(ctx context.Context, symbols []string) []Result[float64] {
+	if len(symbols) < 2 {
+		return EachSymbol(ctx, symbols, b.GetPrice)
+	}
+
+	normalized, unique := b.batchSymbols(symbols)
+	prices, err := b.client.NewListPricesService().Symbols(unique).Do(ctx)
+	if err != nil {
+		err = binanceError(b.name, err)
+		if errors.Is(err, ErrUnknownSymbol) {
+			// One unknown symbol fails the whole request; ask one by one to tell which
+			return EachSymbol(ctx, symbols, b.GetPrice)
+		}
+		return failAll[float64](len(symbols), fmt.Errorf("failed to get prices from Binance: %w", err))
+	}
+
+	bySymbol := make(map[string]string, len(prices))
+	for _, p := range prices {
+		bySymbol[p.Symbol] = p.Price
+	}
+
+	results := make([]Result[float64], len(symbols))
+	for i, symbol := range normalized {
+		raw, ok := bySymbol[symbol]
+		if !ok {
+			results[i].Err = newError(b.name, ErrUnknownSymbol, fmt.Errorf("no price data returned for symbol: %s", symbol))
+			continue
+		}
+		if results[i].Value, err = strconv.ParseFloat(raw, 64); err != nil {
+			results[i].Err = fmt.Errorf("failed to parse price: %w", err)
+		}
+	}
+	return results
+}
+
+// GetTickers returns detailed market data for several symbols with a single request
+func (b *BinanceClient) GetTickers(ctx context.Context, symbols []string) []Result[*models.Ticker] {
+	if len(symbols) < 2 {
+		return EachSymbol(ctx, symbols, b.GetTicker)
+	}
+
+	normalized, unique := b.batchSymbols(symbols)
+	stats, err := b.client.NewListPriceChangeStatsService().Symbols(unique).Do(ctx)
+	if err != nil {
+		err = binanceError(b.name, err)
+		if errors.Is(err, ErrUnknownSymbol) {
+			// One unknown symbol fails the whole request; ask one by one to tell which
+			return EachSymbol(ctx, symbols, b.GetTicker)
+		}
+		return failAll[*models.Ticker](len(symbols), fmt.Errorf("failed to get tickers from Binance: %w", err))
+	}
+
+	bySymbol := make(map[string]*binance.PriceChangeStats, len(stats))
+	for _, t := range stats {
+		bySymbol[t.Symbol] = t
+	}
+
+	results := make([]Result[*models.Ticker], len(symbols))
+	for i, symbol := range normalized {
+		if t, ok := bySymbol[symbol]; ok {
+			results[i].Value = binanceTicker(t)
+		} else {
+			results[i].Err = newError(b.name, ErrUnknownSymbol, fmt.Errorf("no ticker data returned for symbol: %s", symbol))
+		}
+	}
+	return results
+}
+
+// batchSymbols normalizes symbols, returning them in order and without duplicates for a batch request
+func (b *BinanceClient) batchSymbols(symbols []string) (normalized, unique []string) {
+	seen := make(map[string]bool, len(symbols))
+	normalized = make([]string, len(symbols))
+	for i, symbol := range symbols {
+		normalized[i] = b.NormalizeSymbol(symbol)
+		if !seen[normalized[i]] {
+			seen[normalized[i]] = true
+			unique = append(unique, normalized[i])
+		}
+	}
+	return normalized, unique
 }
 
 // GetCandles returns historical OHLCV data
 func (b *BinanceClient) GetCandles(ctx context.Context, symbol, interval string, limit int) ([]models.Candle, error) {
-	// Rate limiting
-	if err := b.limiter.Wait(ctx); err != nil {
-		return nil, fmt.Errorf("rate limit error: %w", err)
+	normalizedSymbol := b.NormalizeSymbol(symbol)
+
+	klines, err := b.client.NewKlinesService().
+		Symbol(normalizedSymbol).
+		Interval(interval).
+		Limit(limit).
+		Do(ctx)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get candles from Binance: %w", binanceError(b.name, err))
 	}
 
+	return binanceCandles(klines), nil
+}
+
+// GetCandleRange returns the candles opening within [start, end), oldest first
+func (b *BinanceClient) GetCandleRange(ctx context.Context, symbol, interval string, start, end time.Time) ([]models.Candle, error) {
 	normalizedSymbol := b.NormalizeSymbol(symbol)
 
+	// Both bounds are inclusive on Binance, so stop just before end
 	klines, err := b.client.NewKlinesService().
 		Symbol(normalizedSymbol).
 		Interval(interval).
-		Limit(limit).
+		StartTime(start.UnixMilli()).
+		EndTime(end.UnixMilli() - 1).
+		Limit(binanceMaxCandles).
 		Do(ctx)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to get candles from Binance: %w", err)
+		return nil, fmt.Errorf("failed to get candles from Binance: %w", binanceError(b.name, err))
 	}
 
+	return binanceCandles(klines), nil
+}
+
+// binanceCandles converts klines into candles
+func binanceCandles(klines []*binance.Kline) []models.Candle {
 	candles := make([]models.Candle, len(klines))
 	for i, k := range klines {
 		open, _ := strconv.ParseFloat(k.Open, 64)
@@ -144,16 +346,374 @@ func (b *BinanceClient) GetCandles(ctx context.Context, symbol, interval string,
 		low, _ := strconv.ParseFloat(k.Low, 64)
 		closePrice, _ := strconv.ParseFloat(k.Close, 64)
 		volume, _ := strconv.ParseFloat(k.Volume, 64)
+		quoteVolume, _ := strconv.ParseFloat(k.QuoteAssetVolume, 64)
 
 		candles[i] = models.Candle{
-			Time:   time.Unix(k.OpenTime/1000, 0),
-			Open:   open,
-			High:   high,
-			Low:    low,
-			Close:  closePrice,
-			Volume: volume,
+			Time:        time.UnixMilli(k.OpenTime),
+			CloseTime:   time.UnixMilli(k.CloseTime),
+			Open:        open,
+			High:        high,
+			Low:         low,
+			Close:       closePrice,
+			Volume:      volume,
+			QuoteVolume: quoteVolume,
 		}
 	}
 
-	return candles, nil
+	return candles
+}
+
+// SubscribeTickers streams last prices from Binance's combined miniTicker stream
+func (b *BinanceClient) SubscribeTickers(ctx context.Context, symbols []string) (<-chan models.PriceUpdate, error) {
+	streams := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		streams[i] = strings.ToLower(b.NormalizeSymbol(symbol)) + "@miniTicker"
+	}
+
+	cfg := streamConfig{
+		// Binance subscribes through the URL, so reconnecting also resubscribes
+		url: b.wsURL + "?streams=" + strings.Join(streams, "/"),
+		parse: func(message []byte) ([]models.PriceUpdate, error) {
+			var event binanceCombinedEvent
+			if err := json.Unmarshal(message, &event); err != nil {
+				return nil, err
+			}
+
+			var ticker binanceMiniTicker
+			if err := json.Unmarshal(event.Data, &ticker); err != nil {
+				return nil, err
+			}
+
+			price, err := strconv.ParseFloat(ticker.Close, 64)
+			if err != nil {
+				return nil, err
+			}
+
+			return []models.PriceUpdate{{
+				Symbol:    ticker.Symbol,
+				Price:     price,
+				Timestamp: time.UnixMilli(ticker.EventTime),
+			}}, nil
+		},
+	}
+
+	return startStream(ctx, cfg)
+}
+
+// binanceDepthLimits are the depth sizes accepted by the Binance order book endpoint
+var binanceDepthLimits = []int{5, 10, 20, 50, 100, 500, 1000, 5000}
+
+// GetOrderBook returns up to depth price levels on each side of the book
+func (b *BinanceClient) GetOrderBook(ctx context.Context, symbol string, depth int) (*models.OrderBook, error) {
+	normalizedSymbol := b.NormalizeSymbol(symbol)
+
+	// Request the smallest supported depth that covers what was asked for
+	limit := binanceDepthLimits[len(binanceDepthLimits)-1]
+	for _, l := range binanceDepthLimits {
+		if l >= depth {
+			limit = l
+			break
+		}
+	}
+
+	res, err := b.client.NewDepthService().Symbol(normalizedSymbol).Limit(limit).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order book from Binance: %w", binanceError(b.name, err))
+	}
+
+	book := &models.OrderBook{
+		Symbol:    normalizedSymbol,
+		Bids:      make([]models.OrderBookLevel, 0, len(res.Bids)),
+		Asks:      make([]models.OrderBookLevel, 0, len(res.Asks)),
+		UpdateID:  res.LastUpdateID,
+		Timestamp: time.Now(),
+	}
+
+	for _, bid := range res.Bids {
+		price, _ := strconv.ParseFloat(bid.Price, 64)
+		quantity, _ := strconv.ParseFloat(bid.Quantity, 64)
+		book.Bids = append(book.Bids, models.OrderBookLevel{Price: price, Quantity: quantity})
+	}
+	for _, ask := range res.Asks {
+		price, _ := strconv.ParseFloat(ask.Price, 64)
+		quantity, _ := strconv.ParseFloat(ask.Quantity, 64)
+		book.Asks = append(book.Asks, models.OrderBookLevel{Price: price, Quantity: quantity})
+	}
+
+	book.Bids = truncateLevels(book.Bids, depth)
+	book.Asks = truncateLevels(book.Asks, depth)
+
+	return book, nil
+}
+
+// GetRecentTrades returns up to limit of the latest trades, oldest first
+func (b *BinanceClient) GetRecentTrades(ctx context.Context, symbol string, limit int) ([]models.Trade, error) {
+	normalizedSymbol := b.NormalizeSymbol(symbol)
+
+	res, err := b.client.NewRecentTradesService().Symbol(normalizedSymbol).Limit(limit).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trades from Binance: %w", binanceError(b.name, err))
+	}
+
+	trades := make([]models.Trade, len(res))
+	for i, t := range res {
+		price, _ := strconv.ParseFloat(t.Price, 64)
+		quantity, _ := strconv.ParseFloat(t.Quantity, 64)
+
+		// When the buyer is the maker, the seller was the aggressor
+		side := models.SideBuy
+		if t.IsBuyerMaker {
+			side = models.SideSell
+		}
+
+		trades[i] = models.Trade{
+			ID:       strconv.FormatInt(t.ID, 10),
+			Symbol:   normalizedSymbol,
+			Price:    price,
+			Quantity: quantity,
+			Side:     side,
+			Time:     time.UnixMilli(t.Time),
+		}
+	}
+
+	return trades, nil
+}
+
+// ListMarkets returns every spot market listed on the exchange
+func (b *BinanceClient) ListMarkets(ctx context.Context) ([]models.Market, error) {
+	info, err := b.client.NewExchangeInfoService().Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exchange info from Binance: %w", binanceError(b.name, err))
+	}
+
+	markets := make([]models.Market, 0, len(info.Symbols))
+	for i := range info.Symbols {
+		sym := &info.Symbols[i]
+
+		status := models.MarketStatusHalted
+		if sym.Status == "TRADING" {
+			status = models.MarketStatusTrading
+		}
+
+		market := models.Market{
+			Symbol: sym.Symbol,
+			Base:   sym.BaseAsset,
+			Quote:  sym.QuoteAsset,
+			Status: status,
+		}
+		if f := sym.PriceFilter(); f != nil {
+			market.TickSize, _ = strconv.ParseFloat(f.TickSize, 64)
+		}
+		if f := sym.LotSizeFilter(); f != nil {
+			market.LotSize, _ = strconv.ParseFloat(f.StepSize, 64)
+		}
+		if f := sym.NotionalFilter(); f != nil {
+			market.MinNotional, _ = strconv.ParseFloat(f.MinNotional, 64)
+		}
+
+		markets = append(markets, market)
+	}
+
+	return markets, nil
+}
+
+// GetBalances returns every asset with a non-zero balance in the account (signed /api/v3/account)
+func (b *BinanceClient) GetBalances(ctx context.Context) ([]models.Balance, error) {
+	if !b.authenticated {
+		return nil, errNoCredentials(b.name)
+	}
+
+	account, err := b.client.NewGetAccountService().OmitZeroBalances(true).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account from Binance: %w", binanceError(b.name, err))
+	}
+
+	balances := make([]models.Balance, 0, len(account.Balances))
+	for _, bal := range account.Balances {
+		free, _ := strconv.ParseFloat(bal.Free, 64)
+		locked, _ := strconv.ParseFloat(bal.Locked, 64)
+		if free == 0 && locked == 0 {
+			continue
+		}
+
+		balances = append(balances, models.Balance{
+			Asset:  bal.Asset,
+			Free:   free,
+			Locked: locked,
+		})
+	}
+
+	return balances, nil
+}
+
+// binanceOrderTypes maps order types to Binance's; a stop-limit is a STOP_LOSS_LIMIT,
+// which triggers above the market for buys and below it for sells
+var binanceOrderTypes = map[models.OrderType]binance.OrderType{
+	models.OrderTypeMarket:    binance.OrderTypeMarket,
+	models.OrderTypeLimit:     binance.OrderTypeLimit,
+	models.OrderTypeStopLimit: binance.OrderTypeStopLossLimit,
+}
+
+// PlaceOrder submits an order (signed POST /api/v3/order). Limit and stop-limit
+// orders are good till canceled.
+func (b *BinanceClient) PlaceOrder(ctx context.Context, req models.OrderRequest) (*models.Order, error) {
+	if !b.authenticated {
+		return nil, errNoCredentials(b.name)
+	}
+
+	orderType, ok := binanceOrderTypes[req.Type]
+	if !ok {
+		return nil, newError(b.name, ErrUnsupported, fmt.Errorf("order type %q", req.Type))
+	}
+
+	side := binance.SideTypeBuy
+	if req.Side == models.SideSell {
+		side = binance.SideTypeSell
+	}
+
+	svc := b.client.NewCreateOrderService().
+		Symbol(b.NormalizeSymbol(req.Symbol)).
+		Side(side).
+		Type(orderType).
+		Quantity(strconv.FormatFloat(req.Quantity, 'f', -1, 64))
+	if req.Type != models.OrderTypeMarket {
+		svc = svc.Price(strconv.FormatFloat(req.Price, 'f', -1, 64)).
+			TimeInForce(binance.TimeInForceTypeGTC)
+	}
+	if req.Type == models.OrderTypeStopLimit {
+		svc = svc.StopPrice(strconv.FormatFloat(req.StopPrice, 'f', -1, 64))
+	}
+
+	res, err := svc.Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to place order on Binance: %w", binanceError(b.name, err))
+	}
+
+	order := binanceOrder(&binance.Order{
+		Symbol:                   res.Symbol,
+		OrderID:                  res.OrderID,
+		ClientOrderID:            res.ClientOrderID,
+		Price:                    res.Price,
+		OrigQuantity:             res.OrigQuantity,
+		ExecutedQuantity:         res.ExecutedQuantity,
+		CummulativeQuoteQuantity: res.CummulativeQuoteQuantity,
+		Status:                   res.Status,
+		Type:                     res.Type,
+		Side:                     res.Side,
+		Time:                     res.TransactTime,
+	})
+	order.StopPrice = req.StopPrice
+	return &order, nil
+}
+
+// CancelOrder cancels an open order (signed DELETE /api/v3/order)
+func (b *BinanceClient) CancelOrder(ctx context.Context, symbol, orderID string) (*models.Order, error) {
+	if !b.authenticated {
+		return nil, errNoCredentials(b.name)
+	}
+
+	id, err := strconv.ParseInt(orderID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Binance order ID %q", orderID)
+	}
+
+	res, err := b.client.NewCancelOrderService().Symbol(b.NormalizeSymbol(symbol)).OrderID(id).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel order on Binance: %w", binanceError(b.name, err))
+	}
+
+	order := binanceOrder(&binance.Order{
+		Symbol:                   res.Symbol,
+		OrderID:                  res.OrderID,
+		ClientOrderID:            res.OrigClientOrderID,
+		Price:                    res.Price,
+		OrigQuantity:             res.OrigQuantity,
+		ExecutedQuantity:         res.ExecutedQuantity,
+		CummulativeQuoteQuantity: res.CummulativeQuoteQuantity,
+		Status:                   res.Status,
+		Type:                     res.Type,
+		Side:                     res.Side,
+		UpdateTime:               res.TransactTime,
+	})
+	return &order, nil
+}
+
+// ListOpenOrders returns the open orders for symbol, or for every symbol if it is empty
+func (b *BinanceClient) ListOpenOrders(ctx context.Context, symbol string) ([]models.Order, error) {
+	if !b.authenticated {
+		return nil, errNoCredentials(b.name)
+	}
+
+	svc := b.client.NewListOpenOrdersService()
+	if symbol != "" {
+		svc = svc.Symbol(b.NormalizeSymbol(symbol))
+	}
+
+	res, err := svc.Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open orders on Binance: %w", binanceError(b.name, err))
+	}
+
+	orders := make([]models.Order, len(res))
+	for i, o := range res {
+		orders[i] = binanceOrder(o)
+	}
+	return orders, nil
+}
+
+// GetOrder returns the current state of an order
+func (b *BinanceClient) GetOrder(ctx context.Context, symbol, orderID string) (*models.Order, error) {
+	if !b.authenticated {
+		return nil, errNoCredentials(b.name)
+	}
+
+	id, err := strconv.ParseInt(orderID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Binance order ID %q", orderID)
+	}
+
+	res, err := b.client.NewGetOrderService().Symbol(b.NormalizeSymbol(symbol)).OrderID(id).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order from Binance: %w", binanceError(b.name, err))
+	}
+
+	order := binanceOrder(res)
+	return &order, nil
+}
+
+// binanceOrder converts a Binance order
+func binanceOrder(o *binance.Order) models.Order {
+	price, _ := strconv.ParseFloat(o.Price, 64)
+	stopPrice, _ := strconv.ParseFloat(o.StopPrice, 64)
+	quantity, _ := strconv.ParseFloat(o.OrigQuantity, 64)
+	executed, _ := strconv.ParseFloat(o.ExecutedQuantity, 64)
+	quote, _ := strconv.ParseFloat(o.CummulativeQuoteQuantity, 64)
+
+	orderType := models.OrderType(strings.ToLower(string(o.Type)))
+	for t, bt := range binanceOrderTypes {
+		if bt == o.Type {
+			orderType = t
+		}
+	}
+
+	order := models.Order{
+		ID:               strconv.FormatInt(o.OrderID, 10),
+		ClientOrderID:    o.ClientOrderID,
+		Symbol:           o.Symbol,
+		Side:             models.Side(strings.ToLower(string(o.Side))),
+		Type:             orderType,
+		Status:           strings.ToLower(string(o.Status)),
+		Price:            price,
+		StopPrice:        stopPrice,
+		Quantity:         quantity,
+		ExecutedQuantity: executed,
+		QuoteQuantity:    quote,
+	}
+	if o.Time > 0 {
+		order.Time = time.UnixMilli(o.Time)
+	}
+	if o.UpdateTime > 0 {
+		order.UpdateTime = time.UnixMilli(o.UpdateTime)
+	}
+	return order
 }
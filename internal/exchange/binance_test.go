@@ -0,0 +1,197 @@
+package exchange
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+)
+
+// newBinanceTestClient returns a Binance client talking to a test server that serves handler
+func newBinanceTestClient(t *testing.T, apiKey, apiSecret string, handler http.HandlerFunc) *BinanceClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewBinanceClient(apiKey, apiSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetBaseURL(server.URL)
+	return client
+}
+
+// verifyBinanceSignature rejects a request whose signature parameter does not
+// match the HMAC of the rest of its query string under secret
+func verifyBinanceSignature(t *testing.T, w http.ResponseWriter, r *http.Request, apiKey, secret string) bool {
+	t.Helper()
+
+	query, signature, _ := strings.Cut(r.URL.RawQuery, "&signature=")
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(query))
+	want := hex.EncodeToString(mac.Sum(nil))
+
+	if r.Header.Get("X-MBX-APIKEY") != apiKey || !strings.Contains(query, "timestamp=") ||
+		!hmac.Equal([]byte(signature), []byte(want)) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"code":-1022,"msg":"Signature for this request is not valid."}`)
+		return false
+	}
+	return true
+}
+
+func TestBinanceGetBalances(t *testing.T) {
+	client := newBinanceTestClient(t, "key", "secret", func(w http.ResponseWriter, r *http.Request) {
+		if !verifyBinanceSignature(t, w, r, "key", "secret") {
+			t.Errorf("signature rejected for %s", r.URL.RequestURI())
+			return
+		}
+		if r.URL.Path != "/api/v3/account" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("omitZeroBalances"); got != "true" {
+			t.Errorf("omitZeroBalances = %q, want true", got)
+		}
+		fmt.Fprint(w, `{"balances":[
+			{"asset":"BTC","free":"0.5","locked":"0.1"},
+			{"asset":"BNB","free":"0","locked":"0"},
+			{"asset":"USDT","free":"1000","locked":"0"}
+		]}`)
+	})
+
+	balances, err := client.GetBalances(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(balances) != 2 {
+		t.Fatalf("balances = %+v, want BTC and USDT", balances)
+	}
+	if b := balances[0]; b.Asset != "BTC" || b.Free != 0.5 || b.Locked != 0.1 {
+		t.Errorf("BTC balance = %+v", b)
+	}
+	if b := balances[1]; b.Asset != "USDT" || b.Free != 1000 {
+		t.Errorf("USDT balance = %+v", b)
+	}
+}
+
+func TestBinanceGetBalancesWrongSecret(t *testing.T) {
+	client := newBinanceTestClient(t, "key", "wrong", func(w http.ResponseWriter, r *http.Request) {
+		verifyBinanceSignature(t, w, r, "key", "secret")
+	})
+
+	_, err := client.GetBalances(context.Background())
+	if !errors.Is(err, ErrAuth) {
+		t.Errorf("err = %v, want ErrAuth", err)
+	}
+}
+
+// listedPairs returns a lookup of markets by base and quote, as SetMarkets takes it
+func listedPairs(markets ...models.Market) func(symbol string) (models.Market, bool) {
+	compact := strings.NewReplacer("/", "", "-", "", "_", "")
+	return func(symbol string) (models.Market, bool) {
+		for _, m := range markets {
+			if m.Base+m.Quote == compact.Replace(strings.ToUpper(symbol)) {
+				return m, true
+			}
+		}
+		return models.Market{}, false
+	}
+}
+
+func TestBinanceNormalizeSymbol(t *testing.T) {
+	client, _ := NewBinanceClient("", "")
+
+	tests := []struct {
+		in, want string
+	}{
+		{"BTC", "BTCUSDT"},
+		{"btc/usdt", "BTCUSDT"},
+		{"ETHBTC", "ETHBTC"},
+		{"ETH/BTC", "ETHBTC"},
+		{"SOLUSDC", "SOLUSDC"},
+		{"BNBETH", "BNBETH"},
+		{"PEPE", "PEPEUSDT"},
+		{"1000SATS", "1000SATSUSDT"},
+		{"WBTC", "WBTCUSDT"},
+		{"WETH", "WETHUSDT"},
+		{"W/BTC", "WBTC"},
+		{"USDC", "USDCUSDT"},
+	}
+
+	for _, tt := range tests {
+		if got := client.NormalizeSymbol(tt.in); got != tt.want {
+			t.Errorf("NormalizeSymbol(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestBinanceNormalizeSymbolWithMarkets(t *testing.T) {
+	client, _ := NewBinanceClient("", "")
+	client.SetMarkets(listedPairs(
+		models.Market{Base: "BTC", Quote: "USDT"},
+		models.Market{Base: "ETH", Quote: "BTC"},
+		models.Market{Base: "W", Quote: "BTC"},
+		models.Market{Base: "WBTC", Quote: "USDT"},
+		models.Market{Base: "WBETH", Quote: "ETH"},
+		models.Market{Base: "WBETH", Quote: "USDT"},
+		models.Market{Base: "STETH", Quote: "USDT"},
+		models.Market{Base: "AEUR", Quote: "USDT"},
+	))
+
+	tests := []struct {
+		in, want string
+	}{
+		// Assets the quote suffix heuristic would read as WB/ETH, ST/ETH and A/EUR
+		{"WBETH", "WBETHUSDT"},
+		{"steth", "STETHUSDT"},
+		{"AEUR", "AEURUSDT"},
+		{"WBETHETH", "WBETHETH"},
+		{"WBTC", "WBTCUSDT"},
+		{"W/BTC", "WBTC"},
+		{"ETHBTC", "ETHBTC"},
+		{"BTC", "BTCUSDT"},
+
+		// Unlisted symbols fall back to the heuristic
+		{"PEPE", "PEPEUSDT"},
+		{"SOLUSDC", "SOLUSDC"},
+	}
+
+	for _, tt := range tests {
+		if got := client.NormalizeSymbol(tt.in); got != tt.want {
+			t.Errorf("NormalizeSymbol(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestBinanceTestnetErrorsNameTestnet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"code":-2015,"msg":"Invalid API-key, IP, or permissions for action."}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewBinanceTestnetClient("key", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetBaseURL(server.URL)
+
+	_, err = client.GetBalances(context.Background())
+
+	var e *Error
+	if !errors.As(err, &e) || !errors.Is(err, ErrAuth) {
+		t.Fatalf("err = %v, want an ErrAuth *Error", err)
+	}
+	if e.Exchange != "binance-testnet" {
+		t.Errorf("Exchange = %q, want binance-testnet", e.Exchange)
+	}
+}
@@ -0,0 +1,80 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+)
+
+// FetchCandleHistory returns every candle opening within [start, end), oldest first.
+// Ranges longer than one request allows are split into consecutive windows of
+// MaxCandleLimit candles each.
+func FetchCandleHistory(ctx context.Context, client Exchange, symbol, interval string, start, end time.Time) ([]models.Candle, error) {
+	caps := client.Capabilities()
+	provider, ok := As[CandleHistoryProvider](client)
+	if !caps.CandleHistory || !ok {
+		return nil, newError(client.GetName(), ErrUnsupported, fmt.Errorf("%s does not serve candle history", client.GetName()))
+	}
+	if !caps.SupportsInterval(interval) {
+		return nil, &UnsupportedIntervalError{Exchange: client.GetName(), Interval: interval}
+	}
+
+	step, ok := IntervalDuration(interval)
+	if !ok {
+		return nil, &UnsupportedIntervalError{Exchange: client.GetName(), Interval: interval}
+	}
+	if !start.Before(end) {
+		return nil, fmt.Errorf("start %s is not before end %s", start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+
+	limit := caps.MaxCandleLimit
+	if limit <= 0 {
+		limit = 100
+	}
+	window := step * time.Duration(limit)
+
+	var candles []models.Candle
+	for from := start; from.Before(end); from = from.Add(window) {
+		to := from.Add(window)
+		if to.After(end) {
+			to = end
+		}
+
+		batch, err := provider.GetCandleRange(ctx, symbol, interval, from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		// Windows may overlap at their edges; keep each open time within the range once
+		for _, c := range filterCandleRange(batch, start, end) {
+			if len(candles) > 0 && !c.Time.After(candles[len(candles)-1].Time) {
+				continue
+			}
+			candles = append(candles, c)
+		}
+	}
+
+	return candles, nil
+}
+
+// candleCloseTime returns the last instant covered by a candle opening at open
+func candleCloseTime(open time.Time, interval string) time.Time {
+	d, ok := IntervalDuration(interval)
+	if !ok {
+		return time.Time{}
+	}
+	return open.Add(d - time.Millisecond)
+}
+
+// filterCandleRange keeps the candles opening within [start, end)
+func filterCandleRange(candles []models.Candle, start, end time.Time) []models.Candle {
+	filtered := candles[:0]
+	for _, c := range candles {
+		if !c.Time.Before(start) && c.Time.Before(end) {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
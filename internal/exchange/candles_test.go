@@ -0,0 +1,157 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+)
+
+// checkCandleRun fails unless candles are the n consecutive candles of step opening from start
+func checkCandleRun(t *testing.T, candles []models.Candle, start time.Time, step time.Duration, n int) {
+	t.Helper()
+
+	if len(candles) != n {
+		t.Fatalf("got %d candles, want %d", len(candles), n)
+	}
+	for i, c := range candles {
+		if want := start.Add(time.Duration(i) * step); !c.Time.Equal(want) {
+			t.Fatalf("candle %d opens at %v, want %v", i, c.Time.UTC(), want)
+		}
+	}
+}
+
+func TestFetchCandleHistoryBinancePages(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(2500 * time.Minute)
+
+	var mu sync.Mutex
+	var windows []string
+	client := newBinanceTestClient(t, "", "", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		from, _ := strconv.ParseInt(query.Get("startTime"), 10, 64)
+		to, _ := strconv.ParseInt(query.Get("endTime"), 10, 64)
+		limit, _ := strconv.Atoi(query.Get("limit"))
+
+		mu.Lock()
+		windows = append(windows, fmt.Sprintf("%d-%d", (from-start.UnixMilli())/60000, (to-start.UnixMilli())/60000))
+		mu.Unlock()
+
+		// Both bounds are inclusive, and at most limit klines are returned
+		var klines []string
+		for open := from; open <= to && len(klines) < limit; open += 60000 {
+			klines = append(klines, fmt.Sprintf(`[%d,"1","1","1","1","1",%d,"1",1,"1","1","0"]`, open, open+59999))
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(klines, ","))
+	})
+
+	candles, err := FetchCandleHistory(context.Background(), client, "BTCUSDT", "1m", start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkCandleRun(t, candles, start, time.Minute, 2500)
+
+	// Windows of 1000 candles, each ending just before the next begins
+	if got, want := strings.Join(windows, " "), "0-999 1000-1999 2000-2499"; got != want {
+		t.Errorf("requested minutes %s, want %s", got, want)
+	}
+}
+
+func TestFetchCandleHistoryOKXPages(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(450 * time.Hour)
+
+	// The exchange has hourly candles from a day before start until end
+	first := start.Add(-24 * time.Hour)
+
+	var mu sync.Mutex
+	var pages int
+	client := newOKXTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v5/market/history-candles" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		after, _ := strconv.ParseInt(query.Get("after"), 10, 64)
+		limit, _ := strconv.Atoi(query.Get("limit"))
+
+		mu.Lock()
+		pages++
+		mu.Unlock()
+
+		// Newest first, opening strictly before after
+		var rows []string
+		for open := time.UnixMilli(after).Add(-time.Hour); !open.Before(first) && len(rows) < limit; open = open.Add(-time.Hour) {
+			rows = append(rows, fmt.Sprintf(`["%d","1","1","1","1","1","1","1","1"]`, open.UnixMilli()))
+		}
+		okxData(w, "["+strings.Join(rows, ",")+"]")
+	})
+
+	candles, err := FetchCandleHistory(context.Background(), client, "BTC-USDT", "1h", start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkCandleRun(t, candles, start, time.Hour, 450)
+
+	// A window of 300 candles takes three pages of 100, the remaining 150 two
+	if pages != 5 {
+		t.Errorf("requested %d pages, want 5", pages)
+	}
+}
+
+// overlappingHistory serves one-minute candles for any range, including the
+// candle opening at the end of the range, as some exchanges do
+type overlappingHistory struct {
+	*stubExchange
+	ranges int
+}
+
+func (o *overlappingHistory) GetCandleRange(ctx context.Context, symbol, interval string, start, end time.Time) ([]models.Candle, error) {
+	o.ranges++
+	var candles []models.Candle
+	for open := start; !open.After(end); open = open.Add(time.Minute) {
+		candles = append(candles, models.Candle{Time: open})
+	}
+	return candles, nil
+}
+
+func (o *overlappingHistory) Capabilities() Capabilities {
+	return Capabilities{Intervals: []string{"1m"}, MaxCandleLimit: 10, CandleHistory: true}
+}
+
+func TestFetchCandleHistoryWindows(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("boundary candles are kept once", func(t *testing.T) {
+		client := &overlappingHistory{stubExchange: &stubExchange{}}
+
+		candles, err := FetchCandleHistory(context.Background(), client, "BTC/USDT", "1m", start, start.Add(25*time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The last window's extra candle opens at end itself, outside the range
+		checkCandleRun(t, candles, start, time.Minute, 25)
+		if client.ranges != 3 {
+			t.Errorf("requested %d windows, want 3", client.ranges)
+		}
+	})
+
+	t.Run("start not before end", func(t *testing.T) {
+		client := &overlappingHistory{stubExchange: &stubExchange{}}
+
+		for _, end := range []time.Time{start, start.Add(-time.Minute)} {
+			if _, err := FetchCandleHistory(context.Background(), client, "BTC/USDT", "1m", start, end); err == nil || !strings.Contains(err.Error(), "is not before end") {
+				t.Errorf("end %v: err = %v, want start is not before end", end, err)
+			}
+		}
+		if client.ranges != 0 {
+			t.Errorf("requested %d windows for an empty range", client.ranges)
+		}
+	})
+}
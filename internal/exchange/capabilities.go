@@ -0,0 +1,101 @@
+package exchange
+
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Capabilities describes what an exchange client supports, so callers can
+// adapt up front instead of discovering gaps through errors
+type Capabilities struct {
+	// Intervals lists the candle intervals GetCandles accepts, shortest first
+	Intervals []string `json:"intervals"`
+
+	// MaxCandleLimit is the most candles a single GetCandles call returns
+	MaxCandleLimit int `json:"max_candle_limit"`
+
+	// Batch reports whether GetPrices and GetTickers fetch several symbols in a single request
+	Batch bool `json:"batch"`
+
+	// Streaming reports whether the client implements Streamer
+	Streaming bool `json:"streaming"`
+
+	// OrderBook reports whether the client implements OrderBookProvider
+	OrderBook bool `json:"order_book"`
+
+	// Trades reports whether the client implements TradesProvider
+	Trades bool `json:"trades"`
+
+	// CandleHistory reports whether the client implements CandleHistoryProvider
+	CandleHistory bool `json:"candle_history"`
+
+	// Markets reports whether the client implements MarketLister
+	Markets bool `json:"markets"`
+
+	// Authenticated reports whether credentials are configured and the client
+	// implements AccountProvider
+	Authenticated bool `json:"authenticated"`
+
+	// Trading reports whether credentials are configured and the client implements Trader
+	Trading bool `json:"trading"`
+}
+
+// SupportsInterval reports whether candles are available for interval
+func (c Capabilities) SupportsInterval(interval string) bool {
+	for _, i := range c.Intervals {
+		if i == interval {
+			return true
+		}
+	}
+	return false
+}
+
+// IntervalDuration returns the length of a Binance-style interval such as "15m", "4h" or "1d".
+// Months ("1M") are approximated as 30 days.
+func IntervalDuration(interval string) (time.Duration, bool) {
+	if len(interval) < 2 {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(interval[:len(interval)-1])
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+
+	var unit time.Duration
+	switch interval[len(interval)-1] {
+	case 's':
+		unit = time.Second
+	case 'm':
+		unit = time.Minute
+	case 'h':
+		unit = time.Hour
+	case 'd':
+		unit = 24 * time.Hour
+	case 'w':
+		unit = 7 * 24 * time.Hour
+	case 'M':
+		unit = 30 * 24 * time.Hour
+	default:
+		return 0, false
+	}
+
+	return time.Duration(n) * unit, true
+}
+
+// sortedIntervals returns the keys of an interval mapping ordered from shortest to longest
+func sortedIntervals[V any](mapping map[string]V) []string {
+	intervals := make([]string, 0, len(mapping))
+	for interval := range mapping {
+		intervals = append(intervals, interval)
+	}
+
+	sort.Slice(intervals, func(i, j int) bool {
+		di, _ := IntervalDuration(intervals[i])
+		dj, _ := IntervalDuration(intervals[j])
+		return di < dj
+	})
+
+	return intervals
+}
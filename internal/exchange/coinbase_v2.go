@@ -2,24 +2,33 @@ package exchange
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+	"github.com/gorilla/websocket"
 	"golang.org/x/time/rate"
 )
 
-// CoinbaseV2Client implements the Exchange interface for Coinbase using REST API
+// CoinbaseV2Client implements the Exchange interface for Coinbase using REST API.
+// Spot prices come from the v2 API; 24h statistics and candles come from the
+// public Exchange API, which the v2 API does not provide.
 type CoinbaseV2Client struct {
-	httpClient *http.Client
-	limiter    *rate.Limiter
-	name       string
-	baseURL    string
+	httpClient  *http.Client
+	name        string
+	baseURL     string
+	exchangeURL string
+	wsURL       string
+	apiKey      string
+	apiSecret   string
 }
 
 // Coinbase API response structures
@@ -31,35 +40,136 @@ type coinbaseTickerResponse struct {
 	} `json:"data"`
 }
 
+// coinbaseProductTicker is the last trade and the best bid and ask on an Exchange API product
+type coinbaseProductTicker struct {
+	Price string    `json:"price"`
+	Bid   string    `json:"bid"`
+	Ask   string    `json:"ask"`
+	Time  time.Time `json:"time"`
+}
+
 type coinbaseStatsResponse struct {
 	Open   string `json:"open"`
 	High   string `json:"high"`
 	Low    string `json:"low"`
+	Last   string `json:"last"`
 	Volume string `json:"volume"`
 }
 
+// coinbaseSubscribeMessage subscribes to channels on the Exchange websocket feed
+type coinbaseSubscribeMessage struct {
+	Type       string   `json:"type"`
+	ProductIDs []string `json:"product_ids"`
+	Channels   []string `json:"channels"`
+}
+
+// coinbaseFeedMessage is the subset of feed messages used for price updates
+type coinbaseFeedMessage struct {
+	Type      string    `json:"type"`
+	ProductID string    `json:"product_id"`
+	Price     string    `json:"price"`
+	Time      time.Time `json:"time"`
+}
+
+// coinbaseBookResponse is the level 2 order book; rows are [price, size, num_orders]
+type coinbaseBookResponse struct {
+	Bids     [][]interface{} `json:"bids"`
+	Asks     [][]interface{} `json:"asks"`
+	Sequence int64           `json:"sequence"`
+	Time     time.Time       `json:"time"`
+}
+
+// coinbaseTrade is a public trade; Side is the maker order side
+type coinbaseTrade struct {
+	TradeID int64     `json:"trade_id"`
+	Price   string    `json:"price"`
+	Size    string    `json:"size"`
+	Side    string    `json:"side"`
+	Time    time.Time `json:"time"`
+}
+
+// coinbaseProduct is a market listed on the Exchange API
+type coinbaseProduct struct {
+	ID              string `json:"id"`
+	BaseCurrency    string `json:"base_currency"`
+	QuoteCurrency   string `json:"quote_currency"`
+	QuoteIncrement  string `json:"quote_increment"`
+	BaseIncrement   string `json:"base_increment"`
+	MinMarketFunds  string `json:"min_market_funds"`
+	Status          string `json:"status"`
+	TradingDisabled bool   `json:"trading_disabled"`
+}
+
+// coinbaseAccountsResponse is a page of v2 accounts, one per currency wallet
+type coinbaseAccountsResponse struct {
+	Pagination struct {
+		NextURI string `json:"next_uri"`
+	} `json:"pagination"`
+	Data []struct {
+		Balance struct {
+			Amount   string `json:"amount"`
+			Currency string `json:"currency"`
+		} `json:"balance"`
+	} `json:"data"`
+}
+
+// coinbaseAPIVersion is sent as CB-VERSION on signed v2 requests
+const coinbaseAPIVersion = "2024-01-01"
+
+// coinbaseGranularities maps Binance-style intervals to Coinbase candle granularities (seconds)
+var coinbaseGranularities = map[string]int{
+	"1m":  60,
+	"5m":  300,
+	"15m": 900,
+	"1h":  3600,
+	"6h":  21600,
+	"1d":  86400,
+}
+
+// coinbaseMaxCandles is the maximum number of candles returned by a single request
+const coinbaseMaxCandles = 300
+
 // NewCoinbaseV2Client creates a new Coinbase client using public API
 func NewCoinbaseV2Client(apiKey, apiSecret string) (*CoinbaseV2Client, error) {
-	httpClient := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
 	// Rate limit: 10 requests per second
 	limiter := rate.NewLimiter(rate.Limit(10), 10)
 
 	return &CoinbaseV2Client{
-		httpClient: httpClient,
-		limiter:    limiter,
-		name:       "coinbase",
-		baseURL:    "https://api.coinbase.com/v2",
+		httpClient:  newHTTPClient("coinbase", limiter),
+		name:        "coinbase",
+		baseURL:     "https://api.coinbase.com/v2",
+		exchangeURL: "https://api.exchange.coinbase.com",
+		wsURL:       "wss://ws-feed.exchange.coinbase.com",
+		apiKey:      apiKey,
+		apiSecret:   apiSecret,
 	}, nil
 }
 
+// SetBaseURLs overrides the v2 and Exchange API endpoints, e.g. to point the client at a test server
+func (c *CoinbaseV2Client) SetBaseURLs(baseURL, exchangeURL string) {
+	c.baseURL = strings.TrimRight(baseURL, "/")
+	c.exchangeURL = strings.TrimRight(exchangeURL, "/")
+}
+
 // GetName returns the exchange name
 func (c *CoinbaseV2Client) GetName() string {
 	return c.name
 }
 
+// Capabilities describes what the client supports
+func (c *CoinbaseV2Client) Capabilities() Capabilities {
+	return Capabilities{
+		Intervals:      sortedIntervals(coinbaseGranularities),
+		MaxCandleLimit: coinbaseMaxCandles,
+		Streaming:      true,
+		OrderBook:      true,
+		Trades:         true,
+		CandleHistory:  true,
+		Markets:        true,
+		Authenticated:  c.apiKey != "" && c.apiSecret != "",
+	}
+}
+
 // NormalizeSymbol converts a symbol like "BTC" to "BTC-USD" for Coinbase
 func (c *CoinbaseV2Client) NormalizeSymbol(symbol string) string {
 	// Convert to uppercase
@@ -71,9 +181,7 @@ func (c *CoinbaseV2Client) NormalizeSymbol(symbol string) string {
 
 	// If no quote currency, default to USD
 	if !strings.Contains(normalized, "-") {
-		if len(normalized) <= 5 {
-			normalized = normalized + "-USD"
-		}
+		normalized = normalized + "-USD"
 	}
 
 	return normalized
@@ -81,104 +189,396 @@ func (c *CoinbaseV2Client) NormalizeSymbol(symbol string) string {
 
 // GetPrice returns the current price for a symbol
 func (c *CoinbaseV2Client) GetPrice(ctx context.Context, symbol string) (float64, error) {
-	// Rate limiting
-	if err := c.limiter.Wait(ctx); err != nil {
-		return 0, fmt.Errorf("rate limit error: %w", err)
+	normalizedSymbol := c.NormalizeSymbol(symbol)
+
+	// Coinbase API uses format: BTC-USD for the pair
+	var result coinbaseTickerResponse
+	if err := c.getJSON(ctx, c.baseURL+"/prices/"+normalizedSymbol+"/spot", nil, &result); err != nil {
+		return 0, fmt.Errorf("failed to get price from Coinbase: %w", err)
 	}
 
-	normalizedSymbol := c.NormalizeSymbol(symbol)
+	price, err := strconv.ParseFloat(result.Data.Amount, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse price: %w", err)
+	}
 
-	// Retry logic
-	maxRetries := 3
-	var lastErr error
+	return price, nil
+}
 
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		if attempt > 0 {
-			time.Sleep(time.Duration(attempt) * time.Second)
-		}
+// getJSON performs a GET request against a Coinbase API endpoint and decodes the response into out
+func (c *CoinbaseV2Client) getJSON(ctx context.Context, endpoint string, params url.Values, out interface{}) error {
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
 
-		// Coinbase API uses format: BTC-USD for the pair
-		url := fmt.Sprintf("%s/prices/%s/spot", c.baseURL, normalizedSymbol)
+	return c.doJSON(req, out, statusError)
+}
 
-		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-		if err != nil {
-			lastErr = fmt.Errorf("failed to create request: %w", err)
-			continue
-		}
+// getSignedJSON performs a GET request signed with the API key, as private v2 endpoints require
+func (c *CoinbaseV2Client) getSignedJSON(ctx context.Context, endpoint string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
 
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			lastErr = err
-			continue
-		}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("CB-ACCESS-KEY", c.apiKey)
+	req.Header.Set("CB-ACCESS-SIGN", coinbaseSignature(c.apiSecret, timestamp, req.Method, req.URL.RequestURI(), ""))
+	req.Header.Set("CB-ACCESS-TIMESTAMP", timestamp)
+	req.Header.Set("CB-VERSION", coinbaseAPIVersion)
 
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			resp.Body.Close()
-			lastErr = fmt.Errorf("status %d: %s", resp.StatusCode, string(body))
-			continue
-		}
+	return c.doJSON(req, out, signedStatusError)
+}
 
-		var result coinbaseTickerResponse
-		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-			resp.Body.Close()
-			lastErr = fmt.Errorf("failed to decode response: %w", err)
-			continue
-		}
-		resp.Body.Close()
+// coinbaseSignature signs a request for the CB-ACCESS-SIGN header: the hex HMAC-SHA256,
+// keyed with the API secret, of the timestamp, method, request path (with query) and body
+func coinbaseSignature(secret, timestamp, method, requestPath, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + method + requestPath + body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
 
-		price, err := strconv.ParseFloat(result.Data.Amount, 64)
-		if err != nil {
-			lastErr = fmt.Errorf("failed to parse price: %w", err)
-			continue
-		}
+// doJSON sends req and decodes a successful response into out; classify turns
+// any other response into an error
+func (c *CoinbaseV2Client) doJSON(req *http.Request, out interface{}, classify func(string, *http.Response) error) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return networkError(c.name, err)
+	}
+	defer resp.Body.Close()
 
-		return price, nil
+	if resp.StatusCode != http.StatusOK {
+		return classify(c.name, resp)
 	}
 
-	return 0, fmt.Errorf("failed after %d retries: %w", maxRetries, lastErr)
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
 }
 
 // GetTicker returns detailed market data for a symbol
 func (c *CoinbaseV2Client) GetTicker(ctx context.Context, symbol string) (*models.Ticker, error) {
-	// Rate limiting
-	if err := c.limiter.Wait(ctx); err != nil {
-		return nil, fmt.Errorf("rate limit error: %w", err)
+	normalizedSymbol := c.NormalizeSymbol(symbol)
+
+	// The 24h stats carry no time, so the price and its time come from the last trade
+	var last coinbaseProductTicker
+	if err := c.getJSON(ctx, c.exchangeURL+"/products/"+normalizedSymbol+"/ticker", nil, &last); err != nil {
+		return nil, fmt.Errorf("failed to get ticker from Coinbase: %w", err)
 	}
 
-	normalizedSymbol := c.NormalizeSymbol(symbol)
+	var stats coinbaseStatsResponse
+	if err := c.getJSON(ctx, c.exchangeURL+"/products/"+normalizedSymbol+"/stats", nil, &stats); err != nil {
+		return nil, fmt.Errorf("failed to get ticker from Coinbase: %w", err)
+	}
 
-	// Get current price
-	currentPrice, err := c.GetPrice(ctx, symbol)
+	price, err := strconv.ParseFloat(last.Price, 64)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to parse price: %w", err)
 	}
-
-	// Get 24h stats - we'll use a simplified approach
-	// Coinbase v2 API doesn't provide as detailed stats as Pro
-	// We'll calculate approximate values
+	open, _ := strconv.ParseFloat(stats.Open, 64)
+	high, _ := strconv.ParseFloat(stats.High, 64)
+	low, _ := strconv.ParseFloat(stats.Low, 64)
+	volume, _ := strconv.ParseFloat(stats.Volume, 64)
+	bid, _ := strconv.ParseFloat(last.Bid, 64)
+	ask, _ := strconv.ParseFloat(last.Ask, 64)
 
 	return &models.Ticker{
 		Symbol:      normalizedSymbol,
-		Price:       currentPrice,
-		Change24h:   0, // Would need historical data
-		Volume24h:   0, // Not available in simple API
-		High24h:     currentPrice * 1.05, // Approximate
-		Low24h:      currentPrice * 0.95, // Approximate
-		LastUpdated: time.Now(),
+		Price:       price,
+		Change24h:   price - open,
+		Volume24h:   volume,
+		High24h:     high,
+		Low24h:      low,
+		LastUpdated: last.Time,
+		Bid:         bid,
+		Ask:         ask,
 	}, nil
 }
 
+// SubscribeTickers streams last prices from the Coinbase Exchange ticker channel
+func (c *CoinbaseV2Client) SubscribeTickers(ctx context.Context, symbols []string) (<-chan models.PriceUpdate, error) {
+	productIDs := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		productIDs[i] = c.NormalizeSymbol(symbol)
+	}
+
+	cfg := streamConfig{
+		url: c.wsURL,
+		subscribe: func(conn *websocket.Conn) error {
+			// The heartbeat channel keeps quiet products from tripping the read timeout
+			return conn.WriteJSON(coinbaseSubscribeMessage{
+				Type:       "subscribe",
+				ProductIDs: productIDs,
+				Channels:   []string{"ticker", "heartbeat"},
+			})
+		},
+		parse: func(message []byte) ([]models.PriceUpdate, error) {
+			var msg coinbaseFeedMessage
+			if err := json.Unmarshal(message, &msg); err != nil {
+				return nil, err
+			}
+
+			if msg.Type != "ticker" {
+				return nil, nil
+			}
+
+			price, err := strconv.ParseFloat(msg.Price, 64)
+			if err != nil {
+				return nil, err
+			}
+
+			return []models.PriceUpdate{{
+				Symbol:    msg.ProductID,
+				Price:     price,
+				Timestamp: msg.Time,
+			}}, nil
+		},
+	}
+
+	return startStream(ctx, cfg)
+}
+
+// GetPrices returns the current prices for several symbols. Coinbase has no
+// batch endpoint, so each symbol is a request of its own.
+func (c *CoinbaseV2Client) GetPrices(ctx context.Context, symbols []string) []Result[float64] {
+	return EachSymbol(ctx, symbols, c.GetPrice)
+}
+
+// GetTickers returns detailed market data for several symbols, one request each
+func (c *CoinbaseV2Client) GetTickers(ctx context.Context, symbols []string) []Result[*models.Ticker] {
+	return EachSymbol(ctx, symbols, c.GetTicker)
+}
+
 // GetCandles returns historical OHLCV data
 func (c *CoinbaseV2Client) GetCandles(ctx context.Context, symbol, interval string, limit int) ([]models.Candle, error) {
-	// Rate limiting
-	if err := c.limiter.Wait(ctx); err != nil {
-		return nil, fmt.Errorf("rate limit error: %w", err)
+	granularity, ok := coinbaseGranularities[interval]
+	if !ok {
+		return nil, &UnsupportedIntervalError{Exchange: c.name, Interval: interval}
 	}
 
-	// Coinbase v2 API doesn't support candles directly
-	// Would need to use Exchange Rates API or historical prices
-	// For now, return empty array
+	if limit <= 0 || limit > coinbaseMaxCandles {
+		limit = coinbaseMaxCandles
+	}
+
+	// Request exactly the window that holds the last `limit` candles
+	end := time.Now().UTC()
+	start := end.Add(-time.Duration(granularity*limit) * time.Second)
+
+	candles, err := c.getCandles(ctx, symbol, interval, granularity, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(candles) > limit {
+		candles = candles[len(candles)-limit:]
+	}
+
+	return candles, nil
+}
+
+// GetCandleRange returns the candles opening within [start, end), oldest first
+func (c *CoinbaseV2Client) GetCandleRange(ctx context.Context, symbol, interval string, start, end time.Time) ([]models.Candle, error) {
+	granularity, ok := coinbaseGranularities[interval]
+	if !ok {
+		return nil, &UnsupportedIntervalError{Exchange: c.name, Interval: interval}
+	}
+
+	// The end bound is inclusive on Coinbase; stop short of it so that a full
+	// window does not exceed the per-request candle limit
+	candles, err := c.getCandles(ctx, symbol, interval, granularity, start, end.Add(-time.Second))
+	if err != nil {
+		return nil, err
+	}
+
+	return filterCandleRange(candles, start, end), nil
+}
+
+// getCandles fetches the candles between start and end in chronological order.
+// Coinbase does not report quote volume, so it is left at zero.
+func (c *CoinbaseV2Client) getCandles(ctx context.Context, symbol, interval string, granularity int, start, end time.Time) ([]models.Candle, error) {
+	normalizedSymbol := c.NormalizeSymbol(symbol)
+	params := url.Values{
+		"granularity": {strconv.Itoa(granularity)},
+		"start":       {start.UTC().Format(time.RFC3339)},
+		"end":         {end.UTC().Format(time.RFC3339)},
+	}
+
+	// Each row is [time, low, high, open, close, volume]
+	var rows [][]float64
+	if err := c.getJSON(ctx, c.exchangeURL+"/products/"+normalizedSymbol+"/candles", params, &rows); err != nil {
+		return nil, fmt.Errorf("failed to get candles from Coinbase: %w", err)
+	}
+
+	// Coinbase returns the newest candle first; callers expect chronological order
+	candles := make([]models.Candle, 0, len(rows))
+	for i := len(rows) - 1; i >= 0; i-- {
+		row := rows[i]
+		if len(row) < 6 {
+			continue
+		}
+
+		open := time.Unix(int64(row[0]), 0)
+		candles = append(candles, models.Candle{
+			Time:      open,
+			CloseTime: candleCloseTime(open, interval),
+			Low:       row[1],
+			High:      row[2],
+			Open:      row[3],
+			Close:     row[4],
+			Volume:    row[5],
+		})
+	}
+
+	return candles, nil
+}
+
+// GetOrderBook returns up to depth price levels on each side of the book
+func (c *CoinbaseV2Client) GetOrderBook(ctx context.Context, symbol string, depth int) (*models.OrderBook, error) {
+	normalizedSymbol := c.NormalizeSymbol(symbol)
+
+	// Level 2 returns the full aggregated book, which is trimmed to depth below
+	var res coinbaseBookResponse
+	if err := c.getJSON(ctx, c.exchangeURL+"/products/"+normalizedSymbol+"/book", url.Values{"level": {"2"}}, &res); err != nil {
+		return nil, fmt.Errorf("failed to get order book from Coinbase: %w", err)
+	}
+
+	timestamp := res.Time
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
 
-	return []models.Candle{}, fmt.Errorf("historical candles not available in Coinbase v2 API")
+	return &models.OrderBook{
+		Symbol:    normalizedSymbol,
+		Bids:      truncateLevels(parseLevels(res.Bids), depth),
+		Asks:      truncateLevels(parseLevels(res.Asks), depth),
+		UpdateID:  res.Sequence,
+		Timestamp: timestamp,
+	}, nil
+}
+
+// GetRecentTrades returns up to limit of the latest trades, oldest first
+func (c *CoinbaseV2Client) GetRecentTrades(ctx context.Context, symbol string, limit int) ([]models.Trade, error) {
+	normalizedSymbol := c.NormalizeSymbol(symbol)
+
+	var res []coinbaseTrade
+	if err := c.getJSON(ctx, c.exchangeURL+"/products/"+normalizedSymbol+"/trades", url.Values{"limit": {strconv.Itoa(limit)}}, &res); err != nil {
+		return nil, fmt.Errorf("failed to get trades from Coinbase: %w", err)
+	}
+
+	// Coinbase returns the newest trade first
+	trades := make([]models.Trade, 0, len(res))
+	for i := len(res) - 1; i >= 0; i-- {
+		t := res[i]
+		price, _ := strconv.ParseFloat(t.Price, 64)
+		quantity, _ := strconv.ParseFloat(t.Size, 64)
+
+		// The reported side is the maker's, so the aggressor took the other side
+		side := models.SideSell
+		if t.Side == "sell" {
+			side = models.SideBuy
+		}
+
+		trades = append(trades, models.Trade{
+			ID:       strconv.FormatInt(t.TradeID, 10),
+			Symbol:   normalizedSymbol,
+			Price:    price,
+			Quantity: quantity,
+			Side:     side,
+			Time:     t.Time,
+		})
+	}
+
+	return trades, nil
+}
+
+// ListMarkets returns every spot market listed on the exchange
+func (c *CoinbaseV2Client) ListMarkets(ctx context.Context) ([]models.Market, error) {
+	var products []coinbaseProduct
+	if err := c.getJSON(ctx, c.exchangeURL+"/products", nil, &products); err != nil {
+		return nil, fmt.Errorf("failed to get products from Coinbase: %w", err)
+	}
+
+	markets := make([]models.Market, 0, len(products))
+	for _, p := range products {
+		status := models.MarketStatusHalted
+		if p.Status == "online" && !p.TradingDisabled {
+			status = models.MarketStatusTrading
+		}
+
+		tickSize, _ := strconv.ParseFloat(p.QuoteIncrement, 64)
+		lotSize, _ := strconv.ParseFloat(p.BaseIncrement, 64)
+		minNotional, _ := strconv.ParseFloat(p.MinMarketFunds, 64)
+
+		markets = append(markets, models.Market{
+			Symbol:      p.ID,
+			Base:        p.BaseCurrency,
+			Quote:       p.QuoteCurrency,
+			Status:      status,
+			TickSize:    tickSize,
+			LotSize:     lotSize,
+			MinNotional: minNotional,
+		})
+	}
+
+	return markets, nil
+}
+
+// GetBalances returns every currency with a non-zero balance in the account (signed v2 accounts).
+// The v2 API does not report holds, so everything is counted as free.
+func (c *CoinbaseV2Client) GetBalances(ctx context.Context) ([]models.Balance, error) {
+	if c.apiKey == "" || c.apiSecret == "" {
+		return nil, errNoCredentials(c.name)
+	}
+
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+
+	// A currency can have several wallets; add them up
+	totals := make(map[string]float64)
+	var order []string
+
+	endpoint := c.baseURL + "/accounts?limit=100"
+	for endpoint != "" {
+		var page coinbaseAccountsResponse
+		if err := c.getSignedJSON(ctx, endpoint, &page); err != nil {
+			return nil, fmt.Errorf("failed to get accounts from Coinbase: %w", err)
+		}
+
+		for _, account := range page.Data {
+			amount, _ := strconv.ParseFloat(account.Balance.Amount, 64)
+			if amount == 0 {
+				continue
+			}
+			currency := account.Balance.Currency
+			if _, ok := totals[currency]; !ok {
+				order = append(order, currency)
+			}
+			totals[currency] += amount
+		}
+
+		// next_uri is a path from the API root, e.g. /v2/accounts?starting_after=...
+		endpoint = ""
+		if page.Pagination.NextURI != "" {
+			next, err := url.Parse(page.Pagination.NextURI)
+			if err != nil {
+				return nil, fmt.Errorf("invalid next page %q: %w", page.Pagination.NextURI, err)
+			}
+			endpoint = base.ResolveReference(next).String()
+		}
+	}
+
+	balances := make([]models.Balance, len(order))
+	for i, currency := range order {
+		balances[i] = models.Balance{Asset: currency, Free: totals[currency]}
+	}
+	return balances, nil
 }
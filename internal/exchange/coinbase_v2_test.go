@@ -0,0 +1,165 @@
+package exchange
+
+import (
+	"context"
+	"crypto/hmac"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newCoinbaseTestClient returns a Coinbase client whose v2 and Exchange APIs are served by handler
+func newCoinbaseTestClient(t *testing.T, apiKey, apiSecret string, handler http.HandlerFunc) *CoinbaseV2Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewCoinbaseV2Client(apiKey, apiSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetBaseURLs(server.URL+"/v2", server.URL)
+	return client
+}
+
+// verifyCoinbaseSignature rejects a request whose CB-ACCESS-SIGN does not match
+// the HMAC of its timestamp, method, request URI and body under secret
+func verifyCoinbaseSignature(t *testing.T, w http.ResponseWriter, r *http.Request, apiKey, secret string) bool {
+	t.Helper()
+
+	body, _ := io.ReadAll(r.Body)
+	want := coinbaseSignature(secret, r.Header.Get("CB-ACCESS-TIMESTAMP"), r.Method, r.URL.RequestURI(), string(body))
+
+	if r.Header.Get("CB-ACCESS-KEY") != apiKey || r.Header.Get("CB-VERSION") == "" ||
+		!hmac.Equal([]byte(r.Header.Get("CB-ACCESS-SIGN")), []byte(want)) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"errors":[{"id":"authentication_error","message":"invalid signature"}]}`)
+		return false
+	}
+	return true
+}
+
+func TestCoinbaseGetBalances(t *testing.T) {
+	pages := 0
+	client := newCoinbaseTestClient(t, "key", "secret", func(w http.ResponseWriter, r *http.Request) {
+		if !verifyCoinbaseSignature(t, w, r, "key", "secret") {
+			t.Errorf("signature rejected for %s", r.URL.RequestURI())
+			return
+		}
+		if r.URL.Path != "/v2/accounts" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+
+		pages++
+		switch r.URL.Query().Get("starting_after") {
+		case "":
+			fmt.Fprint(w, `{
+				"pagination": {"next_uri": "/v2/accounts?limit=100&starting_after=page2"},
+				"data": [
+					{"balance": {"amount": "0.5", "currency": "BTC"}},
+					{"balance": {"amount": "0.0", "currency": "DOGE"}},
+					{"balance": {"amount": "100", "currency": "USD"}}
+				]
+			}`)
+		case "page2":
+			fmt.Fprint(w, `{
+				"pagination": {"next_uri": null},
+				"data": [
+					{"balance": {"amount": "0.25", "currency": "BTC"}},
+					{"balance": {"amount": "2", "currency": "ETH"}}
+				]
+			}`)
+		default:
+			t.Errorf("unexpected page %s", r.URL.RawQuery)
+		}
+	})
+
+	balances, err := client.GetBalances(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pages != 2 {
+		t.Errorf("fetched %d pages, want 2", pages)
+	}
+
+	want := map[string]float64{"BTC": 0.75, "USD": 100, "ETH": 2}
+	if len(balances) != len(want) {
+		t.Fatalf("balances = %+v, want %v", balances, want)
+	}
+	for _, b := range balances {
+		if b.Free != want[b.Asset] || b.Locked != 0 {
+			t.Errorf("%s balance = %+v, want free %v", b.Asset, b, want[b.Asset])
+		}
+	}
+}
+
+func TestCoinbaseGetBalancesWrongSecret(t *testing.T) {
+	client := newCoinbaseTestClient(t, "key", "wrong", func(w http.ResponseWriter, r *http.Request) {
+		verifyCoinbaseSignature(t, w, r, "key", "secret")
+	})
+
+	if _, err := client.GetBalances(context.Background()); !errors.Is(err, ErrAuth) {
+		t.Errorf("err = %v, want ErrAuth", err)
+	}
+}
+
+func TestCoinbaseGetBalancesNoCredentials(t *testing.T) {
+	client := newCoinbaseTestClient(t, "", "", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected")
+	})
+
+	if _, err := client.GetBalances(context.Background()); !errors.Is(err, ErrAuth) {
+		t.Errorf("err = %v, want ErrAuth", err)
+	}
+}
+
+func TestCoinbaseGetBalancesBadRequest(t *testing.T) {
+	client := newCoinbaseTestClient(t, "key", "secret", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"errors":[{"id":"invalid_request","message":"bad limit"}]}`)
+	})
+
+	_, err := client.GetBalances(context.Background())
+	if err == nil || errors.Is(err, ErrUnknownSymbol) || errors.Is(err, ErrAuth) {
+		t.Errorf("err = %v, want an unclassified request error", err)
+	}
+}
+
+func TestCoinbaseGetTickersInParallel(t *testing.T) {
+	var requests inFlight
+	client := newCoinbaseTestClient(t, "", "", func(w http.ResponseWriter, r *http.Request) {
+		requests.enter()
+		defer requests.leave()
+		time.Sleep(10 * time.Millisecond)
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/ticker"):
+			fmt.Fprintf(w, `{"price":"100","bid":"99.5","ask":"100.5","time":%q}`, time.Now().Format(time.RFC3339Nano))
+		case strings.HasSuffix(r.URL.Path, "/stats"):
+			fmt.Fprint(w, `{"open":"90","high":"110","low":"80","last":"100","volume":"5"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	symbols := []string{"BTC", "ETH", "SOL", "ADA", "DOT", "XRP", "LTC", "AVAX"}
+	for i, r := range client.GetTickers(context.Background(), symbols) {
+		if r.Err != nil {
+			t.Fatalf("%s: %v", symbols[i], r.Err)
+		}
+		if want := client.NormalizeSymbol(symbols[i]); r.Value.Symbol != want || r.Value.Change24h != 10 ||
+			r.Value.Bid != 99.5 || r.Value.Ask != 100.5 {
+			t.Errorf("ticker %d = %+v, want %s up 10, bid 99.5 and ask 100.5", i, r.Value, want)
+		}
+	}
+
+	if got := requests.max(); got < 2 || got > maxSymbolFetches {
+		t.Errorf("%d requests in flight at once, want between 2 and %d", got, maxSymbolFetches)
+	}
+}
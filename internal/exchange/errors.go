@@ -0,0 +1,198 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adshao/go-binance/v2/common"
+)
+
+// Sentinel errors classifying why an exchange call failed. Every error returned by
+// the exchange clients that falls into one of these classes matches it with errors.Is.
+var (
+	// ErrUnknownSymbol means the exchange does not list the requested market
+	ErrUnknownSymbol = errors.New("unknown symbol")
+
+	// ErrRateLimited means the exchange rejected the request for exceeding its rate limits
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrUnavailable means the exchange could not be reached or failed to serve the request
+	ErrUnavailable = errors.New("exchange unavailable")
+
+	// ErrUnsupported means the exchange does not offer the requested feature
+	ErrUnsupported = errors.New("not supported")
+
+	// ErrAuth means the exchange rejected the API credentials
+	ErrAuth = errors.New("authentication failed")
+)
+
+// Error is a classified exchange error. Kind is one of the sentinel errors above
+// and Err is the underlying cause; both match with errors.Is and errors.As.
+type Error struct {
+	Exchange string
+	Kind     error
+
+	// RetryAfter is how long the exchange asked us to wait (rate limits only, zero if unknown)
+	RetryAfter time.Duration
+
+	Err error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v: %v", e.Kind, e.Err)
+}
+
+func (e *Error) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// RetryAfter returns how long to wait before retrying a rate-limited request
+func RetryAfter(err error) (time.Duration, bool) {
+	var e *Error
+	if errors.As(err, &e) && errors.Is(e.Kind, ErrRateLimited) && e.RetryAfter > 0 {
+		return e.RetryAfter, true
+	}
+	return 0, false
+}
+
+// Is makes UnsupportedIntervalError match ErrUnsupported
+func (e *UnsupportedIntervalError) Is(target error) bool {
+	return target == ErrUnsupported
+}
+
+// newError classifies err as kind for the named exchange
+func newError(exchangeName string, kind, err error) *Error {
+	return &Error{Exchange: exchangeName, Kind: kind, Err: err}
+}
+
+// errNoCredentials is returned by private endpoints when no API credentials are configured
+func errNoCredentials(exchangeName string) error {
+	return newError(exchangeName, ErrAuth, fmt.Errorf("no API credentials configured for %s", exchangeName))
+}
+
+// networkError classifies a failed HTTP round trip. Cancellation is left as is so
+// callers can tell a user abort from an outage.
+func networkError(exchangeName string, err error) error {
+	var classified *Error
+	if errors.Is(err, context.Canceled) || errors.As(err, &classified) {
+		return err
+	}
+	return newError(exchangeName, ErrUnavailable, err)
+}
+
+// statusError classifies a non-2xx HTTP response from a market data endpoint.
+// It reads (but does not close) the response body.
+func statusError(exchangeName string, resp *http.Response) error {
+	cause := readStatus(resp)
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusBadRequest {
+		// Market data paths only vary by symbol, so a bad request means a bad symbol
+		return newError(exchangeName, ErrUnknownSymbol, cause)
+	}
+	return classifyStatus(exchangeName, resp, cause)
+}
+
+// signedStatusError classifies a non-2xx HTTP response from a signed account or
+// trading endpoint, where a bad request or a missing path says nothing about the
+// symbol. It reads (but does not close) the response body.
+func signedStatusError(exchangeName string, resp *http.Response) error {
+	return classifyStatus(exchangeName, resp, readStatus(resp))
+}
+
+// readStatus reads a failed response into an error carrying its status and body
+func readStatus(resp *http.Response) error {
+	body, _ := io.ReadAll(resp.Body)
+	return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// classifyStatus classifies the statuses that mean the same on every endpoint;
+// any other status is returned as cause, unclassified
+func classifyStatus(exchangeName string, resp *http.Response, cause error) error {
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		e := newError(exchangeName, ErrRateLimited, cause)
+		e.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+		return e
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return newError(exchangeName, ErrAuth, cause)
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return newError(exchangeName, ErrUnavailable, cause)
+	default:
+		return cause
+	}
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		if d := time.Until(at); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
+
+// binanceError classifies an error returned by the go-binance client for the
+// named exchange, which is the testnet for a testnet client
+func binanceError(exchangeName string, err error) error {
+	// Errors raised by the shared transport are already classified
+	var classified *Error
+	if errors.Is(err, context.Canceled) || errors.As(err, &classified) {
+		return err
+	}
+
+	var apiErr *common.APIError
+	if !errors.As(err, &apiErr) {
+		// Anything that is not an API response is a transport failure
+		return newError(exchangeName, ErrUnavailable, err)
+	}
+
+	switch apiErr.Code {
+	case -1121, -1100:
+		return newError(exchangeName, ErrUnknownSymbol, err)
+	case -1003, -1015:
+		return newError(exchangeName, ErrRateLimited, err)
+	case -1002, -1022, -2014, -2015:
+		return newError(exchangeName, ErrAuth, err)
+	case 0, -1000, -1001, -1006, -1007, -1008, -1016:
+		// Code 0 covers non-JSON failures such as gateway errors and regional blocks
+		return newError(exchangeName, ErrUnavailable, err)
+	default:
+		return err
+	}
+}
+
+// okxError classifies an error code returned in an OKX response envelope
+func okxError(exchangeName, code, msg string) error {
+	cause := fmt.Errorf("okx error %s: %s", code, msg)
+
+	switch code {
+	case "51001", "51000":
+		return newError(exchangeName, ErrUnknownSymbol, cause)
+	case "50011", "50061":
+		return newError(exchangeName, ErrRateLimited, cause)
+	case "50001", "50004", "50013", "50026":
+		return newError(exchangeName, ErrUnavailable, cause)
+	}
+
+	// 501xx codes report API key, signature and permission problems
+	if strings.HasPrefix(code, "501") {
+		return newError(exchangeName, ErrAuth, cause)
+	}
+
+	return cause
+}
@@ -0,0 +1,63 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		status int
+		market error
+		signed error
+	}{
+		{http.StatusBadRequest, ErrUnknownSymbol, nil},
+		{http.StatusNotFound, ErrUnknownSymbol, nil},
+		{http.StatusUnauthorized, ErrAuth, ErrAuth},
+		{http.StatusForbidden, ErrAuth, ErrAuth},
+		{http.StatusTooManyRequests, ErrRateLimited, ErrRateLimited},
+		{http.StatusServiceUnavailable, ErrUnavailable, ErrUnavailable},
+		{http.StatusConflict, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.status), func(t *testing.T) {
+			for _, c := range []struct {
+				name     string
+				classify func(string, *http.Response) error
+				want     error
+			}{
+				{"market data", statusError, tt.market},
+				{"signed", signedStatusError, tt.signed},
+			} {
+				rec := httptest.NewRecorder()
+				rec.Header().Set("Retry-After", "7")
+				rec.WriteHeader(tt.status)
+				fmt.Fprint(rec, `{"message":"failed"}`)
+
+				err := c.classify("test", rec.Result())
+
+				var e *Error
+				if c.want == nil {
+					if errors.As(err, &e) {
+						t.Errorf("%s: err = %v, want an unclassified error", c.name, err)
+					}
+					continue
+				}
+				if !errors.Is(err, c.want) {
+					t.Errorf("%s: err = %v, want %v", c.name, err, c.want)
+				}
+				if !errors.As(err, &e) || e.Exchange != "test" {
+					t.Errorf("%s: err = %v, want a *Error from test", c.name, err)
+				}
+				if c.want == ErrRateLimited && e.RetryAfter != 7*time.Second {
+					t.Errorf("%s: RetryAfter = %v, want 7s", c.name, e.RetryAfter)
+				}
+			}
+		})
+	}
+}
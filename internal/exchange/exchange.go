@@ -3,6 +3,10 @@ package exchange
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/Carpe-Wang/terminalCrypto/internal/models"
 )
@@ -15,6 +19,13 @@ type Exchange interface {
 	// GetTicker returns detailed market data for a symbol
 	GetTicker(ctx context.Context, symbol string) (*models.Ticker, error)
 
+	// GetPrices returns the current prices for several symbols, in the order given.
+	// Exchanges without a batch endpoint fetch them symbol by symbol with EachSymbol.
+	GetPrices(ctx context.Context, symbols []string) []Result[float64]
+
+	// GetTickers returns detailed market data for several symbols, in the order given
+	GetTickers(ctx context.Context, symbols []string) []Result[*models.Ticker]
+
 	// GetCandles returns historical OHLCV data
 	GetCandles(ctx context.Context, symbol, interval string, limit int) ([]models.Candle, error)
 
@@ -23,6 +34,151 @@ type Exchange interface {
 
 	// GetName returns the exchange name
 	GetName() string
+
+	// Capabilities describes what the client supports
+	Capabilities() Capabilities
+}
+
+// Result is the outcome for one symbol of a batch request
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// maxSymbolFetches bounds the requests EachSymbol has in flight at once
+const maxSymbolFetches = 4
+
+// EachSymbol fetches symbols with one request each, for exchanges without a
+// batch endpoint. A small pool of workers keeps a few requests in flight at
+// once, so a long list does not take a round trip per symbol.
+func EachSymbol[T any](ctx context.Context, symbols []string, fetch func(context.Context, string) (T, error)) []Result[T] {
+	results := make([]Result[T], len(symbols))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(maxSymbolFetches, len(symbols)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i].Value, results[i].Err = fetch(ctx, symbols[i])
+			}
+		}()
+	}
+
+	for i := range symbols {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// failAll returns a result for every symbol carrying the error of a failed batch request
+func failAll[T any](n int, err error) []Result[T] {
+	results := make([]Result[T], n)
+	for i := range results {
+		results[i].Err = err
+	}
+	return results
+}
+
+// SupportedExchanges lists the exchanges Factory can create
+var SupportedExchanges = []string{"binance", "coinbase", "okx"}
+
+// OrderBookProvider is implemented by exchanges that expose order book depth
+type OrderBookProvider interface {
+	// GetOrderBook returns up to depth price levels on each side of the book
+	GetOrderBook(ctx context.Context, symbol string, depth int) (*models.OrderBook, error)
+}
+
+// TradesProvider is implemented by exchanges that expose recent public trades
+type TradesProvider interface {
+	// GetRecentTrades returns up to limit of the latest trades, oldest first
+	GetRecentTrades(ctx context.Context, symbol string, limit int) ([]models.Trade, error)
+}
+
+// MarketLister is implemented by exchanges that can list their markets
+type MarketLister interface {
+	// ListMarkets returns every spot market listed on the exchange
+	ListMarkets(ctx context.Context) ([]models.Market, error)
+}
+
+// MarketAware is implemented by exchanges whose NormalizeSymbol can consult the
+// markets they list. Until it has them, a client splits a bare symbol on a known
+// quote currency suffix, a heuristic that misreads assets named after a quote,
+// e.g. WBETH as WB/ETH, STETH as ST/ETH or AEUR as A/EUR.
+type MarketAware interface {
+	// SetMarkets gives the client a lookup of its listed markets by base and
+	// quote, ignoring case and separators, e.g. "ETHBTC" or "ETH/BTC"
+	SetMarkets(pair func(symbol string) (models.Market, bool))
+}
+
+// CandleHistoryProvider is implemented by exchanges that can fetch candles for a time range
+type CandleHistoryProvider interface {
+	// GetCandleRange returns the candles opening within [start, end), oldest first.
+	// The range may span at most Capabilities().MaxCandleLimit candles; use
+	// FetchCandleHistory for longer ranges.
+	GetCandleRange(ctx context.Context, symbol, interval string, start, end time.Time) ([]models.Candle, error)
+}
+
+// AccountProvider is implemented by exchanges that can read the balances of the
+// account the API credentials belong to
+type AccountProvider interface {
+	// GetBalances returns every asset with a non-zero balance
+	GetBalances(ctx context.Context) ([]models.Balance, error)
+}
+
+// Trader is implemented by exchanges that can place and manage orders with the
+// account the API credentials belong to
+type Trader interface {
+	// PlaceOrder submits an order and returns it as accepted by the exchange
+	PlaceOrder(ctx context.Context, req models.OrderRequest) (*models.Order, error)
+
+	// CancelOrder cancels an open order
+	CancelOrder(ctx context.Context, symbol, orderID string) (*models.Order, error)
+
+	// ListOpenOrders returns the open orders for symbol, or for every symbol if it is empty
+	ListOpenOrders(ctx context.Context, symbol string) ([]models.Order, error)
+
+	// GetOrder returns the current state of an order
+	GetOrder(ctx context.Context, symbol, orderID string) (*models.Order, error)
+}
+
+// Wrapper is implemented by exchanges that decorate another one, such as PaperExchange
+type Wrapper interface {
+	// Unwrap returns the wrapped exchange
+	Unwrap() Exchange
+}
+
+// As returns the first exchange in client's chain of wrappers that implements T,
+// so that the optional features of a wrapped exchange stay reachable
+func As[T any](client Exchange) (T, bool) {
+	for client != nil {
+		if t, ok := client.(T); ok {
+			return t, true
+		}
+
+		wrapper, ok := client.(Wrapper)
+		if !ok {
+			break
+		}
+		client = wrapper.Unwrap()
+	}
+
+	var zero T
+	return zero, false
+}
+
+// UnsupportedIntervalError is returned when an exchange cannot serve candles for an interval
+type UnsupportedIntervalError struct {
+	Exchange string
+	Interval string
+}
+
+func (e *UnsupportedIntervalError) Error() string {
+	return fmt.Sprintf("interval %q is not supported by %s", e.Interval, e.Exchange)
 }
 
 // Factory creates an exchange client based on the exchange name
@@ -33,8 +189,70 @@ func Factory(exchangeName, apiKey, apiSecret string) (Exchange, error) {
 	case "coinbase":
 		return NewCoinbaseV2Client(apiKey, apiSecret)
 	case "okx":
-		return nil, fmt.Errorf("okx support coming soon")
+		return NewOKXClient(apiKey, apiSecret)
 	default:
-		return nil, fmt.Errorf("unsupported exchange: %s (supported: binance, coinbase, okx)", exchangeName)
+		return nil, fmt.Errorf("unsupported exchange: %s (supported: %s)", exchangeName, strings.Join(SupportedExchanges, ", "))
+	}
+}
+
+// parseLevels converts [price, quantity, ...] string rows into order book levels
+func parseLevels(rows [][]interface{}) []models.OrderBookLevel {
+	levels := make([]models.OrderBookLevel, 0, len(rows))
+	for _, row := range rows {
+		if len(row) < 2 {
+			continue
+		}
+
+		priceStr, _ := row[0].(string)
+		quantityStr, _ := row[1].(string)
+		price, err := strconv.ParseFloat(priceStr, 64)
+		if err != nil {
+			continue
+		}
+		quantity, _ := strconv.ParseFloat(quantityStr, 64)
+
+		levels = append(levels, models.OrderBookLevel{Price: price, Quantity: quantity})
+	}
+	return levels
+}
+
+// truncateLevels keeps at most depth levels
+func truncateLevels(levels []models.OrderBookLevel, depth int) []models.OrderBookLevel {
+	if depth > 0 && len(levels) > depth {
+		return levels[:depth]
+	}
+	return levels
+}
+
+// listedMarkets holds the market lookup given to a MarketAware client
+type listedMarkets struct {
+	mu   sync.RWMutex
+	pair func(symbol string) (models.Market, bool)
+}
+
+func (l *listedMarkets) set(pair func(symbol string) (models.Market, bool)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pair = pair
+}
+
+// split reads a symbol without separators as a listed market: an asset listed
+// against defaultQuote such as "WBETH", or else a pair such as "ETHBTC". The
+// asset comes first, so that WBTC is wrapped bitcoin even where W/BTC is listed.
+// It reports false when no markets are set or neither reading is listed.
+func (l *listedMarkets) split(symbol, defaultQuote string) (base, quote string, ok bool) {
+	l.mu.RLock()
+	pair := l.pair
+	l.mu.RUnlock()
+
+	if pair == nil {
+		return "", "", false
+	}
+	if m, ok := pair(symbol + "/" + defaultQuote); ok {
+		return m.Base, m.Quote, true
+	}
+	if m, ok := pair(symbol); ok {
+		return m.Base, m.Quote, true
 	}
+	return "", "", false
 }
@@ -0,0 +1,81 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// inFlight tracks how many calls run at once
+type inFlight struct {
+	mu       sync.Mutex
+	current  int
+	observed int
+}
+
+func (f *inFlight) enter() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.current++
+	f.observed = max(f.observed, f.current)
+}
+
+func (f *inFlight) leave() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.current--
+}
+
+func (f *inFlight) max() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.observed
+}
+
+func TestEachSymbol(t *testing.T) {
+	symbols := []string{"BTC", "ETH", "BAD", "SOL", "ADA", "DOT", "XRP", "LTC", "DOGE", "AVAX"}
+	errBad := errors.New("bad symbol")
+
+	var calls inFlight
+	results := EachSymbol(context.Background(), symbols, func(ctx context.Context, symbol string) (string, error) {
+		calls.enter()
+		defer calls.leave()
+		time.Sleep(10 * time.Millisecond)
+
+		if symbol == "BAD" {
+			return "", errBad
+		}
+		return symbol + "-USD", nil
+	})
+
+	if len(results) != len(symbols) {
+		t.Fatalf("got %d results, want %d", len(results), len(symbols))
+	}
+	for i, symbol := range symbols {
+		if symbol == "BAD" {
+			if !errors.Is(results[i].Err, errBad) {
+				t.Errorf("result %d err = %v, want %v", i, results[i].Err, errBad)
+			}
+			continue
+		}
+		if results[i].Err != nil || results[i].Value != symbol+"-USD" {
+			t.Errorf("result %d = %+v, want %s-USD", i, results[i], symbol)
+		}
+	}
+
+	if got := calls.max(); got < 2 || got > maxSymbolFetches {
+		t.Errorf("%d calls in flight at once, want between 2 and %d", got, maxSymbolFetches)
+	}
+}
+
+func TestEachSymbolEmpty(t *testing.T) {
+	results := EachSymbol(context.Background(), nil, func(ctx context.Context, symbol string) (float64, error) {
+		t.Error("no fetch expected")
+		return 0, nil
+	})
+	if len(results) != 0 {
+		t.Errorf("got %d results, want none", len(results))
+	}
+}
@@ -0,0 +1,547 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+	"golang.org/x/time/rate"
+)
+
+// OKXClient implements the Exchange interface for OKX using the public v5 REST API
+type OKXClient struct {
+	httpClient *http.Client
+	name       string
+	baseURL    string
+	markets    listedMarkets
+}
+
+// OKX API response structures
+type okxResponse struct {
+	Code string          `json:"code"`
+	Msg  string          `json:"msg"`
+	Data json.RawMessage `json:"data"`
+}
+
+type okxTicker struct {
+	InstID  string `json:"instId"`
+	Last    string `json:"last"`
+	Open24h string `json:"open24h"`
+	High24h string `json:"high24h"`
+	Low24h  string `json:"low24h"`
+	Vol24h  string `json:"vol24h"`
+	BidPx   string `json:"bidPx"`
+	AskPx   string `json:"askPx"`
+	Ts      string `json:"ts"`
+}
+
+// okxBook is an order book snapshot; rows are [price, size, deprecated, num_orders]
+type okxBook struct {
+	Asks  [][]interface{} `json:"asks"`
+	Bids  [][]interface{} `json:"bids"`
+	Ts    string          `json:"ts"`
+	SeqID int64           `json:"seqId"`
+}
+
+// okxTrade is a public trade; Side is the taker side
+type okxTrade struct {
+	TradeID string `json:"tradeId"`
+	Px      string `json:"px"`
+	Sz      string `json:"sz"`
+	Side    string `json:"side"`
+	Ts      string `json:"ts"`
+}
+
+// okxInstrument is a spot instrument from the public instruments endpoint
+type okxInstrument struct {
+	InstID   string `json:"instId"`
+	BaseCcy  string `json:"baseCcy"`
+	QuoteCcy string `json:"quoteCcy"`
+	TickSz   string `json:"tickSz"`
+	LotSz    string `json:"lotSz"`
+	State    string `json:"state"`
+}
+
+// okxMaxBookDepth is the deepest order book OKX returns from the books endpoint
+const okxMaxBookDepth = 400
+
+// okxQuoteCurrencies lists quote currencies used to split concatenated symbols like "ETHBTC"
+var okxQuoteCurrencies = []string{"USDT", "USDC", "USD", "BTC", "ETH", "EUR"}
+
+// okxBars maps Binance-style intervals to OKX bar sizes
+var okxBars = map[string]string{
+	"1m":  "1m",
+	"3m":  "3m",
+	"5m":  "5m",
+	"15m": "15m",
+	"30m": "30m",
+	"1h":  "1H",
+	"2h":  "2H",
+	"4h":  "4H",
+	"6h":  "6Hutc",
+	"12h": "12Hutc",
+	"1d":  "1Dutc",
+	"1w":  "1Wutc",
+	"1M":  "1Mutc",
+}
+
+// okxMaxCandles is the maximum number of candles returned by a single request
+const okxMaxCandles = 300
+
+// okxMaxHistoryCandles is the page size of the history-candles endpoint
+const okxMaxHistoryCandles = 100
+
+// okxMaxTrades is the most trades the trades endpoint returns
+const okxMaxTrades = 500
+
+// NewOKXClient creates a new OKX client using the public API
+func NewOKXClient(apiKey, apiSecret string) (*OKXClient, error) {
+	// Rate limit: 10 requests per second (OKX allows 20 requests per 2 seconds on market endpoints)
+	limiter := rate.NewLimiter(rate.Limit(10), 10)
+
+	return &OKXClient{
+		httpClient: newHTTPClient("okx", limiter),
+		name:       "okx",
+		baseURL:    "https://www.okx.com",
+	}, nil
+}
+
+// SetBaseURL overrides the REST endpoint, e.g. to point the client at a test server
+func (o *OKXClient) SetBaseURL(baseURL string) {
+	o.baseURL = strings.TrimRight(baseURL, "/")
+}
+
+// GetName returns the exchange name
+func (o *OKXClient) GetName() string {
+	return o.name
+}
+
+// Capabilities describes what the client supports
+func (o *OKXClient) Capabilities() Capabilities {
+	return Capabilities{
+		Intervals:      sortedIntervals(okxBars),
+		MaxCandleLimit: okxMaxCandles,
+		Batch:          true,
+		OrderBook:      true,
+		Trades:         true,
+		CandleHistory:  true,
+		Markets:        true,
+	}
+}
+
+// NormalizeSymbol converts a symbol like "BTC/USDT" or "BTC" to "BTC-USDT" for OKX.
+// A bare symbol is looked up in the listed markets once SetMarkets has been
+// called, and otherwise split on a quote currency it ends in.
+func (o *OKXClient) NormalizeSymbol(symbol string) string {
+	// Convert to uppercase and replace common separators
+	normalized := strings.ToUpper(symbol)
+	normalized = strings.ReplaceAll(normalized, "/", "-")
+	normalized = strings.ReplaceAll(normalized, "_", "-")
+
+	if strings.Contains(normalized, "-") {
+		return normalized
+	}
+
+	if base, quote, ok := o.markets.split(normalized, "USDT"); ok {
+		return base + "-" + quote
+	}
+
+	// Split concatenated pairs such as "BTCUSDT"
+	for _, quote := range okxQuoteCurrencies {
+		if len(normalized) > len(quote)+1 && strings.HasSuffix(normalized, quote) {
+			return strings.TrimSuffix(normalized, quote) + "-" + quote
+		}
+	}
+
+	// If no quote currency specified, default to USDT
+	return normalized + "-USDT"
+}
+
+// SetMarkets lets NormalizeSymbol look bare symbols up in the listed markets
+func (o *OKXClient) SetMarkets(pair func(symbol string) (models.Market, bool)) {
+	o.markets.set(pair)
+}
+
+// get performs a GET request against the OKX API and decodes the data field into out
+func (o *OKXClient) get(ctx context.Context, path string, params url.Values, out interface{}) error {
+	endpoint := o.baseURL + path
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return networkError(o.name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return statusError(o.name, resp)
+	}
+
+	var result okxResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if result.Code != "0" {
+		return okxError(o.name, result.Code, result.Msg)
+	}
+
+	if err := json.Unmarshal(result.Data, out); err != nil {
+		return fmt.Errorf("failed to decode data: %w", err)
+	}
+
+	return nil
+}
+
+// getTicker fetches the raw ticker for an instrument
+func (o *OKXClient) getTicker(ctx context.Context, instID string) (*okxTicker, error) {
+	var tickers []okxTicker
+	if err := o.get(ctx, "/api/v5/market/ticker", url.Values{"instId": {instID}}, &tickers); err != nil {
+		return nil, fmt.Errorf("failed to get ticker from OKX: %w", err)
+	}
+
+	if len(tickers) == 0 {
+		return nil, newError(o.name, ErrUnknownSymbol, fmt.Errorf("no ticker data returned for symbol: %s", instID))
+	}
+
+	return &tickers[0], nil
+}
+
+// GetPrice returns the current price for a symbol
+func (o *OKXClient) GetPrice(ctx context.Context, symbol string) (float64, error) {
+	instID := o.NormalizeSymbol(symbol)
+
+	t, err := o.getTicker(ctx, instID)
+	if err != nil {
+		return 0, err
+	}
+
+	price, err := strconv.ParseFloat(t.Last, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse price: %w", err)
+	}
+
+	return price, nil
+}
+
+// GetTicker returns detailed market data for a symbol
+func (o *OKXClient) GetTicker(ctx context.Context, symbol string) (*models.Ticker, error) {
+	instID := o.NormalizeSymbol(symbol)
+
+	t, err := o.getTicker(ctx, instID)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.ticker()
+}
+
+// ticker converts an OKX ticker
+func (t *okxTicker) ticker() (*models.Ticker, error) {
+	price, err := strconv.ParseFloat(t.Last, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse price: %w", err)
+	}
+	open, _ := strconv.ParseFloat(t.Open24h, 64)
+	high, _ := strconv.ParseFloat(t.High24h, 64)
+	low, _ := strconv.ParseFloat(t.Low24h, 64)
+	volume, _ := strconv.ParseFloat(t.Vol24h, 64)
+	bid, _ := strconv.ParseFloat(t.BidPx, 64)
+	ask, _ := strconv.ParseFloat(t.AskPx, 64)
+
+	lastUpdated := time.Now()
+	if ts, err := strconv.ParseInt(t.Ts, 10, 64); err == nil {
+		lastUpdated = time.UnixMilli(ts)
+	}
+
+	return &models.Ticker{
+		Symbol:      t.InstID,
+		Price:       price,
+		Change24h:   price - open,
+		Volume24h:   volume,
+		High24h:     high,
+		Low24h:      low,
+		LastUpdated: lastUpdated,
+		Bid:         bid,
+		Ask:         ask,
+	}, nil
+}
+
+// getTickers fetches the raw tickers for several instruments. OKX has no
+// endpoint for a list of instruments, so every spot ticker is fetched in one
+// request and the ones asked for are picked out.
+func (o *OKXClient) getTickers(ctx context.Context, symbols []string) []Result[*okxTicker] {
+	var tickers []okxTicker
+	if err := o.get(ctx, "/api/v5/market/tickers", url.Values{"instType": {"SPOT"}}, &tickers); err != nil {
+		return failAll[*okxTicker](len(symbols), fmt.Errorf("failed to get tickers from OKX: %w", err))
+	}
+
+	byInstID := make(map[string]*okxTicker, len(tickers))
+	for i := range tickers {
+		byInstID[tickers[i].InstID] = &tickers[i]
+	}
+
+	results := make([]Result[*okxTicker], len(symbols))
+	for i, symbol := range symbols {
+		instID := o.NormalizeSymbol(symbol)
+		if t, ok := byInstID[instID]; ok {
+			results[i].Value = t
+		} else {
+			results[i].Err = newError(o.name, ErrUnknownSymbol, fmt.Errorf("no ticker data returned for symbol: %s", instID))
+		}
+	}
+	return results
+}
+
+// GetPrices returns the current prices for several symbols with a single request
+func (o *OKXClient) GetPrices(ctx context.Context, symbols []string) []Result[float64] {
+	if len(symbols) < 2 {
+		return EachSymbol(ctx, symbols, o.GetPrice)
+	}
+
+	results := make([]Result[float64], len(symbols))
+	for i, r := range o.getTickers(ctx, symbols) {
+		if results[i].Err = r.Err; r.Err != nil {
+			continue
+		}
+		if results[i].Value, results[i].Err = strconv.ParseFloat(r.Value.Last, 64); results[i].Err != nil {
+			results[i].Err = fmt.Errorf("failed to parse price: %w", results[i].Err)
+		}
+	}
+	return results
+}
+
+// GetTickers returns detailed market data for several symbols with a single request
+func (o *OKXClient) GetTickers(ctx context.Context, symbols []string) []Result[*models.Ticker] {
+	if len(symbols) < 2 {
+		return EachSymbol(ctx, symbols, o.GetTicker)
+	}
+
+	results := make([]Result[*models.Ticker], len(symbols))
+	for i, r := range o.getTickers(ctx, symbols) {
+		if results[i].Err = r.Err; r.Err == nil {
+			results[i].Value, results[i].Err = r.Value.ticker()
+		}
+	}
+	return results
+}
+
+// GetCandles returns historical OHLCV data
+func (o *OKXClient) GetCandles(ctx context.Context, symbol, interval string, limit int) ([]models.Candle, error) {
+	bar, ok := okxBars[interval]
+	if !ok {
+		return nil, &UnsupportedIntervalError{Exchange: o.name, Interval: interval}
+	}
+
+	if limit <= 0 || limit > okxMaxCandles {
+		limit = okxMaxCandles
+	}
+
+	instID := o.NormalizeSymbol(symbol)
+	params := url.Values{
+		"instId": {instID},
+		"bar":    {bar},
+		"limit":  {strconv.Itoa(limit)},
+	}
+
+	// Each row is [ts, open, high, low, close, vol, volCcy, volCcyQuote, confirm]
+	var rows [][]string
+	if err := o.get(ctx, "/api/v5/market/candles", params, &rows); err != nil {
+		return nil, fmt.Errorf("failed to get candles from OKX: %w", err)
+	}
+
+	return okxCandles(rows, interval), nil
+}
+
+// GetCandleRange returns the candles opening within [start, end), oldest first
+func (o *OKXClient) GetCandleRange(ctx context.Context, symbol, interval string, start, end time.Time) ([]models.Candle, error) {
+	bar, ok := okxBars[interval]
+	if !ok {
+		return nil, &UnsupportedIntervalError{Exchange: o.name, Interval: interval}
+	}
+
+	instID := o.NormalizeSymbol(symbol)
+
+	// The history endpoint pages backwards from `after` (exclusive), newest first
+	var rows [][]string
+	cursor := end.UnixMilli()
+	for {
+		params := url.Values{
+			"instId": {instID},
+			"bar":    {bar},
+			"after":  {strconv.FormatInt(cursor, 10)},
+			"limit":  {strconv.Itoa(okxMaxHistoryCandles)},
+		}
+
+		var page [][]string
+		if err := o.get(ctx, "/api/v5/market/history-candles", params, &page); err != nil {
+			return nil, fmt.Errorf("failed to get candles from OKX: %w", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		rows = append(rows, page...)
+
+		oldest, err := strconv.ParseInt(page[len(page)-1][0], 10, 64)
+		if err != nil || oldest <= start.UnixMilli() || oldest >= cursor {
+			break
+		}
+		cursor = oldest
+	}
+
+	return filterCandleRange(okxCandles(rows, interval), start, end), nil
+}
+
+// okxCandles converts candle rows, newest first, into chronological candles
+func okxCandles(rows [][]string, interval string) []models.Candle {
+	candles := make([]models.Candle, 0, len(rows))
+	for i := len(rows) - 1; i >= 0; i-- {
+		row := rows[i]
+		if len(row) < 6 {
+			continue
+		}
+
+		ts, _ := strconv.ParseInt(row[0], 10, 64)
+		open, _ := strconv.ParseFloat(row[1], 64)
+		high, _ := strconv.ParseFloat(row[2], 64)
+		low, _ := strconv.ParseFloat(row[3], 64)
+		closePrice, _ := strconv.ParseFloat(row[4], 64)
+		volume, _ := strconv.ParseFloat(row[5], 64)
+
+		var quoteVolume float64
+		if len(row) > 7 {
+			quoteVolume, _ = strconv.ParseFloat(row[7], 64)
+		}
+
+		openTime := time.UnixMilli(ts)
+		candles = append(candles, models.Candle{
+			Time:        openTime,
+			CloseTime:   candleCloseTime(openTime, interval),
+			Open:        open,
+			High:        high,
+			Low:         low,
+			Close:       closePrice,
+			Volume:      volume,
+			QuoteVolume: quoteVolume,
+		})
+	}
+
+	return candles
+}
+
+// GetOrderBook returns up to depth price levels on each side of the book
+func (o *OKXClient) GetOrderBook(ctx context.Context, symbol string, depth int) (*models.OrderBook, error) {
+	if depth <= 0 || depth > okxMaxBookDepth {
+		depth = okxMaxBookDepth
+	}
+
+	instID := o.NormalizeSymbol(symbol)
+	params := url.Values{
+		"instId": {instID},
+		"sz":     {strconv.Itoa(depth)},
+	}
+
+	var books []okxBook
+	if err := o.get(ctx, "/api/v5/market/books", params, &books); err != nil {
+		return nil, fmt.Errorf("failed to get order book from OKX: %w", err)
+	}
+
+	if len(books) == 0 {
+		return nil, newError(o.name, ErrUnknownSymbol, fmt.Errorf("no order book data returned for symbol: %s", instID))
+	}
+
+	book := books[0]
+	timestamp := time.Now()
+	if ts, err := strconv.ParseInt(book.Ts, 10, 64); err == nil {
+		timestamp = time.UnixMilli(ts)
+	}
+
+	return &models.OrderBook{
+		Symbol:    instID,
+		Bids:      truncateLevels(parseLevels(book.Bids), depth),
+		Asks:      truncateLevels(parseLevels(book.Asks), depth),
+		UpdateID:  book.SeqID,
+		Timestamp: timestamp,
+	}, nil
+}
+
+// GetRecentTrades returns up to limit of the latest trades, oldest first
+func (o *OKXClient) GetRecentTrades(ctx context.Context, symbol string, limit int) ([]models.Trade, error) {
+	if limit <= 0 || limit > okxMaxTrades {
+		limit = okxMaxTrades
+	}
+
+	instID := o.NormalizeSymbol(symbol)
+	params := url.Values{
+		"instId": {instID},
+		"limit":  {strconv.Itoa(limit)},
+	}
+
+	var res []okxTrade
+	if err := o.get(ctx, "/api/v5/market/trades", params, &res); err != nil {
+		return nil, fmt.Errorf("failed to get trades from OKX: %w", err)
+	}
+
+	// OKX returns the newest trade first
+	trades := make([]models.Trade, 0, len(res))
+	for i := len(res) - 1; i >= 0; i-- {
+		t := res[i]
+		price, _ := strconv.ParseFloat(t.Px, 64)
+		quantity, _ := strconv.ParseFloat(t.Sz, 64)
+		ts, _ := strconv.ParseInt(t.Ts, 10, 64)
+
+		trades = append(trades, models.Trade{
+			ID:       t.TradeID,
+			Symbol:   instID,
+			Price:    price,
+			Quantity: quantity,
+			Side:     models.Side(t.Side),
+			Time:     time.UnixMilli(ts),
+		})
+	}
+
+	return trades, nil
+}
+
+// ListMarkets returns every spot market listed on the exchange
+func (o *OKXClient) ListMarkets(ctx context.Context) ([]models.Market, error) {
+	var instruments []okxInstrument
+	if err := o.get(ctx, "/api/v5/public/instruments", url.Values{"instType": {"SPOT"}}, &instruments); err != nil {
+		return nil, fmt.Errorf("failed to get instruments from OKX: %w", err)
+	}
+
+	markets := make([]models.Market, 0, len(instruments))
+	for _, inst := range instruments {
+		status := models.MarketStatusHalted
+		if inst.State == "live" {
+			status = models.MarketStatusTrading
+		}
+
+		tickSize, _ := strconv.ParseFloat(inst.TickSz, 64)
+		lotSize, _ := strconv.ParseFloat(inst.LotSz, 64)
+
+		markets = append(markets, models.Market{
+			Symbol:   inst.InstID,
+			Base:     inst.BaseCcy,
+			Quote:    inst.QuoteCcy,
+			Status:   status,
+			TickSize: tickSize,
+			LotSize:  lotSize,
+		})
+	}
+
+	return markets, nil
+}
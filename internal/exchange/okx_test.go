@@ -0,0 +1,256 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+)
+
+// newOKXTestClient returns an OKX client talking to a test server that serves handler
+func newOKXTestClient(t *testing.T, handler http.HandlerFunc) *OKXClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewOKXClient("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetBaseURL(server.URL)
+	return client
+}
+
+// okxData writes a successful OKX response envelope around data
+func okxData(w http.ResponseWriter, data string) {
+	fmt.Fprintf(w, `{"code":"0","msg":"","data":%s}`, data)
+}
+
+func TestOKXGetPriceAndTicker(t *testing.T) {
+	client := newOKXTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v5/market/ticker" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("instId"); got != "BTC-USDT" {
+			t.Errorf("instId = %q, want BTC-USDT", got)
+		}
+		okxData(w, `[{"instId":"BTC-USDT","last":"65000.5","open24h":"64000","high24h":"66000","low24h":"63000","vol24h":"1234.5","bidPx":"65000.1","askPx":"65000.9","ts":"1700000000000"}]`)
+	})
+
+	price, err := client.GetPrice(context.Background(), "BTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price != 65000.5 {
+		t.Errorf("price = %v, want 65000.5", price)
+	}
+
+	ticker, err := client.GetTicker(context.Background(), "btc/usdt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ticker.Symbol != "BTC-USDT" || ticker.Price != 65000.5 || ticker.Change24h != 1000.5 ||
+		ticker.High24h != 66000 || ticker.Low24h != 63000 || ticker.Volume24h != 1234.5 ||
+		ticker.Bid != 65000.1 || ticker.Ask != 65000.9 {
+		t.Errorf("unexpected ticker %+v", ticker)
+	}
+	if !ticker.LastUpdated.Equal(time.UnixMilli(1700000000000)) {
+		t.Errorf("LastUpdated = %v, want the ticker's ts", ticker.LastUpdated)
+	}
+}
+
+func TestOKXGetCandles(t *testing.T) {
+	tests := []struct {
+		interval string
+		bar      string
+	}{
+		{"1m", "1m"},
+		{"1h", "1H"},
+		{"4h", "4H"},
+		{"1d", "1Dutc"},
+		{"1w", "1Wutc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.interval, func(t *testing.T) {
+			client := newOKXTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.Query().Get("bar"); got != tt.bar {
+					t.Errorf("bar = %q, want %q", got, tt.bar)
+				}
+				// OKX returns the newest candle first
+				okxData(w, `[
+					["1700000120000","3","4","2","3.5","30","0","105","1"],
+					["1700000060000","2","3","1","2.5","20","0","50","1"],
+					["1700000000000","1","2","0.5","1.5","10","0","15","1"]
+				]`)
+			})
+
+			candles, err := client.GetCandles(context.Background(), "BTC", tt.interval, 3)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(candles) != 3 {
+				t.Fatalf("got %d candles, want 3", len(candles))
+			}
+			for i, want := range []float64{1, 2, 3} {
+				if candles[i].Open != want {
+					t.Errorf("candle %d open = %v, want %v", i, candles[i].Open, want)
+				}
+			}
+			if !candles[0].Time.Equal(time.UnixMilli(1700000000000)) {
+				t.Errorf("first candle at %v, want the oldest", candles[0].Time)
+			}
+			if candles[2].QuoteVolume != 105 {
+				t.Errorf("quote volume = %v, want 105", candles[2].QuoteVolume)
+			}
+		})
+	}
+
+	t.Run("unsupported interval", func(t *testing.T) {
+		client := newOKXTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Error("no request expected")
+		})
+		if _, err := client.GetCandles(context.Background(), "BTC", "8h", 10); !errors.Is(err, ErrUnsupported) {
+			t.Errorf("err = %v, want ErrUnsupported", err)
+		}
+	})
+}
+
+func TestOKXNormalizeSymbol(t *testing.T) {
+	client, _ := NewOKXClient("", "")
+
+	tests := []struct {
+		in, want string
+	}{
+		{"BTC", "BTC-USDT"},
+		{"btc", "BTC-USDT"},
+		{"ETHBTC", "ETH-BTC"},
+		{"BTCUSDT", "BTC-USDT"},
+		{"SOLUSDC", "SOL-USDC"},
+		{"BTC/USD", "BTC-USD"},
+		{"eth_btc", "ETH-BTC"},
+	}
+
+	for _, tt := range tests {
+		if got := client.NormalizeSymbol(tt.in); got != tt.want {
+			t.Errorf("NormalizeSymbol(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestOKXNormalizeSymbolWithMarkets(t *testing.T) {
+	client, _ := NewOKXClient("", "")
+	client.SetMarkets(listedPairs(
+		models.Market{Base: "BTC", Quote: "USDT"},
+		models.Market{Base: "ETH", Quote: "BTC"},
+		models.Market{Base: "WBETH", Quote: "ETH"},
+		models.Market{Base: "STETH", Quote: "USDT"},
+		models.Market{Base: "STETH", Quote: "ETH"},
+		models.Market{Base: "AEUR", Quote: "USDT"},
+	))
+
+	tests := []struct {
+		in, want string
+	}{
+		{"STETH", "STETH-USDT"},
+		{"STETHETH", "STETH-ETH"},
+		{"AEUR", "AEUR-USDT"},
+		{"WBETHETH", "WBETH-ETH"},
+		{"ETHBTC", "ETH-BTC"},
+		{"btc", "BTC-USDT"},
+
+		// Unlisted symbols fall back to the heuristic
+		{"SOLUSDC", "SOL-USDC"},
+		{"PEPE", "PEPE-USDT"},
+	}
+
+	for _, tt := range tests {
+		if got := client.NormalizeSymbol(tt.in); got != tt.want {
+			t.Errorf("NormalizeSymbol(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestOKXError(t *testing.T) {
+	tests := []struct {
+		code string
+		kind error
+	}{
+		{"51001", ErrUnknownSymbol},
+		{"51000", ErrUnknownSymbol},
+		{"50011", ErrRateLimited},
+		{"50061", ErrRateLimited},
+		{"50001", ErrUnavailable},
+		{"50026", ErrUnavailable},
+		{"50111", ErrAuth},
+		{"50113", ErrAuth},
+		{"99999", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			client := newOKXTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"code":%q,"msg":"failed","data":[]}`, tt.code)
+			})
+
+			_, err := client.GetPrice(context.Background(), "BTC")
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+
+			var e *Error
+			if tt.kind == nil {
+				if errors.As(err, &e) {
+					t.Errorf("err = %v, want an unclassified error", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.kind) {
+				t.Errorf("err = %v, want %v", err, tt.kind)
+			}
+			if !errors.As(err, &e) || e.Exchange != "okx" {
+				t.Errorf("err = %v, want an okx *Error", err)
+			}
+		})
+	}
+}
+
+func TestOKXGetRecentTradesLimit(t *testing.T) {
+	tests := []struct {
+		limit int
+		want  string
+	}{
+		{-1, "500"},
+		{0, "500"},
+		{50, "50"},
+		{1000, "500"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.limit), func(t *testing.T) {
+			client := newOKXTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.Query().Get("limit"); got != tt.want {
+					t.Errorf("limit = %q, want %q", got, tt.want)
+				}
+				okxData(w, `[
+					{"tradeId":"2","px":"101","sz":"0.2","side":"sell","ts":"1700000001000"},
+					{"tradeId":"1","px":"100","sz":"0.1","side":"buy","ts":"1700000000000"}
+				]`)
+			})
+
+			trades, err := client.GetRecentTrades(context.Background(), "BTC", tt.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(trades) != 2 || trades[0].ID != "1" || trades[1].ID != "2" {
+				t.Errorf("trades = %+v, want oldest first", trades)
+			}
+		})
+	}
+}
@@ -0,0 +1,485 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+)
+
+// PaperExchange simulates a trading account on top of another exchange. Market
+// data comes from the wrapped exchange, orders fill against its live prices and
+// the account's balances, orders and fills are kept in a local ledger file.
+//
+// Market orders fill at once at the last price, as do limit orders that are
+// already marketable. Other limit orders rest with their funds locked and fill
+// at their limit price once the last price reaches it, which is checked
+// whenever the account is read or traded. Stop-limit orders are not simulated.
+type PaperExchange struct {
+	Exchange
+
+	path string
+	opts PaperOptions
+
+	mu sync.Mutex
+}
+
+// PaperOptions configures a PaperExchange
+type PaperOptions struct {
+	// FeeRate is charged on the value of every fill, e.g. 0.001 for 0.1%.
+	// Fees are paid in the quote currency.
+	FeeRate float64
+
+	// Balances funds a new account, by asset
+	Balances map[string]float64
+}
+
+// PaperFill is one execution of a paper order
+type PaperFill struct {
+	OrderID       string      `json:"order_id"`
+	Symbol        string      `json:"symbol"`
+	Side          models.Side `json:"side"`
+	Price         float64     `json:"price"`
+	Quantity      float64     `json:"quantity"`
+	QuoteQuantity float64     `json:"quote_quantity"`
+	Fee           float64     `json:"fee"`
+	FeeAsset      string      `json:"fee_asset"`
+	Time          time.Time   `json:"time"`
+}
+
+// paperOrder is an order in the ledger with the assets it trades
+type paperOrder struct {
+	models.Order
+	Base  string `json:"base"`
+	Quote string `json:"quote"`
+
+	// Locked is what a resting order holds back: quote currency for buys, base asset for sells
+	Locked float64 `json:"locked,omitempty"`
+}
+
+// paperLedger is the layout of the ledger file
+type paperLedger struct {
+	Balances  map[string]models.Balance `json:"balances"`
+	Orders    []paperOrder              `json:"orders"`
+	Fills     []PaperFill               `json:"fills"`
+	NextID    int64                     `json:"next_id"`
+	CreatedAt time.Time                 `json:"created_at"`
+}
+
+// paperStatusOpen is the status of an order that has not filled or been canceled
+const paperStatusOpen = "new"
+
+// NewPaperExchange wraps inner in a paper trading account stored at ledgerPath.
+// A missing ledger starts a new account funded with opts.Balances.
+func NewPaperExchange(inner Exchange, ledgerPath string, opts PaperOptions) *PaperExchange {
+	return &PaperExchange{Exchange: inner, path: ledgerPath, opts: opts}
+}
+
+// GetName returns the exchange name
+func (p *PaperExchange) GetName() string {
+	return "paper:" + p.Exchange.GetName()
+}
+
+// Unwrap returns the exchange whose prices the account trades at
+func (p *PaperExchange) Unwrap() Exchange {
+	return p.Exchange
+}
+
+// Capabilities describes what the client supports: the wrapped exchange's market
+// data plus a simulated account
+func (p *PaperExchange) Capabilities() Capabilities {
+	caps := p.Exchange.Capabilities()
+	caps.Authenticated = true
+	caps.Trading = true
+	return caps
+}
+
+// Path returns the location of the ledger file
+func (p *PaperExchange) Path() string {
+	return p.path
+}
+
+// Reset deletes the ledger, so that the next call starts a new account
+func (p *PaperExchange) Reset() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := os.Remove(p.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to reset paper account: %w", err)
+	}
+	return nil
+}
+
+// PlaceOrder fills or rests a market or limit order
+func (p *PaperExchange) PlaceOrder(ctx context.Context, req models.OrderRequest) (*models.Order, error) {
+	if req.Type != models.OrderTypeMarket && req.Type != models.OrderTypeLimit {
+		return nil, newError(p.GetName(), ErrUnsupported, fmt.Errorf("%s orders are not simulated", req.Type))
+	}
+	if req.Quantity <= 0 {
+		return nil, fmt.Errorf("quantity must be positive")
+	}
+	if req.Type == models.OrderTypeLimit && req.Price <= 0 {
+		return nil, fmt.Errorf("price must be positive")
+	}
+
+	base, quote, ok := splitPair(req.Symbol)
+	if !ok {
+		return nil, fmt.Errorf("paper trading needs a BASE/QUOTE symbol, got %q", req.Symbol)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ledger, err := p.load()
+	if err != nil {
+		return nil, err
+	}
+	p.settle(ctx, ledger)
+
+	price, err := p.Exchange.GetPrice(ctx, base+"/"+quote)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	order := paperOrder{
+		Order: models.Order{
+			ID:       strconv.FormatInt(ledger.NextID, 10),
+			Symbol:   p.NormalizeSymbol(base + "/" + quote),
+			Side:     req.Side,
+			Type:     req.Type,
+			Status:   paperStatusOpen,
+			Price:    req.Price,
+			Quantity: req.Quantity,
+			Time:     now,
+		},
+		Base:  base,
+		Quote: quote,
+	}
+
+	marketable := req.Type == models.OrderTypeMarket ||
+		(req.Side == models.SideBuy && price <= req.Price) ||
+		(req.Side == models.SideSell && price >= req.Price)
+
+	// Check funds for what the order will cost now, or at its limit if it rests
+	cost := req.Price
+	if marketable {
+		cost = price
+	}
+	if req.Side == models.SideBuy {
+		need := req.Quantity * cost * (1 + p.opts.FeeRate)
+		if free := ledger.Balances[quote].Free; need > free {
+			return nil, fmt.Errorf("insufficient %s balance: need %s, have %s", quote, formatLedgerAmount(need), formatLedgerAmount(free))
+		}
+		order.Locked = need
+	} else {
+		if free := ledger.Balances[base].Free; req.Quantity > free {
+			return nil, fmt.Errorf("insufficient %s balance: need %s, have %s", base, formatLedgerAmount(req.Quantity), formatLedgerAmount(free))
+		}
+		order.Locked = req.Quantity
+	}
+
+	lock(ledger, &order)
+	if marketable {
+		p.fill(ledger, &order, price, now)
+	}
+
+	ledger.NextID++
+	ledger.Orders = append(ledger.Orders, order)
+	if err := p.save(ledger); err != nil {
+		return nil, err
+	}
+
+	result := order.Order
+	return &result, nil
+}
+
+// CancelOrder cancels a resting order and releases its funds
+func (p *PaperExchange) CancelOrder(ctx context.Context, symbol, orderID string) (*models.Order, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ledger, err := p.load()
+	if err != nil {
+		return nil, err
+	}
+	p.settle(ctx, ledger)
+
+	order, err := p.find(ledger, symbol, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != paperStatusOpen {
+		return nil, fmt.Errorf("order %s is already %s", orderID, order.Status)
+	}
+
+	unlock(ledger, order)
+	order.Status = "canceled"
+	order.UpdateTime = time.Now()
+
+	if err := p.save(ledger); err != nil {
+		return nil, err
+	}
+
+	result := order.Order
+	return &result, nil
+}
+
+// ListOpenOrders returns the resting orders for symbol, or for every symbol if it is empty
+func (p *PaperExchange) ListOpenOrders(ctx context.Context, symbol string) ([]models.Order, error) {
+	ledger, err := p.settled(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []models.Order
+	for _, o := range ledger.Orders {
+		if o.Status == paperStatusOpen && (symbol == "" || o.Symbol == p.NormalizeSymbol(symbol)) {
+			orders = append(orders, o.Order)
+		}
+	}
+	return orders, nil
+}
+
+// GetOrder returns the current state of an order
+func (p *PaperExchange) GetOrder(ctx context.Context, symbol, orderID string) (*models.Order, error) {
+	ledger, err := p.settled(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := p.find(ledger, symbol, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := order.Order
+	return &result, nil
+}
+
+// GetBalances returns every asset with a non-zero balance
+func (p *PaperExchange) GetBalances(ctx context.Context) ([]models.Balance, error) {
+	ledger, err := p.settled(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var balances []models.Balance
+	for _, b := range ledger.Balances {
+		if b.Total() > 0 {
+			balances = append(balances, b)
+		}
+	}
+
+	sort.Slice(balances, func(i, j int) bool { return balances[i].Asset < balances[j].Asset })
+	return balances, nil
+}
+
+// Fills returns every fill in the ledger, oldest first
+func (p *PaperExchange) Fills(ctx context.Context) ([]PaperFill, error) {
+	ledger, err := p.settled(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ledger.Fills, nil
+}
+
+// settled loads the ledger, fills the resting orders the market has reached and
+// saves the result
+func (p *PaperExchange) settled(ctx context.Context) (*paperLedger, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ledger, err := p.load()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.settle(ctx, ledger) {
+		if err := p.save(ledger); err != nil {
+			return nil, err
+		}
+	}
+	return ledger, nil
+}
+
+// settle fills the resting orders whose limit the last price has reached and
+// reports whether any did. Markets whose price cannot be fetched are left for
+// the next call.
+func (p *PaperExchange) settle(ctx context.Context, ledger *paperLedger) bool {
+	prices := make(map[string]float64)
+	filled := false
+
+	for i := range ledger.Orders {
+		order := &ledger.Orders[i]
+		if order.Status != paperStatusOpen {
+			continue
+		}
+
+		pair := order.Base + "/" + order.Quote
+		price, ok := prices[pair]
+		if !ok {
+			var err error
+			if price, err = p.Exchange.GetPrice(ctx, pair); err != nil {
+				continue
+			}
+			prices[pair] = price
+		}
+
+		if (order.Side == models.SideBuy && price <= order.Price) ||
+			(order.Side == models.SideSell && price >= order.Price) {
+			p.fill(ledger, order, order.Price, time.Now())
+			filled = true
+		}
+	}
+
+	return filled
+}
+
+// fill executes an order in full at price, settling its locked funds and charging the fee
+func (p *PaperExchange) fill(ledger *paperLedger, order *paperOrder, price float64, at time.Time) {
+	value := order.Quantity * price
+	fee := value * p.opts.FeeRate
+
+	unlock(ledger, order)
+	if order.Side == models.SideBuy {
+		adjust(ledger, order.Quote, -(value + fee))
+		adjust(ledger, order.Base, order.Quantity)
+	} else {
+		adjust(ledger, order.Base, -order.Quantity)
+		adjust(ledger, order.Quote, value-fee)
+	}
+
+	order.Status = "filled"
+	order.ExecutedQuantity = order.Quantity
+	order.QuoteQuantity = value
+	order.UpdateTime = at
+
+	ledger.Fills = append(ledger.Fills, PaperFill{
+		OrderID:       order.ID,
+		Symbol:        order.Symbol,
+		Side:          order.Side,
+		Price:         price,
+		Quantity:      order.Quantity,
+		QuoteQuantity: value,
+		Fee:           fee,
+		FeeAsset:      order.Quote,
+		Time:          at,
+	})
+}
+
+// find looks an order up by ID, checking that it trades symbol when one is given
+func (p *PaperExchange) find(ledger *paperLedger, symbol, orderID string) (*paperOrder, error) {
+	for i := range ledger.Orders {
+		order := &ledger.Orders[i]
+		if order.ID == orderID && (symbol == "" || order.Symbol == p.NormalizeSymbol(symbol)) {
+			return order, nil
+		}
+	}
+	return nil, fmt.Errorf("no paper order %s for %s", orderID, p.NormalizeSymbol(symbol))
+}
+
+// load reads the ledger, starting a new account if there is none
+func (p *PaperExchange) load() (*paperLedger, error) {
+	data, err := os.ReadFile(p.path)
+	if os.IsNotExist(err) {
+		ledger := &paperLedger{Balances: make(map[string]models.Balance), NextID: 1, CreatedAt: time.Now().UTC()}
+		for asset, amount := range p.opts.Balances {
+			adjust(ledger, strings.ToUpper(asset), amount)
+		}
+		return ledger, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read paper ledger: %w", err)
+	}
+
+	var ledger paperLedger
+	if err := json.Unmarshal(data, &ledger); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", p.path, err)
+	}
+	if ledger.Balances == nil {
+		ledger.Balances = make(map[string]models.Balance)
+	}
+	return &ledger, nil
+}
+
+// save writes the ledger, replacing the file atomically
+func (p *PaperExchange) save(ledger *paperLedger) error {
+	if err := os.MkdirAll(filepath.Dir(p.path), 0700); err != nil {
+		return fmt.Errorf("failed to create paper ledger directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(ledger, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode paper ledger: %w", err)
+	}
+
+	tmp := p.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write paper ledger: %w", err)
+	}
+
+	return os.Rename(tmp, p.path)
+}
+
+// lock moves an order's funds from free to locked
+func lock(ledger *paperLedger, order *paperOrder) {
+	asset := order.Quote
+	if order.Side == models.SideSell {
+		asset = order.Base
+	}
+
+	b := ledger.Balances[asset]
+	b.Asset = asset
+	b.Free = dust(b.Free - order.Locked)
+	b.Locked = dust(b.Locked + order.Locked)
+	ledger.Balances[asset] = b
+}
+
+// unlock returns an order's locked funds to free
+func unlock(ledger *paperLedger, order *paperOrder) {
+	order.Locked = -order.Locked
+	lock(ledger, order)
+	order.Locked = 0
+}
+
+// adjust adds amount (which may be negative) to the free balance of asset
+func adjust(ledger *paperLedger, asset string, amount float64) {
+	b := ledger.Balances[asset]
+	b.Asset = asset
+	b.Free = dust(b.Free + amount)
+	ledger.Balances[asset] = b
+}
+
+// dust rounds away float error left over from settling an amount in full
+func dust(amount float64) float64 {
+	if math.Abs(amount) < 1e-12 {
+		return 0
+	}
+	return amount
+}
+
+// splitPair splits a "BASE/QUOTE" (or "BASE-QUOTE") symbol into its assets
+func splitPair(symbol string) (base, quote string, ok bool) {
+	for _, sep := range []string{"/", "-", "_"} {
+		if base, quote, ok = strings.Cut(strings.ToUpper(symbol), sep); ok && base != "" && quote != "" {
+			return base, quote, true
+		}
+	}
+	return "", "", false
+}
+
+// formatLedgerAmount formats an amount without float noise
+func formatLedgerAmount(amount float64) string {
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(amount, 'g', 10, 64), 64)
+	return strconv.FormatFloat(rounded, 'f', -1, 64)
+}
@@ -0,0 +1,297 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+)
+
+// stubExchange serves prices set by the test and nothing else
+type stubExchange struct {
+	mu     sync.Mutex
+	prices map[string]float64
+}
+
+func (s *stubExchange) setPrice(symbol string, price float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prices[s.NormalizeSymbol(symbol)] = price
+}
+
+func (s *stubExchange) removePrice(symbol string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.prices, s.NormalizeSymbol(symbol))
+}
+
+func (s *stubExchange) GetPrice(ctx context.Context, symbol string) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	price, ok := s.prices[s.NormalizeSymbol(symbol)]
+	if !ok {
+		return 0, newError("stub", ErrUnknownSymbol, fmt.Errorf("no price for %s", symbol))
+	}
+	return price, nil
+}
+
+func (s *stubExchange) GetTicker(ctx context.Context, symbol string) (*models.Ticker, error) {
+	price, err := s.GetPrice(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Ticker{Symbol: s.NormalizeSymbol(symbol), Price: price}, nil
+}
+
+func (s *stubExchange) GetPrices(ctx context.Context, symbols []string) []Result[float64] {
+	return EachSymbol(ctx, symbols, s.GetPrice)
+}
+
+func (s *stubExchange) GetTickers(ctx context.Context, symbols []string) []Result[*models.Ticker] {
+	return EachSymbol(ctx, symbols, s.GetTicker)
+}
+
+func (s *stubExchange) GetCandles(ctx context.Context, symbol, interval string, limit int) ([]models.Candle, error) {
+	return nil, newError("stub", ErrUnsupported, errors.New("no candles"))
+}
+
+func (s *stubExchange) NormalizeSymbol(symbol string) string {
+	return strings.ReplaceAll(strings.ToUpper(symbol), "-", "/")
+}
+
+func (s *stubExchange) GetName() string {
+	return "stub"
+}
+
+func (s *stubExchange) Capabilities() Capabilities {
+	return Capabilities{}
+}
+
+// newPaperTest returns a paper account with 10000 USDT and a 0.1% fee, trading
+// BTC/USDT at 100 on a stub exchange, with its ledger in a temporary directory
+func newPaperTest(t *testing.T) (*PaperExchange, *stubExchange) {
+	t.Helper()
+
+	stub := &stubExchange{prices: map[string]float64{"BTC/USDT": 100}}
+	paper := NewPaperExchange(stub, filepath.Join(t.TempDir(), "paper", "ledger.json"), PaperOptions{
+		FeeRate:  0.001,
+		Balances: map[string]float64{"usdt": 10000},
+	})
+	return paper, stub
+}
+
+// balance returns the balance of asset, which is zero when the account holds none
+func balance(t *testing.T, paper *PaperExchange, asset string) models.Balance {
+	t.Helper()
+
+	balances, err := paper.GetBalances(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range balances {
+		if b.Asset == asset {
+			return b
+		}
+	}
+	return models.Balance{Asset: asset}
+}
+
+// assertBalance checks the free and locked amounts of asset
+func assertBalance(t *testing.T, paper *PaperExchange, asset string, free, locked float64) {
+	t.Helper()
+
+	b := balance(t, paper, asset)
+	if math.Abs(b.Free-free) > 1e-9 || math.Abs(b.Locked-locked) > 1e-9 {
+		t.Errorf("%s = %v free, %v locked; want %v free, %v locked", asset, b.Free, b.Locked, free, locked)
+	}
+}
+
+func placeOrder(t *testing.T, paper *PaperExchange, req models.OrderRequest) *models.Order {
+	t.Helper()
+
+	order, err := paper.PlaceOrder(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return order
+}
+
+func TestPaperMarketOrders(t *testing.T) {
+	paper, stub := newPaperTest(t)
+
+	buy := placeOrder(t, paper, models.OrderRequest{Symbol: "BTC/USDT", Side: models.SideBuy, Type: models.OrderTypeMarket, Quantity: 10})
+	if buy.Status != "filled" || buy.ExecutedQuantity != 10 || buy.QuoteQuantity != 1000 {
+		t.Errorf("buy = %+v, want filled for 1000", buy)
+	}
+
+	// The fee of 0.1% of 1000 is paid in the quote currency on top of the value
+	assertBalance(t, paper, "USDT", 8999, 0)
+	assertBalance(t, paper, "BTC", 10, 0)
+
+	stub.setPrice("BTC/USDT", 110)
+	placeOrder(t, paper, models.OrderRequest{Symbol: "BTC-USDT", Side: models.SideSell, Type: models.OrderTypeMarket, Quantity: 4})
+
+	// The sell's fee comes out of its 440 proceeds
+	assertBalance(t, paper, "USDT", 8999+440-0.44, 0)
+	assertBalance(t, paper, "BTC", 6, 0)
+
+	fills, err := paper.Fills(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fills) != 2 {
+		t.Fatalf("got %d fills, want 2", len(fills))
+	}
+	if f := fills[0]; f.Price != 100 || f.Fee != 1 || f.FeeAsset != "USDT" || f.Side != models.SideBuy {
+		t.Errorf("buy fill = %+v", f)
+	}
+	if f := fills[1]; f.Price != 110 || math.Abs(f.Fee-0.44) > 1e-9 || f.FeeAsset != "USDT" || f.Side != models.SideSell {
+		t.Errorf("sell fill = %+v", f)
+	}
+}
+
+func TestPaperMarketableLimitFillsAtMarket(t *testing.T) {
+	paper, _ := newPaperTest(t)
+
+	// A buy limit above the market fills at once, at the better market price
+	order := placeOrder(t, paper, models.OrderRequest{Symbol: "BTC/USDT", Side: models.SideBuy, Type: models.OrderTypeLimit, Quantity: 1, Price: 105})
+	if order.Status != "filled" || order.QuoteQuantity != 100 {
+		t.Errorf("order = %+v, want filled at 100", order)
+	}
+	assertBalance(t, paper, "USDT", 10000-100.1, 0)
+	assertBalance(t, paper, "BTC", 1, 0)
+}
+
+func TestPaperRestingLimitFillsOnSettle(t *testing.T) {
+	paper, stub := newPaperTest(t)
+	ctx := context.Background()
+
+	order := placeOrder(t, paper, models.OrderRequest{Symbol: "BTC/USDT", Side: models.SideBuy, Type: models.OrderTypeLimit, Quantity: 10, Price: 90})
+	if order.Status != paperStatusOpen {
+		t.Fatalf("order status = %s, want it resting", order.Status)
+	}
+
+	// The order locks its value at the limit price plus the fee
+	assertBalance(t, paper, "USDT", 10000-900.9, 900.9)
+
+	open, err := paper.ListOpenOrders(ctx, "BTC/USDT")
+	if err != nil || len(open) != 1 {
+		t.Fatalf("open orders = %v, %v; want the resting order", open, err)
+	}
+
+	// A price the exchange cannot serve leaves the order for the next settle
+	stub.removePrice("BTC/USDT")
+	assertBalance(t, paper, "USDT", 10000-900.9, 900.9)
+
+	// Once the market trades through the limit, the next read fills it at the limit
+	stub.setPrice("BTC/USDT", 89)
+	assertBalance(t, paper, "USDT", 10000-900.9, 0)
+	assertBalance(t, paper, "BTC", 10, 0)
+
+	got, err := paper.GetOrder(ctx, "BTC/USDT", order.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != "filled" || got.QuoteQuantity != 900 {
+		t.Errorf("order = %+v, want filled at the 90 limit", got)
+	}
+	if open, _ := paper.ListOpenOrders(ctx, ""); len(open) != 0 {
+		t.Errorf("open orders = %v, want none", open)
+	}
+}
+
+func TestPaperCancelReleasesFunds(t *testing.T) {
+	paper, _ := newPaperTest(t)
+	ctx := context.Background()
+
+	placeOrder(t, paper, models.OrderRequest{Symbol: "BTC/USDT", Side: models.SideBuy, Type: models.OrderTypeMarket, Quantity: 5})
+	sell := placeOrder(t, paper, models.OrderRequest{Symbol: "BTC/USDT", Side: models.SideSell, Type: models.OrderTypeLimit, Quantity: 3, Price: 150})
+
+	// A resting sell locks the base asset
+	assertBalance(t, paper, "BTC", 2, 3)
+
+	canceled, err := paper.CancelOrder(ctx, "BTC/USDT", sell.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if canceled.Status != "canceled" {
+		t.Errorf("status = %s, want canceled", canceled.Status)
+	}
+	assertBalance(t, paper, "BTC", 5, 0)
+
+	if _, err := paper.CancelOrder(ctx, "BTC/USDT", sell.ID); err == nil {
+		t.Error("canceling twice should fail")
+	}
+	if _, err := paper.CancelOrder(ctx, "BTC/USDT", "999"); err == nil {
+		t.Error("canceling an unknown order should fail")
+	}
+}
+
+func TestPaperRejectsOrders(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     models.OrderRequest
+		wantErr string
+	}{
+		{"buy beyond the balance", models.OrderRequest{Symbol: "BTC/USDT", Side: models.SideBuy, Type: models.OrderTypeMarket, Quantity: 100},
+			"insufficient USDT balance: need 10010, have 10000"},
+		{"resting buy beyond the balance", models.OrderRequest{Symbol: "BTC/USDT", Side: models.SideBuy, Type: models.OrderTypeLimit, Quantity: 200, Price: 50},
+			"insufficient USDT balance"},
+		{"sell without holdings", models.OrderRequest{Symbol: "BTC/USDT", Side: models.SideSell, Type: models.OrderTypeMarket, Quantity: 1},
+			"insufficient BTC balance"},
+		{"zero quantity", models.OrderRequest{Symbol: "BTC/USDT", Side: models.SideBuy, Type: models.OrderTypeMarket},
+			"quantity must be positive"},
+		{"limit without price", models.OrderRequest{Symbol: "BTC/USDT", Side: models.SideBuy, Type: models.OrderTypeLimit, Quantity: 1},
+			"price must be positive"},
+		{"symbol without quote", models.OrderRequest{Symbol: "BTC", Side: models.SideBuy, Type: models.OrderTypeMarket, Quantity: 1},
+			"BASE/QUOTE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paper, _ := newPaperTest(t)
+
+			_, err := paper.PlaceOrder(context.Background(), tt.req)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+
+			// A rejected order leaves the account as it was
+			assertBalance(t, paper, "USDT", 10000, 0)
+			assertBalance(t, paper, "BTC", 0, 0)
+		})
+	}
+
+	t.Run("stop-limit", func(t *testing.T) {
+		paper, _ := newPaperTest(t)
+		_, err := paper.PlaceOrder(context.Background(), models.OrderRequest{
+			Symbol: "BTC/USDT", Side: models.SideBuy, Type: models.OrderTypeStopLimit, Quantity: 1, Price: 100, StopPrice: 99,
+		})
+		if !errors.Is(err, ErrUnsupported) {
+			t.Errorf("err = %v, want ErrUnsupported", err)
+		}
+	})
+}
+
+func TestPaperLedgerPersists(t *testing.T) {
+	paper, stub := newPaperTest(t)
+	placeOrder(t, paper, models.OrderRequest{Symbol: "BTC/USDT", Side: models.SideBuy, Type: models.OrderTypeMarket, Quantity: 2})
+
+	// A new client on the same ledger sees the account, not a fresh one
+	reopened := NewPaperExchange(stub, paper.Path(), PaperOptions{FeeRate: 0.001, Balances: map[string]float64{"USDT": 50}})
+	assertBalance(t, reopened, "USDT", 10000-200.2, 0)
+	assertBalance(t, reopened, "BTC", 2, 0)
+
+	if err := reopened.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	assertBalance(t, reopened, "USDT", 50, 0)
+	assertBalance(t, reopened, "BTC", 0, 0)
+}
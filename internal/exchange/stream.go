@@ -0,0 +1,181 @@
+package exchange
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+	"github.com/gorilla/websocket"
+)
+
+// Streamer is implemented by exchanges that can push live price updates over a websocket
+type Streamer interface {
+	// SubscribeTickers streams price updates for the given symbols until ctx is cancelled.
+	// The returned channel is closed when the stream stops.
+	SubscribeTickers(ctx context.Context, symbols []string) (<-chan models.PriceUpdate, error)
+}
+
+// streamTimings are the heartbeat and reconnect intervals of a stream
+type streamTimings struct {
+	// readTimeout is how long a connection may stay silent before it is considered dead
+	readTimeout time.Duration
+
+	// pingInterval is how often a client ping is sent to keep the connection alive
+	pingInterval time.Duration
+
+	// minBackoff and maxBackoff bound the delay between reconnect attempts
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+// defaultStreamTimings are the timings of every exchange feed
+var defaultStreamTimings = streamTimings{
+	readTimeout:  60 * time.Second,
+	pingInterval: 20 * time.Second,
+	minBackoff:   1 * time.Second,
+	maxBackoff:   30 * time.Second,
+}
+
+// streamConfig describes how to talk to an exchange websocket feed
+type streamConfig struct {
+	// url is the websocket endpoint to dial
+	url string
+
+	// subscribe sends the subscription messages; it runs after every (re)connect
+	subscribe func(conn *websocket.Conn) error
+
+	// parse converts a raw message into zero or more price updates
+	parse func(message []byte) ([]models.PriceUpdate, error)
+
+	// timings overrides defaultStreamTimings when set
+	timings *streamTimings
+}
+
+// timing returns the stream's timings
+func (cfg streamConfig) timing() streamTimings {
+	if cfg.timings != nil {
+		return *cfg.timings
+	}
+	return defaultStreamTimings
+}
+
+// dialStream connects to the feed and sends the subscription
+func dialStream(ctx context.Context, cfg streamConfig) (*websocket.Conn, error) {
+	dialer := websocket.Dialer{HandshakeTimeout: 10 * time.Second}
+
+	conn, _, err := dialer.DialContext(ctx, cfg.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", cfg.url, err)
+	}
+
+	if cfg.subscribe != nil {
+		if err := cfg.subscribe(conn); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to subscribe: %w", err)
+		}
+	}
+
+	return conn, nil
+}
+
+// startStream connects once synchronously, so that callers can fall back to polling
+// when streaming is unavailable, then keeps the stream alive in the background,
+// reconnecting and resubscribing with exponential backoff.
+func startStream(ctx context.Context, cfg streamConfig) (<-chan models.PriceUpdate, error) {
+	conn, err := dialStream(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(chan models.PriceUpdate, 64)
+	timings := cfg.timing()
+
+	go func() {
+		defer close(updates)
+
+		backoff := timings.minBackoff
+		for {
+			if conn != nil {
+				readStream(ctx, conn, cfg, updates)
+				conn = nil
+				backoff = timings.minBackoff
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+
+			conn, err = dialStream(ctx, cfg)
+			if err != nil {
+				backoff = min(backoff*2, timings.maxBackoff)
+			}
+		}
+	}()
+
+	return updates, nil
+}
+
+// readStream pumps messages from conn into updates until the connection fails or ctx is cancelled
+func readStream(ctx context.Context, conn *websocket.Conn, cfg streamConfig, updates chan<- models.PriceUpdate) {
+	defer conn.Close()
+
+	done := make(chan struct{})
+	defer close(done)
+
+	timings := cfg.timing()
+
+	// Any frame from the server, including pings and pongs, counts as a heartbeat
+	conn.SetReadDeadline(time.Now().Add(timings.readTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(timings.readTimeout))
+	})
+	conn.SetPingHandler(func(data string) error {
+		conn.SetReadDeadline(time.Now().Add(timings.readTimeout))
+		return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(5*time.Second))
+	})
+
+	// Send client pings and unblock the reader when ctx is cancelled
+	go func() {
+		ticker := time.NewTicker(timings.pingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				conn.Close()
+				return
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(5*time.Second)); err != nil {
+					conn.Close()
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		conn.SetReadDeadline(time.Now().Add(timings.readTimeout))
+
+		parsed, err := cfg.parse(message)
+		if err != nil {
+			continue
+		}
+
+		for _, update := range parsed {
+			select {
+			case updates <- update:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,349 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+	"github.com/gorilla/websocket"
+)
+
+// newStreamServer serves a websocket feed and returns its ws:// URL. handle runs
+// once per connection with the connection's number, counting from 1, and the
+// connection is dropped when it returns.
+func newStreamServer(t *testing.T, handle func(conn *websocket.Conn, r *http.Request, n int)) string {
+	t.Helper()
+
+	var upgrader websocket.Upgrader
+	var connections atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		defer conn.Close()
+		handle(conn, r, int(connections.Add(1)))
+	}))
+	t.Cleanup(server.Close)
+
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+// streamContext returns a context cancelled when the test ends, before its servers close
+func streamContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	return ctx
+}
+
+// receive waits for the next update on a stream
+func receive(t *testing.T, updates <-chan models.PriceUpdate) models.PriceUpdate {
+	t.Helper()
+
+	select {
+	case update, ok := <-updates:
+		if !ok {
+			t.Fatal("stream closed")
+		}
+		return update
+	case <-time.After(5 * time.Second):
+		t.Fatal("no update within 5s")
+	}
+	return models.PriceUpdate{}
+}
+
+// drain reads from conn until it fails, so that control frames are answered
+func drain(conn *websocket.Conn) {
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// textStream is a stream whose subscription and updates are plain text
+// messages; each message is delivered as an update for that symbol
+func textStream(url string, timings streamTimings) streamConfig {
+	return streamConfig{
+		url: url,
+		subscribe: func(conn *websocket.Conn) error {
+			return conn.WriteMessage(websocket.TextMessage, []byte("subscribe"))
+		},
+		parse: func(message []byte) ([]models.PriceUpdate, error) {
+			if string(message) == "garbage" {
+				return nil, errors.New("unparseable")
+			}
+			return []models.PriceUpdate{{Symbol: string(message)}}, nil
+		},
+		timings: &timings,
+	}
+}
+
+// fastTimings reconnect at once and never ping unless a test asks for it
+var fastTimings = streamTimings{
+	readTimeout:  5 * time.Second,
+	pingInterval: time.Hour,
+	minBackoff:   10 * time.Millisecond,
+	maxBackoff:   50 * time.Millisecond,
+}
+
+func TestStreamReconnectsAndResubscribes(t *testing.T) {
+	var subscriptions atomic.Int32
+	url := newStreamServer(t, func(conn *websocket.Conn, r *http.Request, n int) {
+		if _, message, err := conn.ReadMessage(); err != nil || string(message) != "subscribe" {
+			t.Errorf("connection %d: subscription = %q, %v", n, message, err)
+			return
+		}
+		subscriptions.Add(1)
+
+		conn.WriteMessage(websocket.TextMessage, []byte("garbage"))
+		conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf("update-%d", n)))
+		if n == 1 {
+			// Drop the first connection without a close frame
+			return
+		}
+		drain(conn)
+	})
+
+	updates, err := startStream(streamContext(t), textStream(url, fastTimings))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The unparseable message is skipped rather than ending the stream
+	for _, want := range []string{"update-1", "update-2"} {
+		if got := receive(t, updates).Symbol; got != want {
+			t.Errorf("update = %q, want %q", got, want)
+		}
+	}
+	if got := subscriptions.Load(); got != 2 {
+		t.Errorf("subscribed %d times, want once per connection", got)
+	}
+}
+
+func TestStreamReconnectsAfterSilence(t *testing.T) {
+	url := newStreamServer(t, func(conn *websocket.Conn, r *http.Request, n int) {
+		conn.ReadMessage()
+		if n == 1 {
+			// Stay silent, and do not read, so that no pong is sent either
+			time.Sleep(time.Second)
+			return
+		}
+		conn.WriteMessage(websocket.TextMessage, []byte("after-reconnect"))
+		drain(conn)
+	})
+
+	timings := fastTimings
+	timings.readTimeout = 100 * time.Millisecond
+
+	start := time.Now()
+	updates, err := startStream(streamContext(t), textStream(url, timings))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := receive(t, updates).Symbol; got != "after-reconnect" {
+		t.Errorf("update = %q, want one from the second connection", got)
+	}
+	if elapsed := time.Since(start); elapsed > 800*time.Millisecond {
+		t.Errorf("reconnected after %s, want soon after the 100ms read timeout", elapsed)
+	}
+}
+
+func TestStreamClientPingsKeepConnectionAlive(t *testing.T) {
+	var pings atomic.Int32
+	url := newStreamServer(t, func(conn *websocket.Conn, r *http.Request, n int) {
+		conn.SetPingHandler(func(data string) error {
+			pings.Add(1)
+			return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+		})
+		go func() {
+			// No data for several read timeouts, only pongs
+			time.Sleep(500 * time.Millisecond)
+			conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf("update-%d", n)))
+		}()
+		drain(conn)
+	})
+
+	timings := fastTimings
+	timings.readTimeout = 150 * time.Millisecond
+	timings.pingInterval = 30 * time.Millisecond
+
+	updates, err := startStream(streamContext(t), textStream(url, timings))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := receive(t, updates).Symbol; got != "update-1" {
+		t.Errorf("update = %q, want one from the first connection", got)
+	}
+	if got := pings.Load(); got < 3 {
+		t.Errorf("server saw %d pings, want several", got)
+	}
+}
+
+func TestStreamServerPingsKeepConnectionAlive(t *testing.T) {
+	var pongs atomic.Int32
+	url := newStreamServer(t, func(conn *websocket.Conn, r *http.Request, n int) {
+		conn.SetPongHandler(func(string) error {
+			pongs.Add(1)
+			return nil
+		})
+		go drain(conn)
+
+		for range 15 {
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second)); err != nil {
+				return
+			}
+			time.Sleep(30 * time.Millisecond)
+		}
+		conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf("update-%d", n)))
+		time.Sleep(time.Second)
+	})
+
+	timings := fastTimings
+	timings.readTimeout = 150 * time.Millisecond
+
+	updates, err := startStream(streamContext(t), textStream(url, timings))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := receive(t, updates).Symbol; got != "update-1" {
+		t.Errorf("update = %q, want one from the first connection", got)
+	}
+	if got := pongs.Load(); got < 3 {
+		t.Errorf("server saw %d pongs, want one per ping", got)
+	}
+}
+
+func TestStreamStopsOnCancel(t *testing.T) {
+	url := newStreamServer(t, func(conn *websocket.Conn, r *http.Request, n int) {
+		drain(conn)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates, err := startStream(ctx, textStream(url, fastTimings))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+
+	select {
+	case _, ok := <-updates:
+		if ok {
+			t.Error("unexpected update")
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("stream not closed after cancel")
+	}
+}
+
+func TestStreamDialFailure(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	server.Close()
+
+	if _, err := startStream(context.Background(), textStream(url, fastTimings)); err == nil {
+		t.Error("expected an error so that callers can fall back to polling")
+	}
+}
+
+func TestBinanceSubscribeTickers(t *testing.T) {
+	url := newStreamServer(t, func(conn *websocket.Conn, r *http.Request, n int) {
+		// Binance subscribes through the URL, so a reconnect must carry the streams again
+		if got := r.URL.Query().Get("streams"); got != "btcusdt@miniTicker/ethusdt@miniTicker" {
+			t.Errorf("connection %d: streams = %q", n, got)
+		}
+
+		if n == 1 {
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"stream":"btcusdt@miniTicker","data":{"e":"24hrMiniTicker","E":1700000000000,"s":"BTCUSDT","c":"65000.50","o":"64000","h":"66000","l":"63000","v":"10","q":"650000"}}`))
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"result":null,"id":1}`))
+			return
+		}
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"stream":"ethusdt@miniTicker","data":{"e":"24hrMiniTicker","E":1700000001000,"s":"ETHUSDT","c":"3500.25"}}`))
+		drain(conn)
+	})
+
+	client, err := NewBinanceClient("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.wsURL = url
+
+	updates, err := client.SubscribeTickers(streamContext(t), []string{"BTC", "ETH"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []models.PriceUpdate{
+		{Symbol: "BTCUSDT", Price: 65000.5, Timestamp: time.UnixMilli(1700000000000)},
+		{Symbol: "ETHUSDT", Price: 3500.25, Timestamp: time.UnixMilli(1700000001000)},
+	}
+	for _, want := range tests {
+		got := receive(t, updates)
+		if got.Symbol != want.Symbol || got.Price != want.Price || !got.Timestamp.Equal(want.Timestamp) {
+			t.Errorf("update = %+v, want %+v", got, want)
+		}
+	}
+}
+
+func TestCoinbaseSubscribeTickers(t *testing.T) {
+	var subscriptions atomic.Int32
+	url := newStreamServer(t, func(conn *websocket.Conn, r *http.Request, n int) {
+		var sub coinbaseSubscribeMessage
+		if err := conn.ReadJSON(&sub); err != nil {
+			t.Errorf("connection %d: %v", n, err)
+			return
+		}
+		if sub.Type != "subscribe" || strings.Join(sub.ProductIDs, ",") != "BTC-USD,ETH-USD" ||
+			strings.Join(sub.Channels, ",") != "ticker,heartbeat" {
+			t.Errorf("connection %d: subscription = %+v", n, sub)
+		}
+		subscriptions.Add(1)
+
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"subscriptions","channels":[{"name":"ticker","product_ids":["BTC-USD","ETH-USD"]}]}`))
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"heartbeat","product_id":"BTC-USD","sequence":1,"time":"2024-01-01T00:00:00Z"}`))
+		if n == 1 {
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"ticker","product_id":"BTC-USD","price":"65000.50","time":"2024-01-01T00:00:01.5Z"}`))
+			return
+		}
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"ticker","product_id":"ETH-USD","price":"3500.25","time":"2024-01-01T00:00:02Z"}`))
+		drain(conn)
+	})
+
+	client, err := NewCoinbaseV2Client("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.wsURL = url
+
+	updates, err := client.SubscribeTickers(streamContext(t), []string{"BTC", "ETH"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Subscription confirmations and heartbeats carry no price
+	tests := []models.PriceUpdate{
+		{Symbol: "BTC-USD", Price: 65000.5, Timestamp: time.Date(2024, 1, 1, 0, 0, 1, 5e8, time.UTC)},
+		{Symbol: "ETH-USD", Price: 3500.25, Timestamp: time.Date(2024, 1, 1, 0, 0, 2, 0, time.UTC)},
+	}
+	for _, want := range tests {
+		got := receive(t, updates)
+		if got.Symbol != want.Symbol || got.Price != want.Price || !got.Timestamp.Equal(want.Timestamp) {
+			t.Errorf("update = %+v, want %+v", got, want)
+		}
+	}
+
+	if got := subscriptions.Load(); got != 2 {
+		t.Errorf("subscribed %d times, want again after the reconnect", got)
+	}
+}
@@ -0,0 +1,357 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// defaultMaxRetries is how many times a failed idempotent request is retried
+	defaultMaxRetries = 3
+
+	// defaultMinBackoff and defaultMaxBackoff bound the exponential retry delay
+	defaultMinBackoff = 250 * time.Millisecond
+	defaultMaxBackoff = 8 * time.Second
+
+	// maxRetryAfter is the longest Retry-After we are willing to wait inside a request;
+	// longer waits are returned to the caller as a rate limit error instead
+	maxRetryAfter = 30 * time.Second
+
+	// breakerThreshold consecutive failures open a host's circuit for breakerCooldown
+	breakerThreshold = 5
+	breakerCooldown  = 30 * time.Second
+
+	// requestTimeout bounds a single HTTP attempt
+	requestTimeout = 10 * time.Second
+)
+
+// RequestMetrics describes one logical request made through the shared transport
+type RequestMetrics struct {
+	Exchange string
+	Method   string
+	Host     string
+	Path     string
+	Status   int
+	Attempts int
+	Duration time.Duration
+	Err      error
+}
+
+var (
+	observerMu      sync.RWMutex
+	requestObserver func(RequestMetrics)
+)
+
+// SetRequestObserver registers a function called after every REST request made by any
+// exchange client, e.g. to log latency and retries. Pass nil to remove it.
+func SetRequestObserver(fn func(RequestMetrics)) {
+	observerMu.Lock()
+	defer observerMu.Unlock()
+	requestObserver = fn
+}
+
+func observeRequest(m RequestMetrics) {
+	observerMu.RLock()
+	fn := requestObserver
+	observerMu.RUnlock()
+
+	if fn != nil {
+		fn(m)
+	}
+}
+
+// weightBudget tracks a server-reported request weight, such as Binance's
+// X-MBX-USED-WEIGHT-1M header, and pauses requests when the budget is nearly spent
+type weightBudget struct {
+	header string
+	limit  int
+	window time.Duration
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+// observe records the used weight reported by a response
+func (w *weightBudget) observe(resp *http.Response) {
+	used, err := strconv.Atoi(resp.Header.Get(w.header))
+	if err != nil {
+		return
+	}
+
+	// Leave 10% headroom; the window resets at the next boundary
+	if used*10 >= w.limit*9 {
+		w.mu.Lock()
+		w.pausedUntil = time.Now().Truncate(w.window).Add(w.window)
+		w.mu.Unlock()
+	}
+}
+
+// wait blocks until the budget window has reset, if it is exhausted
+func (w *weightBudget) wait(ctx context.Context) error {
+	w.mu.Lock()
+	until := w.pausedUntil
+	w.mu.Unlock()
+
+	return sleepContext(ctx, time.Until(until))
+}
+
+// breaker is a per-host circuit breaker
+type breaker struct {
+	failures  int
+	openUntil time.Time
+}
+
+// transport is an http.RoundTripper shared by every exchange client. It rate
+// limits each attempt, retries idempotent requests with exponential backoff and
+// jitter, honours Retry-After and weight headers, and opens a circuit for hosts
+// that keep failing.
+type transport struct {
+	name    string
+	base    http.RoundTripper
+	limiter *rate.Limiter
+	weight  *weightBudget
+
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+
+	mu       sync.Mutex
+	breakers map[string]*breaker
+}
+
+// transportOption customizes a transport
+type transportOption func(*transport)
+
+// withWeightBudget pauses requests when the given response header reports that
+// more than 90% of limit has been used within the window
+func withWeightBudget(header string, limit int, window time.Duration) transportOption {
+	return func(t *transport) {
+		t.weight = &weightBudget{header: header, limit: limit, window: window}
+	}
+}
+
+// newHTTPClient returns an HTTP client whose requests go through the shared transport
+func newHTTPClient(name string, limiter *rate.Limiter, opts ...transportOption) *http.Client {
+	t := &transport{
+		name:       name,
+		base:       http.DefaultTransport,
+		limiter:    limiter,
+		maxRetries: defaultMaxRetries,
+		minBackoff: defaultMinBackoff,
+		maxBackoff: defaultMaxBackoff,
+		breakers:   make(map[string]*breaker),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return &http.Client{Transport: t}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	start := time.Now()
+	metrics := RequestMetrics{
+		Exchange: t.name,
+		Method:   req.Method,
+		Host:     req.URL.Host,
+		Path:     req.URL.Path,
+	}
+
+	var resp *http.Response
+	var err error
+	if until, open := t.open(req.URL.Host); open {
+		err = newError(t.name, ErrUnavailable,
+			fmt.Errorf("too many failures from %s, paused until %s", req.URL.Host, until.Format("15:04:05")))
+	} else {
+		resp, err = t.roundTrip(req, &metrics)
+
+		// A cancelled request is neither a success nor a host failure
+		if ctx.Err() == nil {
+			t.record(req.URL.Host, !hostFailure(resp, err))
+		}
+	}
+
+	metrics.Duration = time.Since(start)
+	metrics.Err = err
+	if resp != nil {
+		metrics.Status = resp.StatusCode
+	}
+	observeRequest(metrics)
+
+	return resp, err
+}
+
+func (t *transport) roundTrip(req *http.Request, metrics *RequestMetrics) (*http.Response, error) {
+	ctx := req.Context()
+
+	// Only requests without side effects are safe to repeat
+	retryable := req.Method == http.MethodGet || req.Method == http.MethodHead
+
+	for attempt := 0; ; attempt++ {
+		metrics.Attempts = attempt + 1
+
+		if t.weight != nil {
+			if err := t.weight.wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+		if err := t.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		attemptCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		resp, err := t.base.RoundTrip(req.Clone(attemptCtx))
+		if err != nil {
+			cancel()
+			if ctx.Err() != nil || !retryable || attempt >= t.maxRetries {
+				return nil, err
+			}
+			if err := sleepContext(ctx, t.backoff(attempt)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+
+		if t.weight != nil {
+			t.weight.observe(resp)
+		}
+
+		if !retryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+
+		// Rate limits (429, and 418 for Binance IP bans) say how long to wait
+		delay := t.backoff(attempt)
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusTeapot {
+			if retryAfter := parseRetryAfter(resp.Header.Get("Retry-After")); retryAfter > 0 {
+				delay = retryAfter
+			}
+			if !retryable || attempt >= t.maxRetries || delay > maxRetryAfter {
+				body, _ := io.ReadAll(resp.Body)
+				resp.Body.Close()
+
+				e := newError(t.name, ErrRateLimited,
+					fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(body))))
+				e.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+				return nil, e
+			}
+		} else if !retryable || attempt >= t.maxRetries {
+			return resp, nil
+		}
+
+		// Drain so the connection can be reused
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// backoff returns the delay before retry number attempt+1: exponential with full jitter
+func (t *transport) backoff(attempt int) time.Duration {
+	d := t.minBackoff << attempt
+	if d <= 0 || d > t.maxBackoff {
+		d = t.maxBackoff
+	}
+	return time.Duration(rand.Int63n(int64(d)) + 1)
+}
+
+// open reports whether the circuit for host is open
+func (t *transport) open(host string) (time.Time, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	b, ok := t.breakers[host]
+	if !ok {
+		return time.Time{}, false
+	}
+	return b.openUntil, time.Now().Before(b.openUntil)
+}
+
+// record updates the circuit for host after a request; after the cooldown a
+// single failure is enough to open it again
+func (t *transport) record(host string, success bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	b, ok := t.breakers[host]
+	if !ok {
+		b = &breaker{}
+		t.breakers[host] = b
+	}
+
+	if success {
+		b.failures = 0
+		b.openUntil = time.Time{}
+		return
+	}
+
+	b.failures++
+	if b.failures >= breakerThreshold {
+		b.openUntil = time.Now().Add(breakerCooldown)
+	}
+}
+
+// hostFailure reports whether a request outcome counts against the host's circuit.
+// Rate limiting means the host is healthy but busy, so it does not count.
+func hostFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, ErrRateLimited)
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
+// retryableStatus reports whether a response status is worth retrying
+func retryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusTeapot,
+		http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// sleepContext sleeps for d or until ctx is cancelled
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// cancelOnClose releases an attempt's timeout context once its body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
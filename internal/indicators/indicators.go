@@ -0,0 +1,323 @@
+// Package indicators computes technical indicators over candles. Every indicator
+// is streaming: it is fed one value or candle at a time with Update and keeps only
+// the state it needs, so it can follow a live feed as well as a fetched history.
+package indicators
+
+import (
+	"math"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+)
+
+// Closes returns the close price of every candle
+func Closes(candles []models.Candle) []float64 {
+	closes := make([]float64, len(candles))
+	for i, c := range candles {
+		closes[i] = c.Close
+	}
+	return closes
+}
+
+// Series feeds values through update and returns one output per input,
+// NaN until the indicator has seen enough data
+func Series(values []float64, update func(float64) (float64, bool)) []float64 {
+	out := make([]float64, len(values))
+	for i, v := range values {
+		result, ok := update(v)
+		if !ok {
+			result = math.NaN()
+		}
+		out[i] = result
+	}
+	return out
+}
+
+// CandleSeries is Series for indicators that consume whole candles
+func CandleSeries(candles []models.Candle, update func(models.Candle) (float64, bool)) []float64 {
+	out := make([]float64, len(candles))
+	for i, c := range candles {
+		result, ok := update(c)
+		if !ok {
+			result = math.NaN()
+		}
+		out[i] = result
+	}
+	return out
+}
+
+// SMA is a simple moving average
+type SMA struct {
+	period int
+	window []float64
+	next   int
+	count  int
+	sum    float64
+}
+
+// NewSMA returns a simple moving average over period values
+func NewSMA(period int) *SMA {
+	period = max(period, 1)
+	return &SMA{period: period, window: make([]float64, period)}
+}
+
+// Update adds a value and returns the average once period values have been seen
+func (s *SMA) Update(value float64) (float64, bool) {
+	s.sum += value - s.window[s.next]
+	s.window[s.next] = value
+	s.next = (s.next + 1) % s.period
+	if s.count < s.period {
+		s.count++
+	}
+
+	if s.count < s.period {
+		return 0, false
+	}
+	return s.sum / float64(s.period), true
+}
+
+// EMA is an exponential moving average, seeded with the SMA of its first period values
+type EMA struct {
+	alpha float64
+	seed  *SMA
+	value float64
+	ready bool
+}
+
+// NewEMA returns an exponential moving average with smoothing 2/(period+1)
+func NewEMA(period int) *EMA {
+	period = max(period, 1)
+	return &EMA{alpha: 2 / float64(period+1), seed: NewSMA(period)}
+}
+
+// Update adds a value and returns the average once period values have been seen
+func (e *EMA) Update(value float64) (float64, bool) {
+	if !e.ready {
+		seed, ok := e.seed.Update(value)
+		if !ok {
+			return 0, false
+		}
+		e.value = seed
+		e.ready = true
+		return e.value, true
+	}
+
+	e.value += e.alpha * (value - e.value)
+	return e.value, true
+}
+
+// wilder is Wilder's smoothing (an EMA with alpha 1/period), seeded with an SMA
+type wilder struct {
+	period int
+	seed   *SMA
+	value  float64
+	ready  bool
+}
+
+func newWilder(period int) *wilder {
+	period = max(period, 1)
+	return &wilder{period: period, seed: NewSMA(period)}
+}
+
+func (w *wilder) update(value float64) (float64, bool) {
+	if !w.ready {
+		seed, ok := w.seed.Update(value)
+		if !ok {
+			return 0, false
+		}
+		w.value = seed
+		w.ready = true
+		return w.value, true
+	}
+
+	w.value = (w.value*float64(w.period-1) + value) / float64(w.period)
+	return w.value, true
+}
+
+// RSI is Wilder's relative strength index, from 0 to 100
+type RSI struct {
+	gains  *wilder
+	losses *wilder
+	prev   float64
+	primed bool
+}
+
+// NewRSI returns a relative strength index over period price changes
+func NewRSI(period int) *RSI {
+	return &RSI{gains: newWilder(period), losses: newWilder(period)}
+}
+
+// Update adds a close price and returns the RSI once period changes have been seen
+func (r *RSI) Update(price float64) (float64, bool) {
+	if !r.primed {
+		r.prev = price
+		r.primed = true
+		return 0, false
+	}
+
+	change := price - r.prev
+	r.prev = price
+
+	gain, _ := r.gains.update(math.Max(change, 0))
+	loss, ok := r.losses.update(math.Max(-change, 0))
+	if !ok {
+		return 0, false
+	}
+
+	if loss == 0 {
+		if gain == 0 {
+			return 50, true
+		}
+		return 100, true
+	}
+	return 100 - 100/(1+gain/loss), true
+}
+
+// MACDValue is one output of the MACD indicator
+type MACDValue struct {
+	MACD      float64 `json:"macd"`
+	Signal    float64 `json:"signal"`
+	Histogram float64 `json:"histogram"`
+}
+
+// MACD is the moving average convergence divergence: the difference between a
+// fast and a slow EMA, with an EMA of that difference as the signal line
+type MACD struct {
+	fast   *EMA
+	slow   *EMA
+	signal *EMA
+}
+
+// NewMACD returns a MACD with the given EMA periods, conventionally 12, 26 and 9
+func NewMACD(fast, slow, signal int) *MACD {
+	return &MACD{fast: NewEMA(fast), slow: NewEMA(slow), signal: NewEMA(signal)}
+}
+
+// Update adds a close price and returns the MACD once the signal line is ready
+func (m *MACD) Update(price float64) (MACDValue, bool) {
+	fast, fastOK := m.fast.Update(price)
+	slow, slowOK := m.slow.Update(price)
+	if !fastOK || !slowOK {
+		return MACDValue{}, false
+	}
+
+	macd := fast - slow
+	signal, ok := m.signal.Update(macd)
+	if !ok {
+		return MACDValue{}, false
+	}
+
+	return MACDValue{MACD: macd, Signal: signal, Histogram: macd - signal}, true
+}
+
+// Band is one output of Bollinger Bands
+type Band struct {
+	Upper  float64 `json:"upper"`
+	Middle float64 `json:"middle"`
+	Lower  float64 `json:"lower"`
+}
+
+// Bollinger computes Bollinger Bands: an SMA with bands a number of standard
+// deviations above and below it
+type Bollinger struct {
+	mean   *SMA
+	window []float64
+	next   int
+	k      float64
+}
+
+// NewBollinger returns Bollinger Bands over period values, k standard deviations wide
+func NewBollinger(period int, k float64) *Bollinger {
+	period = max(period, 1)
+	return &Bollinger{mean: NewSMA(period), window: make([]float64, period), k: k}
+}
+
+// Update adds a close price and returns the bands once period values have been seen
+func (b *Bollinger) Update(price float64) (Band, bool) {
+	b.window[b.next] = price
+	b.next = (b.next + 1) % len(b.window)
+
+	mean, ok := b.mean.Update(price)
+	if !ok {
+		return Band{}, false
+	}
+
+	// Population standard deviation, as in Bollinger's definition
+	var variance float64
+	for _, v := range b.window {
+		variance += (v - mean) * (v - mean)
+	}
+	deviation := math.Sqrt(variance / float64(len(b.window)))
+
+	return Band{
+		Upper:  mean + b.k*deviation,
+		Middle: mean,
+		Lower:  mean - b.k*deviation,
+	}, true
+}
+
+// ATR is Wilder's average true range
+type ATR struct {
+	smooth    *wilder
+	prevClose float64
+	primed    bool
+}
+
+// NewATR returns an average true range over period candles
+func NewATR(period int) *ATR {
+	return &ATR{smooth: newWilder(period)}
+}
+
+// Update adds a candle and returns the ATR once period candles have been seen
+func (a *ATR) Update(c models.Candle) (float64, bool) {
+	trueRange := c.High - c.Low
+	if a.primed {
+		trueRange = math.Max(trueRange, math.Max(math.Abs(c.High-a.prevClose), math.Abs(c.Low-a.prevClose)))
+	}
+	a.prevClose = c.Close
+	a.primed = true
+
+	return a.smooth.update(trueRange)
+}
+
+// VWAP is the volume-weighted average price of the typical price (high+low+close)/3
+type VWAP struct {
+	anchor     func(models.Candle) int64
+	session    int64
+	priceVol   float64
+	volume     float64
+	hasSession bool
+}
+
+// NewVWAP returns a VWAP accumulated since the first candle
+func NewVWAP() *VWAP {
+	return &VWAP{}
+}
+
+// NewDailyVWAP returns a VWAP that restarts at every UTC midnight, the usual
+// anchor for intraday charts
+func NewDailyVWAP() *VWAP {
+	return &VWAP{anchor: func(c models.Candle) int64 {
+		return c.Time.UTC().Unix() / 86400
+	}}
+}
+
+// Update adds a candle and returns the VWAP; it is not ready until some volume has traded
+func (v *VWAP) Update(c models.Candle) (float64, bool) {
+	if v.anchor != nil {
+		session := v.anchor(c)
+		if v.hasSession && session != v.session {
+			v.priceVol, v.volume = 0, 0
+		}
+		v.session = session
+		v.hasSession = true
+	}
+
+	typical := (c.High + c.Low + c.Close) / 3
+	v.priceVol += typical * c.Volume
+	v.volume += c.Volume
+
+	if v.volume == 0 {
+		return 0, false
+	}
+	return v.priceVol / v.volume, true
+}
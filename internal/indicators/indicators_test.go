@@ -0,0 +1,217 @@
+package indicators
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+)
+
+// closes is the 20-close sample of the common RSI(14) worked example
+var closes = []float64{
+	44.34, 44.09, 44.15, 43.61, 44.33, 44.83, 45.10, 45.42, 45.84, 46.08,
+	45.89, 46.03, 45.61, 46.28, 46.28, 46.00, 46.03, 46.41, 46.22, 45.64,
+}
+
+const tolerance = 1e-9
+
+// assertSeries compares got against want, where NaN in want means "not ready"
+func assertSeries(t *testing.T, got, want []float64) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d values, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if math.IsNaN(want[i]) {
+			if !math.IsNaN(got[i]) {
+				t.Errorf("value %d = %v, want not ready", i, got[i])
+			}
+			continue
+		}
+		if math.Abs(got[i]-want[i]) > tolerance {
+			t.Errorf("value %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSMA(t *testing.T) {
+	nan := math.NaN()
+
+	tests := []struct {
+		name   string
+		period int
+		values []float64
+		want   []float64
+	}{
+		{"rolling", 3, []float64{1, 2, 3, 4, 5}, []float64{nan, nan, 2, 3, 4}},
+		{"period 1", 1, []float64{4, 6}, []float64{4, 6}},
+		{"zero period", 0, []float64{4, 6}, []float64{4, 6}},
+		{"short input", 5, []float64{1, 2}, []float64{nan, nan}},
+		{"empty", 3, nil, []float64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertSeries(t, Series(tt.values, NewSMA(tt.period).Update), tt.want)
+		})
+	}
+}
+
+func TestEMA(t *testing.T) {
+	nan := math.NaN()
+
+	// Seeded with the SMA of 1, 2, 3, then smoothed with alpha 2/(3+1) = 0.5
+	assertSeries(t, Series([]float64{1, 2, 3, 4, 5}, NewEMA(3).Update), []float64{nan, nan, 2, 3, 4})
+
+	got := Series(closes, NewEMA(10).Update)
+	if want := 45.87036561912815; math.Abs(got[len(got)-1]-want) > tolerance {
+		t.Errorf("EMA(10) = %v, want %v", got[len(got)-1], want)
+	}
+
+	assertSeries(t, Series([]float64{1, 2}, NewEMA(10).Update), []float64{nan, nan})
+}
+
+func TestRSI(t *testing.T) {
+	nan := math.NaN()
+
+	t.Run("wilder smoothing", func(t *testing.T) {
+		want := make([]float64, len(closes))
+		for i := range 14 {
+			want[i] = nan
+		}
+		copy(want[14:], []float64{
+			70.46413502109705, 66.24961855355505, 66.48094183471265,
+			69.34685316290866, 66.29471265892624, 57.91502067008556,
+		})
+		assertSeries(t, Series(closes, NewRSI(14).Update), want)
+	})
+
+	tests := []struct {
+		name   string
+		values []float64
+		want   []float64
+	}{
+		{"only gains", []float64{1, 2, 3}, []float64{nan, nan, 100}},
+		{"only losses", []float64{3, 2, 1}, []float64{nan, nan, 0}},
+		{"flat", []float64{2, 2, 2}, []float64{nan, nan, 50}},
+		{"short input", []float64{1, 2}, []float64{nan, nan}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertSeries(t, Series(tt.values, NewRSI(2).Update), tt.want)
+		})
+	}
+}
+
+func TestMACD(t *testing.T) {
+	macd := NewMACD(3, 6, 4)
+
+	var last MACDValue
+	for i, price := range closes {
+		value, ok := macd.Update(price)
+		// The slow EMA is ready at the 6th close and the signal three values later
+		if want := i >= 8; ok != want {
+			t.Fatalf("close %d: ready = %v, want %v", i, ok, want)
+		}
+		last = value
+	}
+
+	want := MACDValue{MACD: -0.06354852124444932, Signal: 0.0417042779648594, Histogram: -0.10525279920930872}
+	if math.Abs(last.MACD-want.MACD) > tolerance ||
+		math.Abs(last.Signal-want.Signal) > tolerance ||
+		math.Abs(last.Histogram-want.Histogram) > tolerance {
+		t.Errorf("MACD = %+v, want %+v", last, want)
+	}
+
+	short := NewMACD(12, 26, 9)
+	for _, price := range closes {
+		if _, ok := short.Update(price); ok {
+			t.Fatal("MACD(12, 26, 9) ready before 34 closes")
+		}
+	}
+}
+
+func TestBollinger(t *testing.T) {
+	bands := NewBollinger(3, 2)
+
+	if _, ok := bands.Update(1); ok {
+		t.Error("ready after one value")
+	}
+	if _, ok := bands.Update(2); ok {
+		t.Error("ready after two values")
+	}
+
+	tests := []struct {
+		price float64
+		want  Band
+	}{
+		// Window 1, 2, 3: mean 2, population deviation sqrt(2/3)
+		{3, Band{Upper: 2 + 2*math.Sqrt(2.0/3), Middle: 2, Lower: 2 - 2*math.Sqrt(2.0/3)}},
+		// Window 2, 3, 7: mean 4, population deviation sqrt(14/3)
+		{7, Band{Upper: 4 + 2*math.Sqrt(14.0/3), Middle: 4, Lower: 4 - 2*math.Sqrt(14.0/3)}},
+	}
+
+	for i, tt := range tests {
+		band, ok := bands.Update(tt.price)
+		if !ok {
+			t.Fatalf("update %d not ready", i)
+		}
+		if math.Abs(band.Upper-tt.want.Upper) > tolerance ||
+			math.Abs(band.Middle-tt.want.Middle) > tolerance ||
+			math.Abs(band.Lower-tt.want.Lower) > tolerance {
+			t.Errorf("update %d = %+v, want %+v", i, band, tt.want)
+		}
+	}
+
+	// A flat window collapses the bands onto the mean
+	flat := NewBollinger(3, 2)
+	var band Band
+	for _, price := range []float64{5, 5, 5} {
+		band, _ = flat.Update(price)
+	}
+	if band != (Band{Upper: 5, Middle: 5, Lower: 5}) {
+		t.Errorf("flat bands = %+v, want all 5", band)
+	}
+
+	if _, ok := NewBollinger(20, 2).Update(5); ok {
+		t.Error("Bollinger(20) ready after one value")
+	}
+}
+
+func TestATR(t *testing.T) {
+	nan := math.NaN()
+
+	candles := []models.Candle{
+		{High: 10, Low: 8, Close: 9},
+		{High: 12, Low: 10.5, Close: 11}, // gaps up: true range is high - previous close
+		{High: 12, Low: 10, Close: 11},
+		{High: 11.5, Low: 9.5, Close: 10},
+		{High: 13, Low: 10.5, Close: 12.5}, // true range is high - previous close
+	}
+
+	// True ranges 2, 3, 2, 2, 3, seeded with their SMA and then Wilder-smoothed
+	assertSeries(t, CandleSeries(candles, NewATR(3).Update), []float64{
+		nan, nan, 7.0 / 3, 20.0 / 9, 67.0 / 27,
+	})
+
+	assertSeries(t, CandleSeries(candles[:2], NewATR(14).Update), []float64{nan, nan})
+}
+
+func TestVWAP(t *testing.T) {
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	candles := []models.Candle{
+		{Time: day, High: 12, Low: 9, Close: 9, Volume: 0},                       // no volume yet
+		{Time: day.Add(time.Hour), High: 12, Low: 9, Close: 9, Volume: 2},        // typical 10
+		{Time: day.Add(2 * time.Hour), High: 13, Low: 10, Close: 13, Volume: 3},  // typical 12
+		{Time: day.Add(24 * time.Hour), High: 21, Low: 18, Close: 21, Volume: 1}, // typical 20, next day
+	}
+
+	nan := math.NaN()
+	assertSeries(t, CandleSeries(candles, NewVWAP().Update), []float64{nan, 10, 11.2, 76.0 / 6})
+	assertSeries(t, CandleSeries(candles, NewDailyVWAP().Update), []float64{nan, 10, 11.2, 20})
+	assertSeries(t, CandleSeries(nil, NewVWAP().Update), []float64{})
+}
@@ -0,0 +1,264 @@
+package markets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+)
+
+// maxSuggestions is the number of close matches offered for an unknown symbol
+const maxSuggestions = 5
+
+// Catalog is the list of markets listed on one exchange
+type Catalog struct {
+	Exchange  string          `json:"exchange"`
+	FetchedAt time.Time       `json:"fetched_at"`
+	Markets   []models.Market `json:"markets"`
+}
+
+// UnknownSymbolError is returned when a symbol does not match any listed market
+type UnknownSymbolError struct {
+	Symbol      string
+	Suggestions []string
+}
+
+func (e *UnknownSymbolError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("unknown symbol: %s", e.Symbol)
+	}
+	return fmt.Sprintf("unknown symbol: %s (did you mean %s?)", e.Symbol, strings.Join(e.Suggestions, ", "))
+}
+
+// Is makes UnknownSymbolError match exchange.ErrUnknownSymbol
+func (e *UnknownSymbolError) Is(target error) bool {
+	return target == exchange.ErrUnknownSymbol
+}
+
+// Load returns the catalog for an exchange, reading it from the cache file in dir
+// when it is younger than ttl and fetching (then caching) it otherwise.
+// A stale cache is still used if the exchange cannot be reached.
+func Load(ctx context.Context, lister exchange.MarketLister, exchangeName, dir string, ttl time.Duration, refresh bool) (*Catalog, error) {
+	path := filepath.Join(dir, "markets-"+exchangeName+".json")
+
+	cached, cacheErr := readCache(path)
+	if cacheErr == nil && !refresh && time.Since(cached.FetchedAt) < ttl {
+		return cached, nil
+	}
+
+	markets, err := lister.ListMarkets(ctx)
+	if err != nil {
+		if cacheErr == nil {
+			return cached, nil
+		}
+		return nil, err
+	}
+
+	catalog := &Catalog{
+		Exchange:  exchangeName,
+		FetchedAt: time.Now(),
+		Markets:   markets,
+	}
+
+	if err := writeCache(path, catalog); err != nil {
+		return nil, err
+	}
+
+	return catalog, nil
+}
+
+// readCache loads a catalog from disk
+func readCache(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var catalog Catalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to decode market cache: %w", err)
+	}
+
+	return &catalog, nil
+}
+
+// writeCache stores a catalog on disk, replacing the file atomically
+func writeCache(path string, catalog *Catalog) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(catalog)
+	if err != nil {
+		return fmt.Errorf("failed to encode market cache: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write market cache: %w", err)
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// Filter returns the markets quoted in quote (if set) whose symbol or base contains search (if set)
+func (c *Catalog) Filter(quote, search string) []models.Market {
+	quote = strings.ToUpper(quote)
+	search = strings.ToUpper(search)
+
+	var result []models.Market
+	for _, m := range c.Markets {
+		if quote != "" && !strings.EqualFold(m.Quote, quote) {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToUpper(m.Symbol), search) && !strings.Contains(strings.ToUpper(m.Base), search) {
+			continue
+		}
+		result = append(result, m)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Symbol < result[j].Symbol
+	})
+
+	return result
+}
+
+// Resolve maps a user-supplied symbol to a listed market. The exchange's own
+// normalization is tried first, so a bare asset ("PEPE", "WBTC") gets the
+// default quote; a pair written without the exchange's separator ("ETHBTC") is
+// then matched against every listed base and quote, and finally a bare asset
+// that normalization misread as a pair is looked up as a base.
+func (c *Catalog) Resolve(symbol string, normalize func(string) string) (models.Market, error) {
+	// Exchange-specific normalization, e.g. appending the default quote
+	if m, ok := c.find(normalize(symbol)); ok {
+		return m, nil
+	}
+
+	// Exact pair, ignoring separators
+	if m, ok := c.Pair(symbol); ok {
+		return m, nil
+	}
+
+	// A listed base in the default quote, e.g. "STETH" read as ST/ETH
+	compact := compactSymbol(symbol)
+	if quote, ok := c.find(normalize("BTC")); ok {
+		for _, m := range c.Markets {
+			if compactSymbol(m.Base) == compact && m.Quote == quote.Quote {
+				return m, nil
+			}
+		}
+	}
+
+	return models.Market{}, &UnknownSymbolError{
+		Symbol:      symbol,
+		Suggestions: c.suggest(symbol, normalize),
+	}
+}
+
+// suggest returns listed symbols that look like the requested one
+func (c *Catalog) suggest(symbol string, normalize func(string) string) []string {
+	wanted := compactSymbol(symbol)
+	wantedQuote := ""
+	if m, ok := c.find(normalize("BTC")); ok {
+		wantedQuote = m.Quote
+	}
+
+	type candidate struct {
+		symbol string
+		score  int
+	}
+
+	var candidates []candidate
+	for _, m := range c.Markets {
+		if m.Status != models.MarketStatusTrading {
+			continue
+		}
+
+		// Compare against both the bare base and the full pair
+		score := levenshtein(wanted, compactSymbol(m.Base))
+		if d := levenshtein(wanted, compactSymbol(m.Base+m.Quote)); d < score {
+			score = d
+		}
+		if strings.HasPrefix(compactSymbol(m.Base), wanted) || strings.HasPrefix(wanted, compactSymbol(m.Base)) {
+			score--
+		}
+		if m.Quote != wantedQuote {
+			score++
+		}
+
+		if score <= 2 {
+			candidates = append(candidates, candidate{symbol: m.Symbol, score: score})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score < candidates[j].score
+		}
+		return candidates[i].symbol < candidates[j].symbol
+	})
+
+	var suggestions []string
+	for i := 0; i < len(candidates) && i < maxSuggestions; i++ {
+		suggestions = append(suggestions, candidates[i].symbol)
+	}
+	return suggestions
+}
+
+// find looks a market up by its exchange symbol
+func (c *Catalog) find(symbol string) (models.Market, bool) {
+	for _, m := range c.Markets {
+		if m.Symbol == symbol {
+			return m, true
+		}
+	}
+	return models.Market{}, false
+}
+
+// Pair looks a market up by its base and quote, ignoring case and separators,
+// e.g. "ethbtc" or "ETH/BTC"
+func (c *Catalog) Pair(symbol string) (models.Market, bool) {
+	compact := compactSymbol(symbol)
+	for _, m := range c.Markets {
+		if compactSymbol(m.Base+m.Quote) == compact {
+			return m, true
+		}
+	}
+	return models.Market{}, false
+}
+
+// compactSymbol upper-cases a symbol and strips separators
+func compactSymbol(symbol string) string {
+	return strings.NewReplacer("/", "", "-", "", "_", "").Replace(strings.ToUpper(symbol))
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
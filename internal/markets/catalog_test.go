@@ -0,0 +1,112 @@
+package markets
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+)
+
+// binanceCatalog lists a few Binance markets, including W/BTC, whose compact
+// form collides with the WBTC asset, and assets named after a quote currency
+var binanceCatalog = &Catalog{
+	Exchange: "binance",
+	Markets: []models.Market{
+		{Symbol: "BTCUSDT", Base: "BTC", Quote: "USDT", Status: models.MarketStatusTrading},
+		{Symbol: "ETHBTC", Base: "ETH", Quote: "BTC", Status: models.MarketStatusTrading},
+		{Symbol: "PEPEUSDT", Base: "PEPE", Quote: "USDT", Status: models.MarketStatusTrading},
+		{Symbol: "WBTC", Base: "W", Quote: "BTC", Status: models.MarketStatusTrading},
+		{Symbol: "WBTCUSDT", Base: "WBTC", Quote: "USDT", Status: models.MarketStatusTrading},
+		{Symbol: "1000SATSUSDT", Base: "1000SATS", Quote: "USDT", Status: models.MarketStatusTrading},
+		{Symbol: "STETHUSDT", Base: "STETH", Quote: "USDT", Status: models.MarketStatusTrading},
+		{Symbol: "WBETHETH", Base: "WBETH", Quote: "ETH", Status: models.MarketStatusTrading},
+		{Symbol: "WBETHUSDT", Base: "WBETH", Quote: "USDT", Status: models.MarketStatusTrading},
+		{Symbol: "AEURUSDT", Base: "AEUR", Quote: "USDT", Status: models.MarketStatusTrading},
+	},
+}
+
+func TestResolve(t *testing.T) {
+	client, err := exchange.NewBinanceClient("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		symbol string
+		want   string
+	}{
+		{"PEPE", "PEPEUSDT"},
+		{"pepe/usdt", "PEPEUSDT"},
+		{"WBTC", "WBTCUSDT"},
+		{"W/BTC", "WBTC"},
+		{"1000SATS", "1000SATSUSDT"},
+		{"ETHBTC", "ETHBTC"},
+		{"ETH-BTC", "ETHBTC"},
+		{"BTC", "BTCUSDT"},
+		{"STETH", "STETHUSDT"},
+		{"WBETH", "WBETHUSDT"},
+		{"WBETH/ETH", "WBETHETH"},
+		{"AEUR", "AEURUSDT"},
+	}
+
+	// Resolving must not depend on whether the client has been given the markets
+	for _, listed := range []bool{false, true} {
+		if listed {
+			client.SetMarkets(binanceCatalog.Pair)
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.symbol, func(t *testing.T) {
+				market, err := binanceCatalog.Resolve(tt.symbol, client.NormalizeSymbol)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if market.Symbol != tt.want {
+					t.Errorf("Resolve(%q) = %s, want %s (markets set: %v)", tt.symbol, market.Symbol, tt.want, listed)
+				}
+			})
+		}
+	}
+}
+
+func TestPair(t *testing.T) {
+	tests := []struct {
+		symbol string
+		want   string
+	}{
+		{"ethbtc", "ETHBTC"},
+		{"ETH/BTC", "ETHBTC"},
+		{"W-BTC", "WBTC"},
+		{"WBETHETH", "WBETHETH"},
+		{"STETH", ""},
+		{"ETH", ""},
+	}
+
+	for _, tt := range tests {
+		market, ok := binanceCatalog.Pair(tt.symbol)
+		if market.Symbol != tt.want || ok != (tt.want != "") {
+			t.Errorf("Pair(%q) = %s, %v; want %q", tt.symbol, market.Symbol, ok, tt.want)
+		}
+	}
+}
+
+func TestResolveUnknown(t *testing.T) {
+	client, err := exchange.NewBinanceClient("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = binanceCatalog.Resolve("PEPPE", client.NormalizeSymbol)
+
+	var unknown *UnknownSymbolError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("err = %v, want *UnknownSymbolError", err)
+	}
+	if !errors.Is(err, exchange.ErrUnknownSymbol) {
+		t.Errorf("err = %v, want it to match ErrUnknownSymbol", err)
+	}
+	if len(unknown.Suggestions) == 0 || unknown.Suggestions[0] != "PEPEUSDT" {
+		t.Errorf("suggestions = %v, want PEPEUSDT first", unknown.Suggestions)
+	}
+}
@@ -0,0 +1,94 @@
+package markets
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+)
+
+// CheckOrder checks an order against the market's trading status, tick size,
+// lot size and minimum notional before it is sent, so that a typo is caught
+// without a round trip. price is what the order is expected to fill at: the
+// limit price, or the last price for a market order (zero skips the notional
+// check). Rejections suggest the nearest valid values.
+func CheckOrder(market models.Market, req models.OrderRequest, price float64) error {
+	if market.Status != models.MarketStatusTrading {
+		return fmt.Errorf("%s is not trading (status %s)", market.Symbol, market.Status)
+	}
+
+	if req.Quantity <= 0 {
+		return fmt.Errorf("quantity must be positive")
+	}
+	if !onStep(req.Quantity, market.LotSize) {
+		return fmt.Errorf("quantity %s is not a multiple of the %s lot size %s; try %s",
+			formatStep(req.Quantity), market.Symbol, formatStep(market.LotSize), nearest(req.Quantity, market.LotSize))
+	}
+
+	// Allow for float error in quantity*price right at the minimum
+	if notional := req.Quantity * price; market.MinNotional > 0 && price > 0 && notional < market.MinNotional*(1-1e-9) {
+		return fmt.Errorf("order value %s %s is below the %s minimum of %s; try a quantity of at least %s",
+			formatStep(notional), market.Quote, market.Symbol, formatStep(market.MinNotional),
+			minQuantity(market.MinNotional/price, market.LotSize))
+	}
+
+	if req.Type == models.OrderTypeMarket {
+		return nil
+	}
+
+	if req.Price <= 0 {
+		return fmt.Errorf("price must be positive")
+	}
+	if !onStep(req.Price, market.TickSize) {
+		return fmt.Errorf("price %s is not a multiple of the %s tick size %s; try %s",
+			formatStep(req.Price), market.Symbol, formatStep(market.TickSize), nearest(req.Price, market.TickSize))
+	}
+
+	if req.Type == models.OrderTypeStopLimit {
+		if req.StopPrice <= 0 {
+			return fmt.Errorf("stop price must be positive")
+		}
+		if !onStep(req.StopPrice, market.TickSize) {
+			return fmt.Errorf("stop price %s is not a multiple of the %s tick size %s; try %s",
+				formatStep(req.StopPrice), market.Symbol, formatStep(market.TickSize), nearest(req.StopPrice, market.TickSize))
+		}
+	}
+
+	return nil
+}
+
+// onStep reports whether value is a whole number of steps, allowing for float
+// error. A zero step means the exchange did not report one.
+func onStep(value, step float64) bool {
+	if step <= 0 {
+		return true
+	}
+	n := value / step
+	return math.Abs(n-math.Round(n)) < 1e-6
+}
+
+// nearest returns the valid values on either side of value, or the one above
+// it when rounding down would give zero
+func nearest(value, step float64) string {
+	lower := math.Floor(value/step) * step
+	upper := math.Ceil(value/step) * step
+	if lower <= 0 {
+		return formatStep(upper)
+	}
+	return formatStep(lower) + " or " + formatStep(upper)
+}
+
+// minQuantity returns the smallest valid quantity of at least quantity
+func minQuantity(quantity, step float64) string {
+	if step <= 0 {
+		return formatStep(quantity)
+	}
+	return formatStep(math.Ceil(quantity/step-1e-6) * step)
+}
+
+// formatStep formats a quantity or price without float noise
+func formatStep(value float64) string {
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(value, 'g', 12, 64), 64)
+	return strconv.FormatFloat(rounded, 'f', -1, 64)
+}
@@ -0,0 +1,81 @@
+package markets
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+)
+
+func TestCheckOrder(t *testing.T) {
+	btc := models.Market{
+		Symbol: "BTCUSDT", Base: "BTC", Quote: "USDT", Status: models.MarketStatusTrading,
+		TickSize: 0.01, LotSize: 0.0001, MinNotional: 5,
+	}
+
+	// 0.29 * 100 is 28.999999999999996 in floating point
+	coarse := models.Market{
+		Symbol: "SOLUSDT", Base: "SOL", Quote: "USDT", Status: models.MarketStatusTrading,
+		TickSize: 0.01, LotSize: 0.01, MinNotional: 29,
+	}
+
+	halted := btc
+	halted.Status = models.MarketStatusHalted
+
+	limit := func(quantity, price float64) models.OrderRequest {
+		return models.OrderRequest{Symbol: "BTCUSDT", Side: models.SideBuy, Type: models.OrderTypeLimit, Quantity: quantity, Price: price}
+	}
+	market := func(quantity float64) models.OrderRequest {
+		return models.OrderRequest{Symbol: "BTCUSDT", Side: models.SideBuy, Type: models.OrderTypeMarket, Quantity: quantity}
+	}
+
+	tests := []struct {
+		name   string
+		market models.Market
+		req    models.OrderRequest
+		price  float64
+
+		// wantErr is a substring of the expected error, empty for a valid order
+		wantErr string
+	}{
+		{"valid limit", btc, limit(0.001, 65000.01), 65000.01, ""},
+		{"quantity on step despite float error", btc, limit(0.0003, 20000), 20000, ""},
+		{"price on tick despite float error", coarse, models.OrderRequest{Type: models.OrderTypeLimit, Quantity: 100, Price: 0.29}, 0.29, ""},
+		{"quantity between steps", btc, limit(0.00015, 65000), 65000, "try 0.0001 or 0.0002"},
+		{"quantity below one step", btc, limit(0.00005, 65000), 65000, "try 0.0001"},
+		{"zero quantity", btc, limit(0, 65000), 65000, "quantity must be positive"},
+		{"price between ticks", btc, limit(0.001, 65000.005), 65000.005, "try 65000 or 65000.01"},
+		{"zero price", btc, limit(0.001, 0), 0, "price must be positive"},
+		{"halted", halted, limit(0.001, 65000), 65000, "not trading"},
+
+		{"at min notional", btc, limit(0.0002, 25000), 25000, ""},
+		{"at min notional despite float error", coarse, models.OrderRequest{Type: models.OrderTypeLimit, Quantity: 0.29, Price: 100}, 100, ""},
+		{"below min notional", btc, limit(0.0001, 20000), 20000, "order value 2 USDT is below the BTCUSDT minimum of 5; try a quantity of at least 0.0003"},
+		{"market order below min notional", btc, market(0.0001), 20000, "below the BTCUSDT minimum of 5"},
+		{"market order above min notional", btc, market(0.001), 20000, ""},
+		{"market order without a price", btc, market(0.0001), 0, ""},
+		{"no minimum reported", models.Market{Symbol: "X", Status: models.MarketStatusTrading}, limit(0.0001, 1), 1, ""},
+
+		{"stop price between ticks", btc, models.OrderRequest{
+			Type: models.OrderTypeStopLimit, Quantity: 0.001, Price: 65000, StopPrice: 64999.999,
+		}, 65000, "stop price 64999.999 is not a multiple"},
+		{"stop-limit without stop price", btc, models.OrderRequest{
+			Type: models.OrderTypeStopLimit, Quantity: 0.001, Price: 65000,
+		}, 65000, "stop price must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckOrder(tt.market, tt.req, tt.price)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckOrder() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckOrder() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -11,16 +11,25 @@ type Ticker struct {
 	High24h     float64   `json:"high_24h"`
 	Low24h      float64   `json:"low_24h"`
 	LastUpdated time.Time `json:"last_updated"`
+
+	// Bid and Ask are the best bid and ask, zero when the exchange does not report them
+	Bid float64 `json:"bid,omitempty"`
+	Ask float64 `json:"ask,omitempty"`
 }
 
-// Candle represents OHLCV (Open, High, Low, Close, Volume) data
+// Candle represents OHLCV (Open, High, Low, Close, Volume) data.
+// Time is the open time and CloseTime the last instant the candle covers.
+// Volume is in the base asset; QuoteVolume is in the quote currency, or zero
+// when the exchange does not report it.
 type Candle struct {
-	Time   time.Time `json:"time"`
-	Open   float64   `json:"open"`
-	High   float64   `json:"high"`
-	Low    float64   `json:"low"`
-	Close  float64   `json:"close"`
-	Volume float64   `json:"volume"`
+	Time        time.Time `json:"time"`
+	CloseTime   time.Time `json:"close_time"`
+	Open        float64   `json:"open"`
+	High        float64   `json:"high"`
+	Low         float64   `json:"low"`
+	Close       float64   `json:"close"`
+	Volume      float64   `json:"volume"`
+	QuoteVolume float64   `json:"quote_volume"`
 }
 
 // PriceUpdate represents a real-time price update
@@ -29,3 +38,117 @@ type PriceUpdate struct {
 	Price     float64   `json:"price"`
 	Timestamp time.Time `json:"timestamp"`
 }
+
+// OrderBookLevel represents a single price level in an order book
+type OrderBookLevel struct {
+	Price    float64 `json:"price"`
+	Quantity float64 `json:"quantity"`
+}
+
+// OrderBook represents a snapshot of the bids and asks for a symbol.
+// Bids are sorted from highest to lowest price, asks from lowest to highest.
+type OrderBook struct {
+	Symbol    string           `json:"symbol"`
+	Bids      []OrderBookLevel `json:"bids"`
+	Asks      []OrderBookLevel `json:"asks"`
+	UpdateID  int64            `json:"update_id"`
+	Timestamp time.Time        `json:"timestamp"`
+}
+
+// Side is the direction of a trade or order
+type Side string
+
+const (
+	SideBuy  Side = "buy"
+	SideSell Side = "sell"
+)
+
+// Trade represents a single executed trade; Side is the aggressor (taker) side
+type Trade struct {
+	ID       string    `json:"id"`
+	Symbol   string    `json:"symbol"`
+	Price    float64   `json:"price"`
+	Quantity float64   `json:"quantity"`
+	Side     Side      `json:"side"`
+	Time     time.Time `json:"time"`
+}
+
+// Notional returns the trade value in the quote currency
+func (t Trade) Notional() float64 {
+	return t.Price * t.Quantity
+}
+
+// Market statuses
+const (
+	MarketStatusTrading = "trading"
+	MarketStatusHalted  = "halted"
+)
+
+// Market describes a tradable pair listed on an exchange
+type Market struct {
+	Symbol   string  `json:"symbol"`
+	Base     string  `json:"base"`
+	Quote    string  `json:"quote"`
+	Status   string  `json:"status"`
+	TickSize float64 `json:"tick_size"`
+	LotSize  float64 `json:"lot_size"`
+
+	// MinNotional is the smallest order value, in the quote currency (zero if not reported)
+	MinNotional float64 `json:"min_notional,omitempty"`
+}
+
+// Balance is the amount of one asset held in an exchange account
+type Balance struct {
+	Asset  string  `json:"asset"`
+	Free   float64 `json:"free"`
+	Locked float64 `json:"locked"`
+}
+
+// Total returns the free and locked amounts together
+func (b Balance) Total() float64 {
+	return b.Free + b.Locked
+}
+
+// OrderType is how an order is priced
+type OrderType string
+
+const (
+	// OrderTypeMarket fills immediately at the best available prices
+	OrderTypeMarket OrderType = "market"
+
+	// OrderTypeLimit fills at Price or better
+	OrderTypeLimit OrderType = "limit"
+
+	// OrderTypeStopLimit becomes a limit order at Price once the market trades at StopPrice
+	OrderTypeStopLimit OrderType = "stop_limit"
+)
+
+// OrderRequest describes an order to place
+type OrderRequest struct {
+	Symbol    string    `json:"symbol"`
+	Side      Side      `json:"side"`
+	Type      OrderType `json:"type"`
+	Quantity  float64   `json:"quantity"`
+	Price     float64   `json:"price,omitempty"`
+	StopPrice float64   `json:"stop_price,omitempty"`
+}
+
+// Order is an order placed on an exchange. Status is the exchange's order
+// status in lower case, e.g. "new", "partially_filled", "filled" or "canceled".
+type Order struct {
+	ID               string    `json:"id"`
+	ClientOrderID    string    `json:"client_order_id,omitempty"`
+	Symbol           string    `json:"symbol"`
+	Side             Side      `json:"side"`
+	Type             OrderType `json:"type"`
+	Status           string    `json:"status"`
+	Price            float64   `json:"price"`
+	StopPrice        float64   `json:"stop_price,omitempty"`
+	Quantity         float64   `json:"quantity"`
+	ExecutedQuantity float64   `json:"executed_quantity"`
+
+	// QuoteQuantity is the quote currency spent or received on the executed quantity
+	QuoteQuantity float64   `json:"quote_quantity"`
+	Time          time.Time `json:"time"`
+	UpdateTime    time.Time `json:"update_time,omitzero"`
+}
@@ -0,0 +1,235 @@
+// Package portfolio stores holdings and values them at live prices.
+package portfolio
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+	"go.yaml.in/yaml/v3"
+)
+
+// Holding is a quantity of an asset held in one place
+type Holding struct {
+	ID       int     `yaml:"id" json:"id"`
+	Asset    string  `yaml:"asset" json:"asset"`
+	Quantity float64 `yaml:"quantity" json:"quantity"`
+
+	// CostBasis is the total paid for the holding in the display currency, 0 if unknown
+	CostBasis float64 `yaml:"cost_basis" json:"cost_basis"`
+
+	// Label says where the holding is kept, e.g. "binance" or "ledger"
+	Label   string    `yaml:"label,omitempty" json:"label,omitempty"`
+	AddedAt time.Time `yaml:"added_at" json:"added_at"`
+}
+
+// Validate checks that the holding is complete
+func (h Holding) Validate() error {
+	if h.Asset == "" {
+		return fmt.Errorf("holding needs an asset")
+	}
+	if h.Quantity <= 0 {
+		return fmt.Errorf("quantity must be positive")
+	}
+	if h.CostBasis < 0 {
+		return fmt.Errorf("cost basis must not be negative")
+	}
+	return nil
+}
+
+// file is the layout of portfolio.yaml
+type file struct {
+	Holdings []Holding `yaml:"holdings"`
+}
+
+// Path returns the location of the holdings file in dir
+func Path(dir string) string {
+	return filepath.Join(dir, "portfolio.yaml")
+}
+
+// Load reads the holdings stored at path, ordered by ID. A missing file holds none.
+func Load(path string) ([]Holding, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read portfolio: %w", err)
+	}
+
+	var f file
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+
+	sort.Slice(f.Holdings, func(i, j int) bool { return f.Holdings[i].ID < f.Holdings[j].ID })
+	return f.Holdings, nil
+}
+
+// Save writes holdings to path, replacing the file atomically
+func Save(path string, holdings []Holding) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create portfolio directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(file{Holdings: holdings})
+	if err != nil {
+		return fmt.Errorf("failed to encode portfolio: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write portfolio: %w", err)
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// Add validates holding, gives it the next free ID and appends it to the file at path
+func Add(path string, holding Holding) (Holding, error) {
+	holding.Asset = strings.ToUpper(holding.Asset)
+	if err := holding.Validate(); err != nil {
+		return Holding{}, err
+	}
+
+	holdings, err := Load(path)
+	if err != nil {
+		return Holding{}, err
+	}
+
+	holding.ID = 1
+	for _, h := range holdings {
+		holding.ID = max(holding.ID, h.ID+1)
+	}
+	if holding.AddedAt.IsZero() {
+		holding.AddedAt = time.Now().UTC()
+	}
+
+	if err := Save(path, append(holdings, holding)); err != nil {
+		return Holding{}, err
+	}
+	return holding, nil
+}
+
+// Remove deletes the holdings with the given IDs from the file at path and returns
+// the ones it removed. Unknown IDs are an error and leave the file unchanged.
+func Remove(path string, ids ...int) ([]Holding, error) {
+	holdings, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	remove := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		remove[id] = true
+	}
+
+	var kept, removed []Holding
+	for _, h := range holdings {
+		if remove[h.ID] {
+			removed = append(removed, h)
+			delete(remove, h.ID)
+		} else {
+			kept = append(kept, h)
+		}
+	}
+
+	if len(remove) > 0 {
+		var missing []string
+		for _, id := range ids {
+			if remove[id] {
+				missing = append(missing, strconv.Itoa(id))
+			}
+		}
+		return nil, fmt.Errorf("no holding with ID %s", strings.Join(missing, ", "))
+	}
+
+	if err := Save(path, kept); err != nil {
+		return nil, err
+	}
+	return removed, nil
+}
+
+// Position is a holding valued at the current price
+type Position struct {
+	Holding
+
+	Price float64 `json:"price"`
+	Value float64 `json:"value"`
+
+	// Allocation is the share of the portfolio's value, in percent
+	Allocation float64 `json:"allocation"`
+
+	// UnrealizedPnL is Value less the cost basis; zero when the cost is unknown
+	UnrealizedPnL float64 `json:"unrealized_pnl"`
+
+	// PnL24h is the change in Value over the last 24 hours
+	PnL24h float64 `json:"pnl_24h"`
+
+	// Err is why the holding could not be valued
+	Err error `json:"-"`
+}
+
+// Valuation is a portfolio valued at current prices
+type Valuation struct {
+	Currency  string
+	Positions []Position
+
+	// Totals over the positions that could be valued
+	Value         float64
+	CostBasis     float64
+	UnrealizedPnL float64
+	PnL24h        float64
+}
+
+// Value prices each holding with the ticker for its asset, quoted in currency.
+// Holdings of the currency itself are worth their quantity. A holding whose
+// asset is missing from tickers gets the error in errs, if any.
+func Value(holdings []Holding, currency string, tickers map[string]*models.Ticker, errs map[string]error) Valuation {
+	v := Valuation{Currency: currency, Positions: make([]Position, len(holdings))}
+
+	for i, h := range holdings {
+		p := Position{Holding: h}
+
+		switch ticker, ok := tickers[h.Asset]; {
+		case strings.EqualFold(h.Asset, currency):
+			p.Price = 1
+		case ok:
+			p.Price = ticker.Price
+			p.PnL24h = h.Quantity * ticker.Change24h
+		default:
+			p.Err = errs[h.Asset]
+			if p.Err == nil {
+				p.Err = fmt.Errorf("no price for %s", h.Asset)
+			}
+			v.Positions[i] = p
+			continue
+		}
+
+		p.Value = h.Quantity * p.Price
+		if h.CostBasis > 0 {
+			p.UnrealizedPnL = p.Value - h.CostBasis
+		}
+
+		v.Value += p.Value
+		v.CostBasis += h.CostBasis
+		v.UnrealizedPnL += p.UnrealizedPnL
+		v.PnL24h += p.PnL24h
+		v.Positions[i] = p
+	}
+
+	if v.Value > 0 {
+		for i := range v.Positions {
+			if v.Positions[i].Err == nil {
+				v.Positions[i].Allocation = v.Positions[i].Value / v.Value * 100
+			}
+		}
+	}
+
+	return v
+}
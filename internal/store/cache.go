@@ -0,0 +1,207 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+)
+
+// CachedExchange records the market data an exchange returns in a Store and
+// serves candles from it: closed candles already on disk are read without a
+// request, and the latest candles fall back to the store when the exchange is
+// unreachable, so repeated charts and backtests work offline. Recording is best
+// effort: a store that cannot be written never fails a request.
+type CachedExchange struct {
+	exchange.Exchange
+
+	store *Store
+}
+
+// NewCachedExchange wraps inner with a read-through cache kept in store
+func NewCachedExchange(inner exchange.Exchange, store *Store) *CachedExchange {
+	return &CachedExchange{Exchange: inner, store: store}
+}
+
+// Unwrap returns the exchange the data comes from
+func (c *CachedExchange) Unwrap() exchange.Exchange {
+	return c.Exchange
+}
+
+// GetPrice returns the current price and records it
+func (c *CachedExchange) GetPrice(ctx context.Context, symbol string) (float64, error) {
+	price, err := c.Exchange.GetPrice(ctx, symbol)
+	if err == nil {
+		update := models.PriceUpdate{Symbol: c.NormalizeSymbol(symbol), Price: price, Timestamp: time.Now()}
+		c.store.AppendPrices(c.GetName(), update.Symbol, update)
+	}
+	return price, err
+}
+
+// GetTicker returns the ticker and records it
+func (c *CachedExchange) GetTicker(ctx context.Context, symbol string) (*models.Ticker, error) {
+	ticker, err := c.Exchange.GetTicker(ctx, symbol)
+	if err == nil {
+		c.store.AppendTicker(c.GetName(), c.NormalizeSymbol(symbol), *ticker)
+	}
+	return ticker, err
+}
+
+// GetPrices returns the current prices for several symbols and records them
+func (c *CachedExchange) GetPrices(ctx context.Context, symbols []string) []exchange.Result[float64] {
+	results := c.Exchange.GetPrices(ctx, symbols)
+	now := time.Now()
+	for i, r := range results {
+		if r.Err == nil {
+			update := models.PriceUpdate{Symbol: c.NormalizeSymbol(symbols[i]), Price: r.Value, Timestamp: now}
+			c.store.AppendPrices(c.GetName(), update.Symbol, update)
+		}
+	}
+	return results
+}
+
+// GetTickers returns the tickers for several symbols and records them
+func (c *CachedExchange) GetTickers(ctx context.Context, symbols []string) []exchange.Result[*models.Ticker] {
+	results := c.Exchange.GetTickers(ctx, symbols)
+	for i, r := range results {
+		if r.Err == nil {
+			c.store.AppendTicker(c.GetName(), c.NormalizeSymbol(symbols[i]), *r.Value)
+		}
+	}
+	return results
+}
+
+// SubscribeTickers streams price updates from the wrapped exchange and records them
+func (c *CachedExchange) SubscribeTickers(ctx context.Context, symbols []string) (<-chan models.PriceUpdate, error) {
+	streamer, ok := exchange.As[exchange.Streamer](c.Exchange)
+	if !ok {
+		return nil, fmt.Errorf("%s does not stream prices: %w", c.GetName(), exchange.ErrUnsupported)
+	}
+
+	updates, err := streamer.SubscribeTickers(ctx, symbols)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan models.PriceUpdate)
+	go func() {
+		defer close(out)
+		for update := range updates {
+			c.store.AppendPrices(c.GetName(), update.Symbol, update)
+			select {
+			case out <- update:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// GetCandles returns the latest limit candles. Closed candles already stored
+// without a gap are read from disk and only the missing or still open tail is
+// fetched. When the exchange is unreachable, the newest stored candles are
+// returned instead.
+func (c *CachedExchange) GetCandles(ctx context.Context, symbol, interval string, limit int) ([]models.Candle, error) {
+	key := c.NormalizeSymbol(symbol)
+	stored, missing := c.storedCandles(key, interval, limit)
+
+	candles, err := c.Exchange.GetCandles(ctx, symbol, interval, missing)
+	if err != nil {
+		if errors.Is(err, exchange.ErrUnavailable) {
+			if stored, storeErr := c.store.LastCandles(c.GetName(), key, interval, limit); storeErr == nil && len(stored) > 0 {
+				return stored, nil
+			}
+		}
+		return nil, err
+	}
+	c.store.WriteCandles(c.GetName(), key, interval, candles)
+
+	if len(candles) == 0 {
+		return stored, nil
+	}
+
+	// A fetched candle replaces a stored one with the same open time
+	n := sort.Search(len(stored), func(i int) bool { return !stored[i].Time.Before(candles[0].Time) })
+	candles = append(stored[:n:n], candles...)
+	if len(candles) > limit {
+		candles = candles[len(candles)-limit:]
+	}
+	return candles, nil
+}
+
+// storedCandles returns the closed candles among the latest limit that are on
+// disk, if they run without a gap from the oldest to the newest stored one, and
+// how many newer candles are left to fetch
+func (c *CachedExchange) storedCandles(key, interval string, limit int) ([]models.Candle, int) {
+	step, ok := exchange.IntervalDuration(interval)
+	if !ok || step > 24*time.Hour || limit < 2 {
+		return nil, limit
+	}
+
+	// The candle opening at the start of the current interval is still open
+	open := time.Now().Truncate(step)
+	start := open.Add(-time.Duration(limit-1) * step)
+
+	stored, err := c.store.Candles(c.GetName(), key, interval, start, open)
+	if err != nil || len(stored) == 0 || !complete(stored, interval, start, stored[len(stored)-1].Time.Add(step)) {
+		return nil, limit
+	}
+	return stored, limit - len(stored)
+}
+
+// GetCandleRange returns the candles opening within [start, end). A range of
+// closed candles that is complete on disk is served from the store; anything
+// else is fetched and stored.
+func (c *CachedExchange) GetCandleRange(ctx context.Context, symbol, interval string, start, end time.Time) ([]models.Candle, error) {
+	provider, ok := exchange.As[exchange.CandleHistoryProvider](c.Exchange)
+	if !ok {
+		return nil, fmt.Errorf("%s does not serve candle history: %w", c.GetName(), exchange.ErrUnsupported)
+	}
+
+	key := c.NormalizeSymbol(symbol)
+	stored, storeErr := c.store.Candles(c.GetName(), key, interval, start, end)
+	if storeErr == nil && complete(stored, interval, start, end) {
+		return stored, nil
+	}
+
+	candles, err := provider.GetCandleRange(ctx, symbol, interval, start, end)
+	if err != nil {
+		// Better a partial range than none when offline
+		if errors.Is(err, exchange.ErrUnavailable) && len(stored) > 0 {
+			return stored, nil
+		}
+		return nil, err
+	}
+
+	c.store.WriteCandles(c.GetName(), key, interval, candles)
+	return candles, nil
+}
+
+// complete reports whether candles hold every candle of a fixed interval opening
+// within [start, end), all of them closed
+func complete(candles []models.Candle, interval string, start, end time.Time) bool {
+	step, ok := exchange.IntervalDuration(interval)
+	if !ok || len(candles) == 0 {
+		return false
+	}
+	if step > 24*time.Hour {
+		// Weeks open on Mondays and months vary in length, so their count cannot be checked
+		return false
+	}
+
+	// Candles of a day or less open on multiples of the interval within the UTC day
+	want := 0
+	for t := start.Truncate(step); t.Before(end); t = t.Add(step) {
+		if !t.Before(start) {
+			want++
+		}
+	}
+
+	last := candles[len(candles)-1]
+	return len(candles) == want && !last.Time.Add(step).After(time.Now())
+}
@@ -0,0 +1,123 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/exchange"
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+)
+
+func TestComplete(t *testing.T) {
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	hours := func(from, n int) []models.Candle {
+		candles := make([]models.Candle, n)
+		for i := range candles {
+			candles[i] = models.Candle{Time: day.Add(time.Duration(from+i) * time.Hour)}
+		}
+		return candles
+	}
+	hour := func(n int) time.Time { return day.Add(time.Duration(n) * time.Hour) }
+
+	// The candle opening now has not closed yet
+	now := time.Now().UTC().Truncate(time.Hour)
+	forming := []models.Candle{{Time: now.Add(-time.Hour)}, {Time: now}}
+
+	tests := []struct {
+		name       string
+		candles    []models.Candle
+		interval   string
+		start, end time.Time
+		want       bool
+	}{
+		{"every candle", hours(0, 24), "1h", hour(0), hour(24), true},
+		{"missing candle", append(hours(0, 10), hours(11, 13)...), "1h", hour(0), hour(24), false},
+		{"no candles", nil, "1h", hour(0), hour(24), false},
+		{"unaligned start skips the candle opening before it", hours(1, 3), "1h", hour(0).Add(30 * time.Minute), hour(4), true},
+		{"unaligned end includes the candle opening before it", hours(0, 4), "1h", hour(0), hour(3).Add(time.Minute), true},
+		{"last candle still forming", forming, "1h", now.Add(-time.Hour), now.Add(time.Hour), false},
+		{"days", []models.Candle{{Time: day}, {Time: day.AddDate(0, 0, 1)}}, "1d", day, day.AddDate(0, 0, 2), true},
+		{"weeks are never complete", []models.Candle{{Time: day}}, "1w", day, day.AddDate(0, 0, 7), false},
+		{"unknown interval", hours(0, 1), "7x", hour(0), hour(1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := complete(tt.candles, tt.interval, tt.start, tt.end); got != tt.want {
+				t.Errorf("complete() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCachedGetCandlesReadsThrough(t *testing.T) {
+	// Daily candles, so that no new candle opens while the test runs
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	var mu sync.Mutex
+	var limits []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		limits = append(limits, r.URL.Query().Get("limit"))
+		mu.Unlock()
+
+		// The latest limit daily klines, the last one still open
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		klines := make([]string, limit)
+		for i := range klines {
+			open := today.AddDate(0, 0, i-limit+1)
+			klines[i] = fmt.Sprintf(`[%d,"1","1","1","%d","1",%d,"1",1,"1","1","0"]`,
+				open.UnixMilli(), open.Day(), open.AddDate(0, 0, 1).UnixMilli()-1)
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(klines, ","))
+	}))
+	defer server.Close()
+
+	client, err := exchange.NewBinanceClient("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetBaseURL(server.URL)
+	cached := NewCachedExchange(client, New(t.TempDir()))
+
+	check := func(wantLimits ...string) {
+		t.Helper()
+
+		candles, err := cached.GetCandles(context.Background(), "BTCUSDT", "1d", 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(candles) != 5 {
+			t.Fatalf("got %d candles, want 5", len(candles))
+		}
+		for i, c := range candles {
+			if want := today.AddDate(0, 0, i-4); !c.Time.Equal(want) || c.Close != float64(want.Day()) {
+				t.Errorf("candle %d = %v close %v, want %v", i, c.Time, c.Close, want)
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if fmt.Sprint(limits) != fmt.Sprint(wantLimits) {
+			t.Errorf("requested limits %v, want %v", limits, wantLimits)
+		}
+		limits = nil
+	}
+
+	// The first call fetches every candle; later ones read the closed candles
+	// from disk and fetch only the one still open
+	check("5")
+	check("1")
+	check("1")
+
+	// Offline, the stored candles are served
+	server.Close()
+	check()
+}
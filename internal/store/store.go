@@ -0,0 +1,420 @@
+// Package store keeps market data on disk: candles per exchange, symbol and
+// interval, and the tickers and price updates seen per exchange and symbol.
+// Each series is a JSON Lines file, de-duplicated by time and queried by range.
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+)
+
+// Store is a directory of market data series
+type Store struct {
+	dir string
+
+	mu sync.Mutex
+
+	// retention is how long tickers and prices are kept; zero keeps them forever
+	retention time.Duration
+
+	// lastAppended is the time of the newest record in each ticker or price file
+	// this process has appended to, so that repeats are not written twice
+	lastAppended map[string]time.Time
+
+	// sinceCompaction counts the records appended to each ticker or price file
+	// since it was last compacted
+	sinceCompaction map[string]int
+
+	// candles holds each candle series this process has read or written, so
+	// that a backfill writing window after window does not re-read the file
+	candles map[string]*candleSeries
+}
+
+// compactEvery is how many records are appended to a ticker or price file
+// between compactions, so that a long stream cannot outgrow the retention
+const compactEvery = 10000
+
+// candleSeries is a candle file as last read or written, with the size and
+// modification time that tell whether another process has changed it since
+type candleSeries struct {
+	candles []models.Candle
+	size    int64
+	modTime time.Time
+}
+
+// New returns a store kept in dir
+func New(dir string) *Store {
+	return &Store{
+		dir:             dir,
+		lastAppended:    make(map[string]time.Time),
+		sinceCompaction: make(map[string]int),
+		candles:         make(map[string]*candleSeries),
+	}
+}
+
+// SetRetention sets how long tickers and prices are kept. Older records are
+// dropped when a file is first appended to, and again every compactEvery records.
+func (s *Store) SetRetention(retention time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.retention = retention
+}
+
+// Dir returns the directory the store is kept in
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// WriteCandles merges candles into the stored series. A candle replaces a stored
+// one with the same open time, so a candle that was still forming is updated.
+// Candles newer than every stored one are appended; only candles that overlap
+// the stored series make it rewrite the file.
+func (s *Store) WriteCandles(exchangeName, symbol, interval string, candles []models.Candle) error {
+	if len(candles) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := s.path(exchangeName, symbol, "candles-"+interval)
+	stored, err := s.loadCandles(path)
+	if err != nil {
+		return err
+	}
+
+	at := func(c models.Candle) time.Time { return c.Time }
+	added := mergeByTime(nil, candles, at)
+
+	var merged []models.Candle
+	if len(stored) == 0 || added[0].Time.After(stored[len(stored)-1].Time) {
+		err = appendLines(path, added)
+		merged = append(stored, added...)
+	} else {
+		merged = mergeByTime(stored, added, at)
+		err = writeLines(path, merged)
+	}
+	if err != nil {
+		delete(s.candles, path)
+		return err
+	}
+
+	s.cacheCandles(path, merged)
+	return nil
+}
+
+// Candles returns the stored candles opening within [start, end), oldest first.
+// A zero start or end leaves that side of the range open.
+func (s *Store) Candles(exchangeName, symbol, interval string, start, end time.Time) ([]models.Candle, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	candles, err := s.loadCandles(s.path(exchangeName, symbol, "candles-"+interval))
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(timeRange(candles, start, end, func(c models.Candle) time.Time { return c.Time })), nil
+}
+
+// loadCandles returns a candle series, reading the file only when this process
+// has not seen it yet or it has changed since. The caller holds s.mu.
+func (s *Store) loadCandles(path string) ([]models.Candle, error) {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		delete(s.candles, path)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if cached, ok := s.candles[path]; ok && cached.size == info.Size() && cached.modTime.Equal(info.ModTime()) {
+		return cached.candles, nil
+	}
+
+	candles, err := readLines[models.Candle](path)
+	if err != nil {
+		return nil, err
+	}
+	candles = mergeByTime(nil, candles, func(c models.Candle) time.Time { return c.Time })
+	s.candles[path] = &candleSeries{candles: candles, size: info.Size(), modTime: info.ModTime()}
+	return candles, nil
+}
+
+// cacheCandles remembers the series just written to path. The caller holds s.mu.
+func (s *Store) cacheCandles(path string, candles []models.Candle) {
+	info, err := os.Stat(path)
+	if err != nil {
+		delete(s.candles, path)
+		return
+	}
+	s.candles[path] = &candleSeries{candles: candles, size: info.Size(), modTime: info.ModTime()}
+}
+
+// LastCandles returns up to n of the newest stored candles, oldest first
+func (s *Store) LastCandles(exchangeName, symbol, interval string, n int) ([]models.Candle, error) {
+	candles, err := s.Candles(exchangeName, symbol, interval, time.Time{}, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	if n > 0 && len(candles) > n {
+		candles = candles[len(candles)-n:]
+	}
+	return candles, nil
+}
+
+// AppendTicker adds a ticker to the series for its symbol. A ticker without a
+// time is stamped with the current time.
+func (s *Store) AppendTicker(exchangeName, symbol string, ticker models.Ticker) error {
+	if ticker.LastUpdated.IsZero() {
+		ticker.LastUpdated = time.Now()
+	}
+	return appendRecords(s, s.path(exchangeName, symbol, "tickers"), []models.Ticker{ticker},
+		func(t models.Ticker) time.Time { return t.LastUpdated })
+}
+
+// Tickers returns the stored tickers updated within [start, end), oldest first
+func (s *Store) Tickers(exchangeName, symbol string, start, end time.Time) ([]models.Ticker, error) {
+	return queryRecords(s, s.path(exchangeName, symbol, "tickers"), start, end,
+		func(t models.Ticker) time.Time { return t.LastUpdated })
+}
+
+// AppendPrices adds price updates to the series for symbol
+func (s *Store) AppendPrices(exchangeName, symbol string, updates ...models.PriceUpdate) error {
+	return appendRecords(s, s.path(exchangeName, symbol, "prices"), updates,
+		func(u models.PriceUpdate) time.Time { return u.Timestamp })
+}
+
+// Prices returns the stored price updates within [start, end), oldest first
+func (s *Store) Prices(exchangeName, symbol string, start, end time.Time) ([]models.PriceUpdate, error) {
+	return queryRecords(s, s.path(exchangeName, symbol, "prices"), start, end,
+		func(u models.PriceUpdate) time.Time { return u.Timestamp })
+}
+
+// path returns the file holding a series
+func (s *Store) path(exchangeName, symbol, series string) string {
+	return filepath.Join(s.dir, sanitize(exchangeName), sanitize(strings.ToUpper(symbol)), series+".jsonl")
+}
+
+// sanitize makes a name safe to use as a path element
+func sanitize(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+// appendRecords appends the records newer than the last one this process
+// appended to the file; older duplicates are dropped when the series is read
+func appendRecords[T any](s *Store, path string, records []T, at func(T) time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, seen := s.lastAppended[path]; !seen || s.sinceCompaction[path] >= compactEvery {
+		if err := compactRecords(s, path, at); err != nil {
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	last := s.lastAppended[path]
+	for _, r := range records {
+		if !at(r).After(last) {
+			continue
+		}
+		line, err := json.Marshal(r)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", path, err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+		last = at(r)
+	}
+	if buf.Len() == 0 {
+		return nil
+	}
+
+	if err := appendFile(path, buf.Bytes()); err != nil {
+		return err
+	}
+
+	s.lastAppended[path] = last
+	s.sinceCompaction[path] += bytes.Count(buf.Bytes(), []byte{'\n'})
+	return nil
+}
+
+// compactRecords drops the duplicates in an appended series and the records
+// older than the retention, rewriting the file only if that removes any. It
+// also records the newest stored time, so that repeats are not appended.
+// The caller holds s.mu.
+func compactRecords[T any](s *Store, path string, at func(T) time.Time) error {
+	s.sinceCompaction[path] = 0
+
+	records, err := readLines[T](path)
+	if err != nil {
+		return err
+	}
+
+	kept := mergeByTime(nil, records, at)
+	if s.retention > 0 {
+		kept = timeRange(kept, time.Now().Add(-s.retention), time.Time{}, at)
+	}
+
+	last := s.lastAppended[path]
+	if len(kept) > 0 && at(kept[len(kept)-1]).After(last) {
+		last = at(kept[len(kept)-1])
+	}
+	s.lastAppended[path] = last
+
+	if len(kept) == len(records) {
+		return nil
+	}
+	return writeLines(path, kept)
+}
+
+// queryRecords reads an appended series, de-duplicates it and returns the records within [start, end)
+func queryRecords[T any](s *Store, path string, start, end time.Time, at func(T) time.Time) ([]T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records, err := readLines[T](path)
+	if err != nil {
+		return nil, err
+	}
+
+	records = mergeByTime(nil, records, at)
+	return timeRange(records, start, end, at), nil
+}
+
+// readLines reads a JSON Lines file; a missing file is an empty series. Lines
+// that fail to decode, such as one cut short by a crash, are skipped.
+func readLines[T any](path string) ([]T, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var records []T
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var r T
+		if err := json.Unmarshal(scanner.Bytes(), &r); err == nil {
+			records = append(records, r)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return records, nil
+}
+
+// appendLines adds records to the end of a JSON Lines file
+func appendLines[T any](path string, records []T) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, r := range records {
+		if err := encoder.Encode(r); err != nil {
+			return fmt.Errorf("failed to encode %s: %w", path, err)
+		}
+	}
+	return appendFile(path, buf.Bytes())
+}
+
+// appendFile adds data to the end of a file, creating it if needed
+func appendFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create store directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeLines replaces a JSON Lines file atomically
+func writeLines[T any](path string, records []T) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create store directory: %w", err)
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, r := range records {
+		if err := encoder.Encode(r); err != nil {
+			return fmt.Errorf("failed to encode %s: %w", path, err)
+		}
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// mergeByTime combines two series into one ordered by time, keeping one record
+// per time: the last one given, with added taking precedence over stored
+func mergeByTime[T any](stored, added []T, at func(T) time.Time) []T {
+	byTime := make(map[int64]T, len(stored)+len(added))
+	for _, r := range stored {
+		byTime[at(r).UnixNano()] = r
+	}
+	for _, r := range added {
+		byTime[at(r).UnixNano()] = r
+	}
+
+	merged := make([]T, 0, len(byTime))
+	for _, r := range byTime {
+		merged = append(merged, r)
+	}
+
+	sort.Slice(merged, func(i, j int) bool { return at(merged[i]).Before(at(merged[j])) })
+	return merged
+}
+
+// timeRange returns the records of a time-ordered series within [start, end);
+// a zero bound is open
+func timeRange[T any](records []T, start, end time.Time, at func(T) time.Time) []T {
+	from := 0
+	if !start.IsZero() {
+		from = sort.Search(len(records), func(i int) bool { return !at(records[i]).Before(start) })
+	}
+
+	to := len(records)
+	if !end.IsZero() {
+		to = sort.Search(len(records), func(i int) bool { return !at(records[i]).Before(end) })
+	}
+
+	if from >= to {
+		return nil
+	}
+	return records[from:to]
+}
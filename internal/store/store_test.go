@@ -0,0 +1,217 @@
+package store
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Carpe-Wang/terminalCrypto/internal/models"
+)
+
+// minuteCandles returns n one-minute candles opening at start, closing at their index
+func minuteCandles(start time.Time, from, n int) []models.Candle {
+	candles := make([]models.Candle, n)
+	for i := range candles {
+		candles[i] = models.Candle{Time: start.Add(time.Duration(from+i) * time.Minute), Close: float64(from + i)}
+	}
+	return candles
+}
+
+func TestWriteCandles(t *testing.T) {
+	s := New(t.TempDir())
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Consecutive windows, as a backfill writes them
+	for from := 0; from < 30; from += 10 {
+		if err := s.WriteCandles("binance", "BTCUSDT", "1m", minuteCandles(start, from, 10)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// An update of the candle that was still forming, and one new candle
+	update := minuteCandles(start, 29, 2)
+	update[0].Close = 99
+	if err := s.WriteCandles("binance", "BTCUSDT", "1m", update); err != nil {
+		t.Fatal(err)
+	}
+
+	// A fresh store reads back what is on disk
+	for _, store := range []*Store{s, New(s.Dir())} {
+		candles, err := store.Candles("binance", "BTCUSDT", "1m", time.Time{}, time.Time{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(candles) != 31 {
+			t.Fatalf("got %d candles, want 31", len(candles))
+		}
+		for i, c := range candles {
+			want := float64(i)
+			if i == 29 {
+				want = 99
+			}
+			if !c.Time.Equal(start.Add(time.Duration(i)*time.Minute)) || c.Close != want {
+				t.Errorf("candle %d = %v close %v, want close %v", i, c.Time, c.Close, want)
+			}
+		}
+	}
+}
+
+func TestWriteCandlesSeesOtherWriters(t *testing.T) {
+	dir := t.TempDir()
+	a, b := New(dir), New(dir)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if err := a.WriteCandles("okx", "BTC-USDT", "1m", minuteCandles(start, 0, 5)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Candles("okx", "BTC-USDT", "1m", time.Time{}, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.WriteCandles("okx", "BTC-USDT", "1m", minuteCandles(start, 5, 5)); err != nil {
+		t.Fatal(err)
+	}
+
+	candles, err := a.LastCandles("okx", "BTC-USDT", "1m", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candles) != 3 || candles[2].Close != 9 {
+		t.Errorf("got %+v, want the candles the other store wrote", candles)
+	}
+
+	// A removed file is an empty series again
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	if candles, err := a.Candles("okx", "BTC-USDT", "1m", time.Time{}, time.Time{}); err != nil || len(candles) != 0 {
+		t.Errorf("got %d candles, %v; want none", len(candles), err)
+	}
+}
+
+func TestMergeByTime(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(c models.Candle) time.Time { return c.Time }
+	candle := func(minute int, close float64) models.Candle {
+		return models.Candle{Time: start.Add(time.Duration(minute) * time.Minute), Close: close}
+	}
+
+	tests := []struct {
+		name          string
+		stored, added []models.Candle
+		want          []float64
+	}{
+		{"empty", nil, nil, nil},
+		{"added only", nil, []models.Candle{candle(1, 1), candle(0, 0)}, []float64{0, 1}},
+		{"interleaved", []models.Candle{candle(0, 0), candle(2, 2)}, []models.Candle{candle(1, 1), candle(3, 3)}, []float64{0, 1, 2, 3}},
+		{"added replaces stored", []models.Candle{candle(0, 0), candle(1, 1)}, []models.Candle{candle(1, 9)}, []float64{0, 9}},
+		{"last duplicate wins", nil, []models.Candle{candle(0, 1), candle(0, 2)}, []float64{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged := mergeByTime(tt.stored, tt.added, at)
+			if len(merged) != len(tt.want) {
+				t.Fatalf("got %d records, want %d", len(merged), len(tt.want))
+			}
+			for i, c := range merged {
+				if c.Close != tt.want[i] {
+					t.Errorf("record %d close = %v, want %v", i, c.Close, tt.want[i])
+				}
+				if i > 0 && !c.Time.After(merged[i-1].Time) {
+					t.Errorf("record %d is not after record %d", i, i-1)
+				}
+			}
+		})
+	}
+}
+
+func TestTimeRange(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	candles := minuteCandles(start, 0, 10)
+	at := func(c models.Candle) time.Time { return c.Time }
+	minute := func(n int) time.Time { return start.Add(time.Duration(n) * time.Minute) }
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		first, n   int
+	}{
+		{"open", time.Time{}, time.Time{}, 0, 10},
+		{"open start", time.Time{}, minute(3), 0, 3},
+		{"open end", minute(7), time.Time{}, 7, 3},
+		{"inclusive start, exclusive end", minute(2), minute(5), 2, 3},
+		{"between candles", minute(2).Add(time.Second), minute(5).Add(time.Second), 3, 3},
+		{"before the series", minute(-5), minute(0), 0, 0},
+		{"after the series", minute(10), minute(20), 0, 0},
+		{"empty range", minute(5), minute(5), 0, 0},
+		{"reversed range", minute(6), minute(4), 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := timeRange(candles, tt.start, tt.end, at)
+			if len(got) != tt.n {
+				t.Fatalf("got %d candles, want %d", len(got), tt.n)
+			}
+			if tt.n > 0 && got[0].Close != float64(tt.first) {
+				t.Errorf("first candle close = %v, want %v", got[0].Close, tt.first)
+			}
+		})
+	}
+}
+
+func TestRetention(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	// Records left by an earlier run, two of them past the retention
+	old := New(dir)
+	for _, age := range []time.Duration{72 * time.Hour, 48 * time.Hour, time.Hour} {
+		if err := old.AppendPrices("binance", "BTCUSDT", models.PriceUpdate{Symbol: "BTCUSDT", Price: 1, Timestamp: now.Add(-age)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s := New(dir)
+	s.SetRetention(24 * time.Hour)
+
+	// A record no newer than what is stored is not appended again
+	stale := models.PriceUpdate{Symbol: "BTCUSDT", Price: 2, Timestamp: now.Add(-2 * time.Hour)}
+	fresh := models.PriceUpdate{Symbol: "BTCUSDT", Price: 3, Timestamp: now}
+	if err := s.AppendPrices("binance", "BTCUSDT", stale, fresh); err != nil {
+		t.Fatal(err)
+	}
+
+	prices, err := New(dir).Prices("binance", "BTCUSDT", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prices) != 2 || prices[0].Price != 1 || prices[1].Price != 3 {
+		t.Errorf("got %+v, want the hour-old price and the fresh one", prices)
+	}
+}
+
+func TestRetentionDuringLongStream(t *testing.T) {
+	s := New(t.TempDir())
+	s.SetRetention(time.Hour)
+
+	// Records stamped two hours back expire as soon as the file is compacted again
+	start := time.Now().Add(-2 * time.Hour)
+	for i := 0; i < compactEvery; i++ {
+		update := models.PriceUpdate{Symbol: "ETHUSDT", Price: 1, Timestamp: start.Add(time.Duration(i) * time.Millisecond)}
+		if err := s.AppendPrices("okx", "ETH-USDT", update); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.AppendPrices("okx", "ETH-USDT", models.PriceUpdate{Symbol: "ETHUSDT", Price: 2, Timestamp: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	prices, err := s.Prices("okx", "ETH-USDT", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prices) != 1 || prices[0].Price != 2 {
+		t.Errorf("got %d prices, want only the fresh one", len(prices))
+	}
+}